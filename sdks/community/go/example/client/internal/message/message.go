@@ -142,6 +142,9 @@ func getMessageFromEvent(event events.Event) *Message {
 			return nil
 		}
 		var contents []string
+		if delta.PatchFormat() == events.StatePatchMergePatch {
+			contents = append(contents, fmt.Sprintf("%s Merge patch: %s", serverStyle.Render("Server:"), delta.MergePatch))
+		}
 		for _, op := range delta.Delta {
 			currOp := fmt.Sprintf("%s Operation: %s, Path: %s, Value: %s", serverStyle.Render("Server:"), op.Op, op.Path, op.Value)
 			contents = append(contents, currOp)
@@ -7,10 +7,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"strings"
 	"time"
 
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
 	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/types"
 	"github.com/sirupsen/logrus"
 )
@@ -24,6 +26,10 @@ type Config struct {
 	ReadTimeout    time.Duration
 	BufferSize     int
 	Logger         *logrus.Logger
+	// EventLogger receives a debug record for every decoded event and a
+	// warning for every event that fails to decode or validate. By default
+	// nothing is logged.
+	EventLogger *slog.Logger
 }
 
 type Client struct {
@@ -48,6 +54,10 @@ func NewClient(config Config) *Client {
 		config.Logger = logrus.New()
 	}
 
+	if config.EventLogger == nil {
+		config.EventLogger = events.DiscardLogger
+	}
+
 	if config.ConnectTimeout == 0 {
 		config.ConnectTimeout = 30 * time.Second
 	}
@@ -94,9 +104,24 @@ func (c *Client) stream(opts StreamOptions) (<-chan Frame, <-chan error, error)
 		opts.Context = context.Background()
 	}
 
+	resp, err := c.connect(opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	frames := make(chan Frame, c.config.BufferSize)
+	errors := make(chan error, 1)
+
+	go c.readStream(opts.Context, resp, frames, errors)
+
+	return frames, errors, nil
+}
+
+// connect posts the run payload and returns the established SSE response
+func (c *Client) connect(opts StreamOptions) (*http.Response, error) {
 	payloadBytes, err := json.Marshal(opts.Payload)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to marshal payload: %w", err)
+		return nil, fmt.Errorf("failed to marshal payload: %w", err)
 	}
 
 	req, err := http.NewRequestWithContext(
@@ -106,7 +131,7 @@ func (c *Client) stream(opts StreamOptions) (<-chan Frame, <-chan error, error)
 		bytes.NewReader(payloadBytes),
 	)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
@@ -148,19 +173,19 @@ func (c *Client) stream(opts StreamOptions) (<-chan Frame, <-chan error, error)
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to execute request: %w", err)
+		return nil, &retryableError{err: fmt.Errorf("failed to execute request: %w", err)}
 	}
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
 		_ = resp.Body.Close()
-		return nil, nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(body))
+		return nil, &StatusError{StatusCode: resp.StatusCode, Body: string(body)}
 	}
 
 	contentType := resp.Header.Get("Content-Type")
 	if !strings.HasPrefix(contentType, "text/event-stream") {
 		_ = resp.Body.Close()
-		return nil, nil, fmt.Errorf("unexpected content-type: %s", contentType)
+		return nil, fmt.Errorf("unexpected content-type: %s", contentType)
 	}
 
 	if c.logger != nil {
@@ -170,12 +195,7 @@ func (c *Client) stream(opts StreamOptions) (<-chan Frame, <-chan error, error)
 		}).Info("SSE connection established")
 	}
 
-	frames := make(chan Frame, c.config.BufferSize)
-	errors := make(chan error, 1)
-
-	go c.readStream(opts.Context, resp, frames, errors)
-
-	return frames, errors, nil
+	return resp, nil
 }
 
 func (c *Client) readStream(ctx context.Context, resp *http.Response, frames chan<- Frame, errors chan<- error) {
@@ -188,61 +208,66 @@ func (c *Client) readStream(ctx context.Context, resp *http.Response, frames cha
 		}
 	}()
 
-	reader := bufio.NewReader(resp.Body)
 	var buffer bytes.Buffer
 	var frameCount int64
 	var byteCount int64
 	startTime := time.Now()
 
-	// Create a channel for read results
+	// A single goroutine reads lines so that the read timeout can be applied.
+	// It exits once the body is closed or this function has returned, so an
+	// abandoned or timed out stream never leaves it blocked.
 	type readResult struct {
 		line []byte
 		err  error
 	}
 	readCh := make(chan readResult)
+	done := make(chan struct{})
+	defer close(done)
 
-	for {
-		select {
-		case <-ctx.Done():
-			if c.logger != nil {
-				c.logger.WithField("reason", "context cancelled").Debug("Stopping SSE stream")
-			}
-			return
-		default:
-		}
-
-		// Start async read
-		go func() {
+	go func() {
+		reader := bufio.NewReader(resp.Body)
+		for {
 			line, err := reader.ReadBytes('\n')
 			select {
 			case readCh <- readResult{line: line, err: err}:
-			case <-ctx.Done():
+			case <-done:
+				return
 			}
-		}()
+			if err != nil {
+				return
+			}
+		}
+	}()
 
+	var timeout <-chan time.Time
+	var timer *time.Timer
+	if c.config.ReadTimeout > 0 {
+		timer = time.NewTimer(c.config.ReadTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	for {
 		// Wait for read result with timeout
+		if timer != nil {
+			timer.Reset(c.config.ReadTimeout)
+		}
+
 		var result readResult
-		if c.config.ReadTimeout > 0 {
+		select {
+		case result = <-readCh:
+			// Got result
+		case <-timeout:
 			select {
-			case result = <-readCh:
-				// Got result
-			case <-time.After(c.config.ReadTimeout):
-				// Timeout occurred
-				select {
-				case errors <- fmt.Errorf("read timeout after %v", c.config.ReadTimeout):
-				case <-ctx.Done():
-				}
-				return
+			case errors <- fmt.Errorf("read timeout after %v", c.config.ReadTimeout):
 			case <-ctx.Done():
-				return
 			}
-		} else {
-			select {
-			case result = <-readCh:
-				// Got result
-			case <-ctx.Done():
-				return
+			return
+		case <-ctx.Done():
+			if c.logger != nil {
+				c.logger.WithField("reason", "context cancelled").Debug("Stopping SSE stream")
 			}
+			return
 		}
 
 		if result.err != nil {
@@ -304,6 +329,23 @@ func (c *Client) readStream(ctx context.Context, resp *http.Response, frames cha
 	}
 }
 
+// StatusError is returned when the endpoint responds with a non-200 status
+type StatusError struct {
+	StatusCode int
+	Body       string
+}
+
+// Error implements the error interface
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected status code %d: %s", e.StatusCode, e.Body)
+}
+
+// Temporary reports whether retrying the request may succeed, which is the
+// case for server errors but not for client errors
+func (e *StatusError) Temporary() bool {
+	return e.StatusCode >= 500
+}
+
 func (c *Client) Close() error {
 	c.httpClient.CloseIdleConnections()
 	return nil
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/types"
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/testutil"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -750,3 +751,47 @@ func BenchmarkReadStream(b *testing.B) {
 		}
 	}
 }
+
+func TestStreamReadTimeoutReleasesGoroutines(t *testing.T) {
+	testutil.VerifyNoGoroutineLeaks(t)
+
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprintf(w, "data: initial\n\n")
+		w.(http.Flusher).Flush()
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewClient(Config{
+		Endpoint:    server.URL,
+		ReadTimeout: 50 * time.Millisecond,
+		Logger:      logrus.New(),
+	})
+	defer client.Close()
+
+	// The context is never cancelled, so only the timeout ends the stream
+	frames, errs, err := client.Stream(StreamOptions{
+		Context: context.Background(),
+		Payload: newTestRunAgentInput(),
+	})
+	require.NoError(t, err)
+
+	frame := <-frames
+	assert.Equal(t, "initial", string(frame.Data))
+
+	select {
+	case err := <-errs:
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "read timeout")
+	case <-time.After(2 * time.Second):
+		require.FailNow(t, "timeout waiting for read timeout error")
+	}
+	_, ok := <-frames
+	assert.False(t, ok)
+}
@@ -0,0 +1,408 @@
+package sse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/types"
+	ssecodec "github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/encoding/sse"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ErrStreamIncomplete is delivered when the server closes the stream before
+// sending RUN_FINISHED or RUN_ERROR
+var ErrStreamIncomplete = errors.New("event stream ended before the run finished")
+
+// Errors delivered when a run is cancelled by WithRunTimeout or WithIdleTimeout
+var (
+	ErrRunTimeout  = errors.New("run did not finish before its timeout")
+	ErrIdleTimeout = errors.New("no event received before the idle timeout")
+)
+
+// RunOption defines options for running agents
+type RunOption func(*runConfig)
+
+// runConfig holds the settings applied by RunOption values
+type runConfig struct {
+	maxRetries  int
+	backoff     time.Duration
+	runTimeout  time.Duration
+	idleTimeout time.Duration
+	tracer      trace.Tracer
+	// closeClient closes the client's idle connections once the run ends
+	closeClient bool
+}
+
+// WithReconnect retries transient failures up to maxRetries consecutive
+// times, waiting backoff before the first retry and doubling the wait after
+// each further failure, up to a minute. Connection errors, 5xx responses and
+// streams that break before the run finished are retried; when the server
+// sent event IDs, the last one is passed in the Last-Event-ID header so that
+// it can resume the stream. Client errors (4xx) and malformed events are not
+// retried.
+func WithReconnect(maxRetries int, backoff time.Duration) RunOption {
+	return func(c *runConfig) {
+		c.maxRetries = maxRetries
+		c.backoff = backoff
+	}
+}
+
+// WithRunTimeout cancels the run when no RUN_FINISHED or RUN_ERROR arrives
+// within d of calling RunAgent, closing the connection and delivering an
+// error wrapping ErrRunTimeout. The time spent connecting and reconnecting
+// counts towards the timeout.
+func WithRunTimeout(d time.Duration) RunOption {
+	return func(c *runConfig) {
+		c.runTimeout = d
+	}
+}
+
+// WithIdleTimeout cancels the run when the server goes d without sending an
+// event, closing the connection and delivering an error wrapping
+// ErrIdleTimeout. The timer starts once connected and restarts after every
+// event is delivered and on every reconnection. It is paused while an event
+// waits for the consumer, so a slow reader does not count as a quiet server.
+func WithIdleTimeout(d time.Duration) RunOption {
+	return func(c *runConfig) {
+		c.idleTimeout = d
+	}
+}
+
+// watchdog cancels a run whose timeouts expire
+type watchdog struct {
+	idle      time.Duration
+	runTimer  *time.Timer
+	idleTimer *time.Timer
+}
+
+// newWatchdog starts the run timeout. The idle timer starts with the first
+// call to touch.
+func newWatchdog(cfg *runConfig, cancel context.CancelCauseFunc) *watchdog {
+	w := &watchdog{idle: cfg.idleTimeout}
+	if cfg.runTimeout > 0 {
+		w.runTimer = time.AfterFunc(cfg.runTimeout, func() { cancel(ErrRunTimeout) })
+	}
+	if cfg.idleTimeout > 0 {
+		w.idleTimer = time.AfterFunc(cfg.idleTimeout, func() { cancel(ErrIdleTimeout) })
+		w.idleTimer.Stop()
+	}
+	return w
+}
+
+// touch restarts the idle timer
+func (w *watchdog) touch() {
+	if w.idleTimer != nil {
+		w.idleTimer.Reset(w.idle)
+	}
+}
+
+// pause stops the idle timer until the next call to touch
+func (w *watchdog) pause() {
+	if w.idleTimer != nil {
+		w.idleTimer.Stop()
+	}
+}
+
+// stop disarms both timers
+func (w *watchdog) stop() {
+	if w.runTimer != nil {
+		w.runTimer.Stop()
+	}
+	if w.idleTimer != nil {
+		w.idleTimer.Stop()
+	}
+}
+
+// timeoutCause returns the timeout error that cancelled ctx, or nil when ctx
+// is live or was cancelled for another reason
+func timeoutCause(ctx context.Context) error {
+	if cause := context.Cause(ctx); errors.Is(cause, ErrRunTimeout) || errors.Is(cause, ErrIdleTimeout) {
+		return cause
+	}
+	return nil
+}
+
+// retryableError marks failures that a reconnect may recover from
+type retryableError struct {
+	err error
+}
+
+// Error implements the error interface
+func (e *retryableError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the underlying error
+func (e *retryableError) Unwrap() error {
+	return e.err
+}
+
+// isRetryable reports whether err is a transient connection or stream failure
+func isRetryable(err error) bool {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.Temporary()
+	}
+	var transient *retryableError
+	return errors.As(err, &transient)
+}
+
+// readTracker records the error returned by the underlying body, so that
+// transport failures can be told apart from malformed events
+type readTracker struct {
+	r   io.Reader
+	err error
+}
+
+// Read implements io.Reader
+func (t *readTracker) Read(p []byte) (int, error) {
+	n, err := t.r.Read(p)
+	if err != nil {
+		t.err = err
+	}
+	return n, err
+}
+
+// RunAgent posts input to the configured endpoint and streams the decoded
+// events. The event channel closes after RUN_FINISHED or RUN_ERROR, when the
+// stream fails, or when ctx is cancelled. A failure, including a stream that
+// ends before the run finished, is sent on the error channel before both
+// channels close. Errors establishing the first connection are returned
+// directly.
+func (c *Client) RunAgent(ctx context.Context, input types.RunAgentInput, options ...RunOption) (<-chan events.Event, <-chan error, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	cfg := &runConfig{}
+	for _, opt := range options {
+		opt(cfg)
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	timeouts := newWatchdog(cfg, cancel)
+	ctx, tracing := startRunTracer(ctx, cfg.tracer, input)
+
+	failures := 0
+	resp, err := c.connectWithRetry(ctx, input, "", cfg, &failures)
+	if err != nil {
+		timeouts.stop()
+		cancel(nil)
+		if cause := timeoutCause(ctx); cause != nil {
+			err = fmt.Errorf("run %s: %w", input.RunID, cause)
+		}
+		tracing.fail(err)
+		tracing.end()
+		if cfg.closeClient {
+			_ = c.Close()
+		}
+		return nil, nil, err
+	}
+
+	out := make(chan events.Event, c.config.BufferSize)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer func() {
+			timeouts.stop()
+			cancel(nil)
+			tracing.end()
+			close(out)
+			close(errs)
+			if cfg.closeClient {
+				_ = c.Close()
+			}
+		}()
+		fail := func(err error) {
+			tracing.fail(err)
+			errs <- err
+		}
+
+		lastEventID := ""
+		for {
+			timeouts.touch()
+			body := &readTracker{r: resp.Body}
+			decoder := ssecodec.NewDecoder(body,
+				ssecodec.WithCompression(resp.Header.Get("Content-Encoding")),
+				ssecodec.WithDecoderLogger(c.config.EventLogger))
+
+			finished, err := c.forwardEvents(ctx, decoder, body, out, &failures, timeouts, tracing)
+			if id := decoder.LastEventID(); id != "" {
+				lastEventID = id
+			}
+			_ = resp.Body.Close()
+			if finished {
+				return
+			}
+			if ctx.Err() != nil {
+				if cause := timeoutCause(ctx); cause != nil {
+					fail(fmt.Errorf("run %s: %w", input.RunID, cause))
+				} else {
+					tracing.fail(ctx.Err())
+				}
+				return
+			}
+
+			if !isRetryable(err) || failures >= cfg.maxRetries {
+				fail(fmt.Errorf("run %s: %w", input.RunID, err))
+				return
+			}
+			failures++
+
+			if c.logger != nil {
+				c.logger.WithFields(logrus.Fields{
+					"error":         err,
+					"last_event_id": lastEventID,
+				}).Warn("SSE stream interrupted, reconnecting")
+			}
+
+			// The idle timeout only measures a connected server
+			timeouts.pause()
+			resp, err = c.connectWithRetry(ctx, input, lastEventID, cfg, &failures)
+			if err != nil {
+				if cause := timeoutCause(ctx); cause != nil {
+					fail(fmt.Errorf("run %s: %w", input.RunID, cause))
+				} else if ctx.Err() == nil {
+					fail(fmt.Errorf("run %s: %w", input.RunID, err))
+				} else {
+					tracing.fail(ctx.Err())
+				}
+				return
+			}
+		}
+	}()
+
+	return out, errs, nil
+}
+
+// RunAgentResult runs an agent to completion for request/response style
+// agents and returns its RUN_FINISHED event, whose Into method decodes the
+// result. Other events are passed to handle when it is not nil. A RUN_ERROR
+// is returned as the error from RunErrorEvent.AsError, and stream failures as
+// on the error channel of RunAgent.
+func (c *Client) RunAgentResult(ctx context.Context, input types.RunAgentInput, handle func(events.Event), options ...RunOption) (*events.RunFinishedEvent, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, errs, err := c.RunAgent(ctx, input, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	for event := range stream {
+		switch e := event.(type) {
+		case *events.RunFinishedEvent:
+			return e, nil
+		case *events.RunErrorEvent:
+			return nil, e.AsError()
+		}
+		if handle != nil {
+			handle(event)
+		}
+	}
+
+	if err := <-errs; err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf("run %s: %w", input.RunID, ErrStreamIncomplete)
+}
+
+// forwardEvents sends decoded events to out until the run finishes, the
+// stream fails or ctx is done, which interrupts a read that is waiting for
+// the server. Receiving an event resets the consecutive failure count and
+// the idle timeout.
+func (c *Client) forwardEvents(ctx context.Context, decoder *ssecodec.Decoder, body *readTracker, out chan<- events.Event, failures *int, timeouts *watchdog, tracing *runTracer) (bool, error) {
+	for {
+		event, err := decoder.NextContext(ctx)
+		if err != nil {
+			switch {
+			case errors.Is(err, io.EOF):
+				return false, &retryableError{err: ErrStreamIncomplete}
+			case body.err != nil && !errors.Is(body.err, io.EOF):
+				return false, &retryableError{err: err}
+			default:
+				return false, err
+			}
+		}
+		*failures = 0
+		tracing.observe(event)
+
+		timeouts.pause()
+		select {
+		case out <- event:
+		case <-ctx.Done():
+			return false, ctx.Err()
+		}
+		timeouts.touch()
+
+		switch event.Type() {
+		case events.EventTypeRunFinished, events.EventTypeRunError:
+			return true, nil
+		}
+	}
+}
+
+// connectWithRetry connects, retrying transient failures with exponential
+// backoff while the consecutive failure count stays within the limit
+func (c *Client) connectWithRetry(ctx context.Context, input types.RunAgentInput, lastEventID string, cfg *runConfig, failures *int) (*http.Response, error) {
+	for {
+		if *failures > 0 {
+			select {
+			case <-time.After(reconnectDelay(cfg.backoff, *failures)):
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+
+		opts := StreamOptions{Context: ctx, Payload: input}
+		if lastEventID != "" {
+			opts.Headers = map[string]string{"Last-Event-ID": lastEventID}
+		}
+
+		resp, err := c.connect(opts)
+		if err == nil {
+			return resp, nil
+		}
+		if ctx.Err() != nil || !isRetryable(err) || *failures >= cfg.maxRetries {
+			return nil, err
+		}
+		*failures++
+	}
+}
+
+// maxReconnectBackoff caps the doubled wait between reconnection attempts
+const maxReconnectBackoff = time.Minute
+
+// reconnectDelay returns the wait before the retry that follows the given
+// number of consecutive failures
+func reconnectDelay(backoff time.Duration, failures int) time.Duration {
+	if backoff <= 0 {
+		return 0
+	}
+	delay := backoff
+	for i := 1; i < failures && delay < maxReconnectBackoff; i++ {
+		delay *= 2
+	}
+	return max(backoff, min(delay, maxReconnectBackoff))
+}
+
+// RunAgent runs an agent at url with a default client; see Client.RunAgent.
+// The client's connections are closed once the run ends.
+func RunAgent(ctx context.Context, url string, input types.RunAgentInput, options ...RunOption) (<-chan events.Event, <-chan error, error) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	options = append(options[:len(options):len(options)], func(c *runConfig) { c.closeClient = true })
+	return NewClient(Config{Endpoint: url, Logger: logger}).RunAgent(ctx, input, options...)
+}
@@ -0,0 +1,499 @@
+package sse
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/types"
+	ssecodec "github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/encoding/sse"
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newEventServer serves the given events as an SSE stream and records the request input
+func newEventServer(t *testing.T, received *types.RunAgentInput, evts ...events.Event) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "text/event-stream", r.Header.Get("Accept"))
+		if received != nil {
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(received))
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		enc := ssecodec.NewEncoder(w)
+		for _, event := range evts {
+			assert.NoError(t, enc.Encode(event))
+			w.(http.Flusher).Flush()
+		}
+	}))
+}
+
+// collect drains the event and error channels
+func collect(t *testing.T, out <-chan events.Event, errs <-chan error) ([]events.Event, error) {
+	var collected []events.Event
+	timeout := time.After(2 * time.Second)
+	for out != nil || errs != nil {
+		select {
+		case event, ok := <-out:
+			if !ok {
+				out = nil
+				continue
+			}
+			collected = append(collected, event)
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+			return collected, err
+		case <-timeout:
+			t.Fatal("timed out waiting for the stream to close")
+		}
+	}
+	return collected, nil
+}
+
+func TestRunAgent(t *testing.T) {
+	var received types.RunAgentInput
+	server := newEventServer(t, &received,
+		events.NewRunStartedEvent("thread-1", "run-1"),
+		events.NewTextMessageStartEvent("msg-1", events.WithRole("assistant")),
+		events.NewTextMessageContentEvent("msg-1", "Hello"),
+		events.NewTextMessageEndEvent("msg-1"),
+		events.NewRunFinishedEvent("thread-1", "run-1"),
+		// Anything after the terminal event is ignored
+		events.NewRunStartedEvent("thread-1", "run-2"),
+	)
+	defer server.Close()
+
+	out, errs, err := RunAgent(context.Background(), server.URL, newTestRunAgentInput())
+	require.NoError(t, err)
+
+	collected, err := collect(t, out, errs)
+	require.NoError(t, err)
+	require.Len(t, collected, 5)
+	assert.Equal(t, events.EventTypeRunStarted, collected[0].Type())
+	assert.Equal(t, "Hello", collected[2].(*events.TextMessageContentEvent).Delta)
+	assert.Equal(t, events.EventTypeRunFinished, collected[4].Type())
+	assert.Equal(t, "run-1", received.RunID)
+}
+
+func TestRunAgent_ClosesConnections(t *testing.T) {
+	// The default client must not leave idle keep-alive connections behind
+	for name, handler := range map[string]http.HandlerFunc{
+		"Finished": func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/event-stream")
+			enc := ssecodec.NewEncoder(w)
+			assert.NoError(t, enc.Encode(events.NewRunStartedEvent("thread-1", "run-1")))
+			assert.NoError(t, enc.Encode(events.NewRunFinishedEvent("thread-1", "run-1")))
+		},
+		"Rejected": func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "nope", http.StatusUnauthorized)
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var closed atomic.Int32
+			server := httptest.NewUnstartedServer(handler)
+			server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+				if state == http.StateClosed {
+					closed.Add(1)
+				}
+			}
+			server.Start()
+			defer server.Close()
+
+			out, errs, err := RunAgent(context.Background(), server.URL, newTestRunAgentInput())
+			if err == nil {
+				_, err = collect(t, out, errs)
+				require.NoError(t, err)
+			}
+			assert.Eventually(t, func() bool { return closed.Load() == 1 }, time.Second, 10*time.Millisecond)
+		})
+	}
+}
+
+func TestRunAgent_RunError(t *testing.T) {
+	server := newEventServer(t, nil,
+		events.NewRunStartedEvent("thread-1", "run-1"),
+		events.NewRunErrorEvent("boom"),
+	)
+	defer server.Close()
+
+	out, errs, err := RunAgent(context.Background(), server.URL, newTestRunAgentInput())
+	require.NoError(t, err)
+
+	collected, err := collect(t, out, errs)
+	require.NoError(t, err)
+	require.Len(t, collected, 2)
+	assert.Equal(t, events.EventTypeRunError, collected[1].Type())
+}
+
+func TestRunAgent_IncompleteStream(t *testing.T) {
+	server := newEventServer(t, nil, events.NewRunStartedEvent("thread-1", "run-1"))
+	defer server.Close()
+
+	out, errs, err := RunAgent(context.Background(), server.URL, newTestRunAgentInput())
+	require.NoError(t, err)
+
+	collected, err := collect(t, out, errs)
+	assert.Len(t, collected, 1)
+	assert.ErrorIs(t, err, ErrStreamIncomplete)
+}
+
+func TestRunAgent_ConnectionErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "nope", http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	_, _, err := RunAgent(context.Background(), server.URL, newTestRunAgentInput())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "401")
+}
+
+func TestRunAgent_Cancel(t *testing.T) {
+	testutil.VerifyNoGoroutineLeaks(t)
+
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_ = ssecodec.NewEncoder(w).Encode(events.NewRunStartedEvent("thread-1", "run-1"))
+		w.(http.Flusher).Flush()
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	out, errs, err := RunAgent(ctx, server.URL, newTestRunAgentInput())
+	require.NoError(t, err)
+
+	first := <-out
+	assert.Equal(t, events.EventTypeRunStarted, first.Type())
+	cancel()
+
+	collected, err := collect(t, out, errs)
+	assert.NoError(t, err)
+	assert.Empty(t, collected)
+}
+
+// traceKey is a context key standing in for a tracing span
+type traceKey struct{}
+
+// traceHandler records the trace value of the context of every log record
+type traceHandler struct {
+	slog.Handler
+	traces chan any
+}
+
+// Handle implements slog.Handler
+func (h *traceHandler) Handle(ctx context.Context, _ slog.Record) error {
+	h.traces <- ctx.Value(traceKey{})
+	return nil
+}
+
+func TestRunAgent_PropagatesContext(t *testing.T) {
+	server := newEventServer(t, nil,
+		events.NewRunStartedEvent("thread-1", "run-1"),
+		events.NewRunFinishedEvent("thread-1", "run-1"),
+	)
+	defer server.Close()
+
+	handler := &traceHandler{
+		Handler: slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelDebug}),
+		traces:  make(chan any, 10),
+	}
+	client := NewClient(Config{Endpoint: server.URL, EventLogger: slog.New(handler)})
+	ctx := context.WithValue(context.Background(), traceKey{}, "span-1")
+	out, errs, err := client.RunAgent(ctx, newTestRunAgentInput())
+	require.NoError(t, err)
+
+	collected, err := collect(t, out, errs)
+	require.NoError(t, err)
+	require.Len(t, collected, 2)
+
+	close(handler.traces)
+	var traces []any
+	for trace := range handler.traces {
+		traces = append(traces, trace)
+	}
+	assert.Equal(t, []any{"span-1", "span-1"}, traces)
+}
+
+func TestRunAgent_Timeouts(t *testing.T) {
+	testutil.VerifyNoGoroutineLeaks(t)
+
+	// The server starts a run and then sends a chunk every interval, or
+	// nothing when interval is zero, until the client disconnects
+	newServer := func(interval time.Duration, disconnected chan<- struct{}) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer close(disconnected)
+			w.Header().Set("Content-Type", "text/event-stream")
+			enc := ssecodec.NewEncoder(w)
+			_ = enc.Encode(events.NewRunStartedEvent("thread-1", "run-1"))
+			w.(http.Flusher).Flush()
+
+			var tick <-chan time.Time
+			if interval > 0 {
+				ticker := time.NewTicker(interval)
+				defer ticker.Stop()
+				tick = ticker.C
+			}
+			for {
+				select {
+				case <-tick:
+					_ = enc.Encode(events.NewTextMessageContentEvent("msg-1", "."))
+				case <-r.Context().Done():
+					return
+				}
+			}
+		}))
+	}
+
+	cases := []struct {
+		name     string
+		interval time.Duration
+		options  []RunOption
+		expected error
+	}{
+		{"RunTimeout", 10 * time.Millisecond, []RunOption{WithRunTimeout(150 * time.Millisecond), WithIdleTimeout(100 * time.Millisecond)}, ErrRunTimeout},
+		{"IdleTimeout", 0, []RunOption{WithRunTimeout(time.Minute), WithIdleTimeout(100 * time.Millisecond)}, ErrIdleTimeout},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			disconnected := make(chan struct{})
+			server := newServer(tc.interval, disconnected)
+			defer server.Close()
+
+			out, errs, err := RunAgent(context.Background(), server.URL, newTestRunAgentInput(), tc.options...)
+			require.NoError(t, err)
+
+			collected, err := collect(t, out, errs)
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, tc.expected), "unexpected error: %v", err)
+			assert.NotEmpty(t, collected)
+
+			select {
+			case <-disconnected:
+			case <-time.After(time.Second):
+				t.Fatal("connection was not closed")
+			}
+		})
+	}
+
+	t.Run("FinishedRunStopsTimers", func(t *testing.T) {
+		server := newEventServer(t, nil,
+			events.NewRunStartedEvent("thread-1", "run-1"),
+			events.NewRunFinishedEvent("thread-1", "run-1"))
+		defer server.Close()
+
+		out, errs, err := RunAgent(context.Background(), server.URL, newTestRunAgentInput(),
+			WithRunTimeout(50*time.Millisecond), WithIdleTimeout(50*time.Millisecond))
+		require.NoError(t, err)
+		collected, err := collect(t, out, errs)
+		assert.NoError(t, err)
+		assert.Len(t, collected, 2)
+	})
+
+	t.Run("BackoffIsNotIdle", func(t *testing.T) {
+		server := testutil.NewMockServer()
+		defer server.Close()
+		server.Script(events.NewRunStartedEvent("thread-1", "run-1")).
+			Drop().
+			Script(events.NewRunFinishedEvent("thread-1", "run-1"))
+
+		out, errs, err := RunAgent(context.Background(), server.URL, newTestRunAgentInput(),
+			WithReconnect(3, 300*time.Millisecond), WithIdleTimeout(100*time.Millisecond))
+		require.NoError(t, err)
+		collected, err := collect(t, out, errs)
+		require.NoError(t, err)
+		assert.Equal(t, events.EventTypeRunFinished, collected[len(collected)-1].Type())
+		assert.Len(t, server.Requests(), 2)
+	})
+
+	t.Run("SlowConsumerIsNotIdle", func(t *testing.T) {
+		server := newEventServer(t, nil,
+			events.NewRunStartedEvent("thread-1", "run-1"),
+			events.NewTextMessageContentEvent("msg-1", "a"),
+			events.NewTextMessageContentEvent("msg-1", "b"),
+			events.NewRunFinishedEvent("thread-1", "run-1"))
+		defer server.Close()
+
+		client := NewClient(Config{Endpoint: server.URL, BufferSize: 1})
+		out, errs, err := client.RunAgent(context.Background(), newTestRunAgentInput(), WithIdleTimeout(50*time.Millisecond))
+		require.NoError(t, err)
+
+		// Each event takes the consumer longer than the idle timeout
+		var collected []events.Event
+		for event := range out {
+			time.Sleep(100 * time.Millisecond)
+			collected = append(collected, event)
+		}
+		assert.NoError(t, <-errs)
+		assert.Len(t, collected, 4)
+	})
+}
+
+func TestRunAgent_ReconnectsWithLastEventID(t *testing.T) {
+	var attempts atomic.Int32
+	var resumedFrom atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch attempts.Add(1) {
+		case 1:
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, "id: 1\ndata: {\"type\":\"RUN_STARTED\",\"threadId\":\"thread-1\",\"runId\":\"run-1\"}\n\n")
+			fmt.Fprint(w, "id: 2\ndata: {\"type\":\"STEP_STARTED\",\"stepName\":\"plan\"}\n\n")
+			// Connection drops before the run finishes
+		case 2:
+			http.Error(w, "overloaded", http.StatusServiceUnavailable)
+		default:
+			resumedFrom.Store(r.Header.Get("Last-Event-ID"))
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, "id: 3\ndata: {\"type\":\"STEP_FINISHED\",\"stepName\":\"plan\"}\n\n")
+			fmt.Fprint(w, "id: 4\ndata: {\"type\":\"RUN_FINISHED\",\"threadId\":\"thread-1\",\"runId\":\"run-1\"}\n\n")
+		}
+	}))
+	defer server.Close()
+
+	out, errs, err := RunAgent(context.Background(), server.URL, newTestRunAgentInput(), WithReconnect(3, time.Millisecond))
+	require.NoError(t, err)
+
+	collected, err := collect(t, out, errs)
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), attempts.Load())
+	assert.Equal(t, "2", resumedFrom.Load())
+
+	types := make([]events.EventType, len(collected))
+	for i, event := range collected {
+		types[i] = event.Type()
+	}
+	assert.Equal(t, []events.EventType{
+		events.EventTypeRunStarted,
+		events.EventTypeStepStarted,
+		events.EventTypeStepFinished,
+		events.EventTypeRunFinished,
+	}, types)
+}
+
+func TestRunAgent_ReconnectLimits(t *testing.T) {
+	t.Run("ClientErrorsAreNotRetried", func(t *testing.T) {
+		var attempts atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts.Add(1)
+			http.Error(w, "bad input", http.StatusBadRequest)
+		}))
+		defer server.Close()
+
+		_, _, err := RunAgent(context.Background(), server.URL, newTestRunAgentInput(), WithReconnect(3, time.Millisecond))
+		var statusErr *StatusError
+		require.ErrorAs(t, err, &statusErr)
+		assert.Equal(t, http.StatusBadRequest, statusErr.StatusCode)
+		assert.Equal(t, int32(1), attempts.Load())
+	})
+
+	t.Run("EventsResetFailureCount", func(t *testing.T) {
+		var attempts atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts.Add(1)
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, "data: {\"type\":\"RUN_STARTED\",\"threadId\":\"thread-1\",\"runId\":\"run-1\"}\n\n")
+		}))
+		defer server.Close()
+
+		// Each attempt delivers an event, so consecutive failures never
+		// accumulate; cap the run with a context instead
+		ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+		defer cancel()
+		out, errs, err := RunAgent(ctx, server.URL, newTestRunAgentInput(), WithReconnect(1, 10*time.Millisecond))
+		require.NoError(t, err)
+		_, err = collect(t, out, errs)
+		assert.NoError(t, err)
+		assert.Greater(t, attempts.Load(), int32(2))
+	})
+
+	t.Run("ServerErrorsExhaustRetries", func(t *testing.T) {
+		var attempts atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts.Add(1)
+			http.Error(w, "down", http.StatusBadGateway)
+		}))
+		defer server.Close()
+
+		_, _, err := RunAgent(context.Background(), server.URL, newTestRunAgentInput(), WithReconnect(2, time.Millisecond))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "502")
+		assert.Equal(t, int32(3), attempts.Load())
+	})
+
+	t.Run("BackoffIsCapped", func(t *testing.T) {
+		assert.Equal(t, 10*time.Millisecond, reconnectDelay(10*time.Millisecond, 1))
+		assert.Equal(t, 40*time.Millisecond, reconnectDelay(10*time.Millisecond, 3))
+		assert.Equal(t, maxReconnectBackoff, reconnectDelay(time.Second, 10))
+		assert.Equal(t, maxReconnectBackoff, reconnectDelay(time.Second, 1000))
+		assert.Equal(t, 2*time.Hour, reconnectDelay(2*time.Hour, 5))
+		assert.Zero(t, reconnectDelay(0, 5))
+	})
+}
+
+func TestRunAgentResult(t *testing.T) {
+	client := func(url string) *Client {
+		return NewClient(Config{Endpoint: url})
+	}
+
+	t.Run("Result", func(t *testing.T) {
+		server := newEventServer(t, nil,
+			events.NewRunStartedEvent("thread-1", "run-1"),
+			events.NewTextMessageContentEvent("msg-1", "working"),
+			events.NewRunFinishedEventWithOptions("thread-1", "run-1", events.WithResult(map[string]any{"answer": 42})),
+		)
+		defer server.Close()
+
+		var handled []events.EventType
+		finished, err := client(server.URL).RunAgentResult(context.Background(), newTestRunAgentInput(), func(event events.Event) {
+			handled = append(handled, event.Type())
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []events.EventType{events.EventTypeRunStarted, events.EventTypeTextMessageContent}, handled)
+
+		var result struct {
+			Answer int `json:"answer"`
+		}
+		require.NoError(t, finished.Into(&result))
+		assert.Equal(t, 42, result.Answer)
+	})
+
+	t.Run("RunError", func(t *testing.T) {
+		server := newEventServer(t, nil,
+			events.NewRunStartedEvent("thread-1", "run-1"),
+			events.NewRunErrorEvent("took too long", events.WithErrorCode(events.RunErrorCodeTimeout)),
+		)
+		defer server.Close()
+
+		_, err := client(server.URL).RunAgentResult(context.Background(), newTestRunAgentInput(), nil)
+		assert.ErrorIs(t, err, events.ErrRunTimeout)
+	})
+
+	t.Run("IncompleteStream", func(t *testing.T) {
+		server := newEventServer(t, nil, events.NewRunStartedEvent("thread-1", "run-1"))
+		defer server.Close()
+
+		_, err := client(server.URL).RunAgentResult(context.Background(), newTestRunAgentInput(), nil)
+		assert.ErrorIs(t, err, ErrStreamIncomplete)
+	})
+}
@@ -0,0 +1,155 @@
+package sse
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/types"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// Names of the spans created by WithTracer
+const (
+	RunSpanName      = "ag-ui.run"
+	ToolCallSpanName = "ag-ui.tool_call"
+)
+
+// Attributes set on the spans created by WithTracer
+const (
+	AttrThreadID     = attribute.Key("ag_ui.thread_id")
+	AttrRunID        = attribute.Key("ag_ui.run_id")
+	AttrToolCallID   = attribute.Key("ag_ui.tool_call.id")
+	AttrToolCallName = attribute.Key("ag_ui.tool_call.name")
+	AttrErrorCode    = attribute.Key("ag_ui.error.code")
+)
+
+// WithTracer traces the run with tracer. A client span covers the whole run,
+// including reconnections, and carries the thread and run IDs; it ends with
+// RUN_FINISHED, or with an error status on RUN_ERROR or a failed stream. Each
+// tool call gets a child span from TOOL_CALL_START to TOOL_CALL_END. The run
+// span is in the context of the HTTP requests and of the records passed to
+// Config.EventLogger, so instrumented transports and log handlers are
+// correlated with it. Without a tracer no spans are created.
+func WithTracer(tracer trace.Tracer) RunOption {
+	return func(c *runConfig) {
+		c.tracer = tracer
+	}
+}
+
+// runTracer records the spans of a single run
+type runTracer struct {
+	ctx       context.Context
+	span      trace.Span
+	tracer    trace.Tracer
+	toolCalls map[string]trace.Span
+	// indexIDs maps the stream index of a tool call to its ID once known
+	indexIDs map[int]string
+}
+
+// startRunTracer starts the run span, returning the context carrying it
+func startRunTracer(ctx context.Context, tracer trace.Tracer, input types.RunAgentInput) (context.Context, *runTracer) {
+	if tracer == nil {
+		tracer = noop.NewTracerProvider().Tracer("")
+	}
+	ctx, span := tracer.Start(ctx, RunSpanName,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(AttrThreadID.String(input.ThreadID), AttrRunID.String(input.RunID)))
+	return ctx, &runTracer{
+		ctx:       ctx,
+		span:      span,
+		tracer:    tracer,
+		toolCalls: make(map[string]trace.Span),
+		indexIDs:  make(map[int]string),
+	}
+}
+
+// observe updates the spans for an event that is about to be delivered
+func (r *runTracer) observe(event events.Event) {
+	switch e := event.(type) {
+	case *events.ToolCallStartEvent:
+		attrs := []attribute.KeyValue{AttrToolCallName.String(e.ToolCallName)}
+		if e.ToolCallID != "" {
+			attrs = append(attrs, AttrToolCallID.String(e.ToolCallID))
+		}
+		_, span := r.tracer.Start(r.ctx, ToolCallSpanName, trace.WithAttributes(attrs...))
+		if e.Index != nil {
+			delete(r.indexIDs, *e.Index)
+		}
+		r.toolCalls[toolCallSpanKey(e.ToolCallID, e.Index)] = span
+
+	case *events.ToolCallArgsEvent:
+		r.assignToolCallID(e.ToolCallID, e.Index)
+
+	case *events.ToolCallEndEvent:
+		r.assignToolCallID(e.ToolCallID, e.Index)
+		key := r.toolCallKey(e.ToolCallID, e.Index)
+		if e.Index != nil {
+			delete(r.indexIDs, *e.Index)
+		}
+		if span, ok := r.toolCalls[key]; ok {
+			delete(r.toolCalls, key)
+			span.End()
+		}
+
+	case *events.RunErrorEvent:
+		if e.Code != nil {
+			r.span.SetAttributes(AttrErrorCode.String(*e.Code))
+		}
+		r.span.SetStatus(codes.Error, e.Message)
+	}
+}
+
+// assignToolCallID moves the span of a tool call started by stream index
+// to its ID once an event carries both
+func (r *runTracer) assignToolCallID(id string, index *int) {
+	if id == "" || index == nil {
+		return
+	}
+	key := toolCallSpanKey("", index)
+	if span, ok := r.toolCalls[key]; ok {
+		delete(r.toolCalls, key)
+		span.SetAttributes(AttrToolCallID.String(id))
+		r.toolCalls[id] = span
+		r.indexIDs[*index] = id
+	}
+}
+
+// toolCallKey returns the key of the span of a tool call identified by ID,
+// by stream index, or by the index of a tool call whose ID is already known
+func (r *runTracer) toolCallKey(id string, index *int) string {
+	if id == "" && index != nil {
+		if assigned, ok := r.indexIDs[*index]; ok {
+			return assigned
+		}
+	}
+	return toolCallSpanKey(id, index)
+}
+
+// fail marks the run as failed with err
+func (r *runTracer) fail(err error) {
+	r.span.RecordError(err)
+	r.span.SetStatus(codes.Error, err.Error())
+}
+
+// end ends the run span and the spans of tool calls that never ended
+func (r *runTracer) end() {
+	for key, span := range r.toolCalls {
+		span.SetStatus(codes.Error, "tool call did not end before the run")
+		span.End()
+		delete(r.toolCalls, key)
+	}
+	r.span.End()
+}
+
+// toolCallSpanKey identifies a tool call by ID, or by stream index while
+// the ID is not known yet
+func toolCallSpanKey(id string, index *int) string {
+	if id != "" || index == nil {
+		return id
+	}
+	return fmt.Sprintf("#%d", *index)
+}
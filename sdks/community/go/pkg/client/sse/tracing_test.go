@@ -0,0 +1,202 @@
+package sse
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// recordingTracer records the spans it starts
+type recordingTracer struct {
+	noop.Tracer
+	mu    sync.Mutex
+	spans []*recordedSpan
+}
+
+// recordedSpan is a span started by a recordingTracer
+type recordedSpan struct {
+	noop.Span
+	tracer *recordingTracer
+	name   string
+	parent *recordedSpan
+	attrs  map[attribute.Key]string
+	status codes.Code
+	ended  bool
+}
+
+// Start implements trace.Tracer
+func (t *recordingTracer) Start(ctx context.Context, name string, options ...trace.SpanStartOption) (context.Context, trace.Span) {
+	span := &recordedSpan{tracer: t, name: name, attrs: make(map[attribute.Key]string)}
+	span.parent, _ = trace.SpanFromContext(ctx).(*recordedSpan)
+	config := trace.NewSpanStartConfig(options...)
+	span.SetAttributes(config.Attributes()...)
+
+	t.mu.Lock()
+	t.spans = append(t.spans, span)
+	t.mu.Unlock()
+	return trace.ContextWithSpan(ctx, span), span
+}
+
+// named returns the recorded spans with the given name
+func (t *recordingTracer) named(name string) []*recordedSpan {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var spans []*recordedSpan
+	for _, span := range t.spans {
+		if span.name == name {
+			spans = append(spans, span)
+		}
+	}
+	return spans
+}
+
+// SetAttributes implements trace.Span
+func (s *recordedSpan) SetAttributes(attrs ...attribute.KeyValue) {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	for _, attr := range attrs {
+		s.attrs[attr.Key] = attr.Value.Emit()
+	}
+}
+
+// SetStatus implements trace.Span
+func (s *recordedSpan) SetStatus(code codes.Code, _ string) {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	s.status = code
+}
+
+// End implements trace.Span
+func (s *recordedSpan) End(...trace.SpanEndOption) {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	s.ended = true
+}
+
+func TestRunAgent_WithTracer(t *testing.T) {
+	server := newEventServer(t, nil,
+		events.NewRunStartedEvent("thread-1", "run-1"),
+		events.NewToolCallStartEvent("call-1", "search"),
+		events.NewToolCallArgsEvent("call-1", "{}"),
+		events.NewToolCallEndEvent("call-1"),
+		events.NewToolCallStartEvent("", "lookup", events.WithToolCallIndex(0)),
+		events.NewToolCallEndEventWithOptions("call-2", events.WithToolCallEndIndex(0)),
+		events.NewRunFinishedEvent("thread-1", "run-1"),
+	)
+	defer server.Close()
+
+	tracer := &recordingTracer{}
+	out, errs, err := RunAgent(context.Background(), server.URL, newTestRunAgentInput(), WithTracer(tracer))
+	require.NoError(t, err)
+	_, err = collect(t, out, errs)
+	require.NoError(t, err)
+
+	runs := tracer.named(RunSpanName)
+	require.Len(t, runs, 1)
+	run := runs[0]
+	assert.True(t, run.ended)
+	assert.Equal(t, codes.Unset, run.status)
+	assert.Equal(t, newTestRunAgentInput().ThreadID, run.attrs[AttrThreadID])
+	assert.Equal(t, newTestRunAgentInput().RunID, run.attrs[AttrRunID])
+
+	toolCalls := tracer.named(ToolCallSpanName)
+	require.Len(t, toolCalls, 2)
+	for i, want := range []struct{ id, name string }{{"call-1", "search"}, {"call-2", "lookup"}} {
+		assert.Same(t, run, toolCalls[i].parent)
+		assert.True(t, toolCalls[i].ended)
+		assert.Equal(t, codes.Unset, toolCalls[i].status)
+		assert.Equal(t, want.id, toolCalls[i].attrs[AttrToolCallID])
+		assert.Equal(t, want.name, toolCalls[i].attrs[AttrToolCallName])
+	}
+}
+
+func TestRunAgent_WithTracerIndexOnlyEnd(t *testing.T) {
+	server := newEventServer(t, nil,
+		events.NewRunStartedEvent("thread-1", "run-1"),
+		events.NewToolCallStartEvent("", "search", events.WithToolCallIndex(0)),
+		events.NewToolCallEndEventWithOptions("", events.WithToolCallEndIndex(0)),
+		events.NewToolCallStartEvent("", "lookup", events.WithToolCallIndex(1)),
+		events.NewToolCallArgsEventWithOptions("call-2", "{}", events.WithToolCallArgsIndex(1)),
+		events.NewToolCallEndEventWithOptions("", events.WithToolCallEndIndex(1)),
+		events.NewRunFinishedEvent("thread-1", "run-1"),
+	)
+	defer server.Close()
+
+	tracer := &recordingTracer{}
+	out, errs, err := RunAgent(context.Background(), server.URL, newTestRunAgentInput(), WithTracer(tracer))
+	require.NoError(t, err)
+	_, err = collect(t, out, errs)
+	require.NoError(t, err)
+
+	toolCalls := tracer.named(ToolCallSpanName)
+	require.Len(t, toolCalls, 2)
+	for _, span := range toolCalls {
+		assert.True(t, span.ended)
+		assert.Equal(t, codes.Unset, span.status, "span ended by TOOL_CALL_END, not at run end")
+	}
+	assert.Equal(t, "call-2", toolCalls[1].attrs[AttrToolCallID])
+}
+
+func TestRunAgent_WithTracerFailures(t *testing.T) {
+	t.Run("RunError", func(t *testing.T) {
+		server := newEventServer(t, nil,
+			events.NewRunStartedEvent("thread-1", "run-1"),
+			events.NewRunErrorEvent("boom", events.WithErrorCode(events.RunErrorCodeInternal)),
+		)
+		defer server.Close()
+
+		tracer := &recordingTracer{}
+		out, errs, err := RunAgent(context.Background(), server.URL, newTestRunAgentInput(), WithTracer(tracer))
+		require.NoError(t, err)
+		_, err = collect(t, out, errs)
+		require.NoError(t, err)
+
+		run := tracer.named(RunSpanName)[0]
+		assert.True(t, run.ended)
+		assert.Equal(t, codes.Error, run.status)
+		assert.Equal(t, events.RunErrorCodeInternal, run.attrs[AttrErrorCode])
+	})
+
+	t.Run("IncompleteStream", func(t *testing.T) {
+		server := newEventServer(t, nil,
+			events.NewRunStartedEvent("thread-1", "run-1"),
+			events.NewToolCallStartEvent("call-1", "search"),
+		)
+		defer server.Close()
+
+		tracer := &recordingTracer{}
+		out, errs, err := RunAgent(context.Background(), server.URL, newTestRunAgentInput(), WithTracer(tracer))
+		require.NoError(t, err)
+		_, err = collect(t, out, errs)
+		require.True(t, errors.Is(err, ErrStreamIncomplete))
+		for range out {
+			// The spans have ended once the channels close
+		}
+
+		run := tracer.named(RunSpanName)[0]
+		assert.True(t, run.ended)
+		assert.Equal(t, codes.Error, run.status)
+		toolCall := tracer.named(ToolCallSpanName)[0]
+		assert.True(t, toolCall.ended)
+		assert.Equal(t, codes.Error, toolCall.status)
+	})
+
+	t.Run("ConnectionError", func(t *testing.T) {
+		tracer := &recordingTracer{}
+		_, _, err := RunAgent(context.Background(), "http://127.0.0.1:1", newTestRunAgentInput(), WithTracer(tracer))
+		require.Error(t, err)
+
+		run := tracer.named(RunSpanName)[0]
+		assert.True(t, run.ended)
+		assert.Equal(t, codes.Error, run.status)
+	})
+}
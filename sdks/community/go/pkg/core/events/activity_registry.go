@@ -0,0 +1,115 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/schema"
+	coretypes "github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/types"
+)
+
+var (
+	activityTypesMu sync.RWMutex
+	activityTypes   = map[string]*activityTypeSpec{}
+)
+
+// activityTypeSpec is a registered activity type and its optional content
+// schema, compiled once at registration
+type activityTypeSpec struct {
+	raw      json.RawMessage
+	compiled *schema.Schema
+}
+
+// RegisterActivityType adds an activity type to the set accepted for activity
+// messages. Once at least one type is registered, activity messages with an
+// unregistered activityType fail validation. When schema is non-empty it must
+// be a JSON Schema (see package schema for the supported keywords), and
+// activity content is validated against it. Registering an existing type
+// replaces its schema.
+func RegisterActivityType(name string, contentSchema json.RawMessage) error {
+	if name == "" {
+		return fmt.Errorf("activity type name is required")
+	}
+
+	spec := &activityTypeSpec{}
+	if len(contentSchema) > 0 {
+		compiled, err := schema.Compile(contentSchema)
+		if err != nil {
+			return fmt.Errorf("invalid schema for activity type %s: %w", name, err)
+		}
+		spec.raw = append(json.RawMessage(nil), contentSchema...)
+		spec.compiled = compiled
+	}
+
+	activityTypesMu.Lock()
+	defer activityTypesMu.Unlock()
+	activityTypes[name] = spec
+	return nil
+}
+
+// UnregisterActivityType removes an activity type. Removing the last
+// registered type restores permissive validation.
+func UnregisterActivityType(name string) {
+	activityTypesMu.Lock()
+	defer activityTypesMu.Unlock()
+	delete(activityTypes, name)
+}
+
+// RegisteredActivityTypes returns the registered activity type names in sorted order
+func RegisteredActivityTypes() []string {
+	activityTypesMu.RLock()
+	defer activityTypesMu.RUnlock()
+
+	names := make([]string, 0, len(activityTypes))
+	for name := range activityTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LookupActivityType returns the schema registered for an activity type
+func LookupActivityType(name string) (json.RawMessage, bool) {
+	activityTypesMu.RLock()
+	defer activityTypesMu.RUnlock()
+
+	spec, ok := activityTypes[name]
+	if !ok {
+		return nil, false
+	}
+	return spec.raw, true
+}
+
+// validateActivityContent checks an activity type and its content against the
+// registry. An empty registry accepts every activity type. Schema failures are
+// returned as a wrapped *schema.ValidationError listing each offending field.
+func validateActivityContent(activityType string, content map[string]any) error {
+	activityTypesMu.RLock()
+	defer activityTypesMu.RUnlock()
+
+	if len(activityTypes) == 0 {
+		return nil
+	}
+
+	spec, ok := activityTypes[activityType]
+	if !ok {
+		return fmt.Errorf("activityType %s is not registered", activityType)
+	}
+
+	if spec.compiled != nil {
+		if err := spec.compiled.Validate(content); err != nil {
+			return fmt.Errorf("content for activity type %s is invalid: %w", activityType, err)
+		}
+	}
+	return nil
+}
+
+// RegisterActivityStruct binds an activity type to a Go struct, so that
+// Message.ActivityValue returns activity content as that struct. It is a
+// shorthand for types.RegisterActivityStruct and complements the content
+// schema of RegisterActivityType.
+func RegisterActivityStruct(activityType string, proto any) {
+	coretypes.RegisterActivityStruct(activityType, proto)
+}
@@ -0,0 +1,86 @@
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/schema"
+	coretypes "github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActivityTypeRegistry(t *testing.T) {
+	msg := Message{
+		ID:           "act-1",
+		Role:         coretypes.RoleActivity,
+		ActivityType: "PLAN",
+		Content:      map[string]any{"steps": []any{"a"}},
+	}
+
+	// An empty registry keeps validation permissive
+	require.Empty(t, RegisteredActivityTypes())
+	require.NoError(t, validateMessage(msg))
+
+	require.NoError(t, RegisterActivityType("PLAN", json.RawMessage(`{"type":"object","required":["steps"]}`)))
+	require.NoError(t, RegisterActivityType("THINKING", nil))
+	defer UnregisterActivityType("PLAN")
+	defer UnregisterActivityType("THINKING")
+
+	assert.Equal(t, []string{"PLAN", "THINKING"}, RegisteredActivityTypes())
+	schema, ok := LookupActivityType("PLAN")
+	require.True(t, ok)
+	assert.JSONEq(t, `{"type":"object","required":["steps"]}`, string(schema))
+
+	assert.NoError(t, validateMessage(msg))
+
+	msg.Content = map[string]any{"title": "no steps"}
+	err := validateMessage(msg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `content for activity type PLAN is invalid`)
+	assert.Contains(t, err.Error(), `missing required property "steps"`)
+
+	msg.ActivityType = "THINKING"
+	assert.NoError(t, validateMessage(msg))
+
+	msg.ActivityType = "UNKNOWN"
+	err = validateMessage(msg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "activityType UNKNOWN is not registered")
+
+	assert.Error(t, RegisterActivityType("", nil))
+	assert.Error(t, RegisterActivityType("BROKEN", json.RawMessage(`{`)))
+	assert.Error(t, RegisterActivityType("BROKEN", json.RawMessage(`{"type":"map"}`)))
+	_, ok = LookupActivityType("BROKEN")
+	assert.False(t, ok)
+}
+
+func TestActivityTypeRegistry_SchemaFieldErrors(t *testing.T) {
+	require.NoError(t, RegisterActivityType("PLAN", json.RawMessage(`{
+		"type": "object",
+		"required": ["steps"],
+		"properties": {
+			"steps": {"type": "array", "items": {"type": "string"}},
+			"done": {"type": "boolean"}
+		}
+	}`)))
+	defer UnregisterActivityType("PLAN")
+
+	event := NewMessagesSnapshotEvent([]Message{{
+		ID:           "act-1",
+		Role:         coretypes.RoleActivity,
+		ActivityType: "PLAN",
+		Content:      map[string]any{"steps": []any{"a", 2}, "done": "yes"},
+	}})
+
+	err := event.Validate()
+	require.Error(t, err)
+
+	var validationErr *schema.ValidationError
+	require.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, []schema.FieldError{
+		{Path: "/done", Message: "expected boolean, got string"},
+		{Path: "/steps/1", Message: "expected string, got integer"},
+	}, validationErr.Errors)
+}
@@ -43,6 +43,22 @@ func TestBaseEventMethods(t *testing.T) {
 		assert.Equal(t, "", runID)
 	})
 
+	t.Run("RawJSON_Method", func(t *testing.T) {
+		base := NewBaseEvent(EventTypeRunStarted)
+		assert.Nil(t, base.RawJSON())
+
+		base.RawEvent = map[string]any{"provider": "openai"}
+		assert.JSONEq(t, `{"provider":"openai"}`, string(base.RawJSON()))
+
+		base.RawEvent = json.RawMessage(`{"id":1}`)
+		assert.Equal(t, json.RawMessage(`{"id":1}`), base.RawJSON())
+
+		// Every concrete event exposes the accessor through the Event interface
+		var event Event = NewTextMessageContentEvent("msg-1", "hi")
+		event.GetBaseEvent().RawEvent = "source"
+		assert.Equal(t, `"source"`, string(event.RawJSON()))
+	})
+
 	t.Run("ToJSON_Method", func(t *testing.T) {
 		base := NewBaseEvent(EventTypeRunStarted)
 
@@ -447,6 +463,31 @@ func TestRunFinishedEvent_WithResult(t *testing.T) {
 	assert.NotNil(t, decoded["result"])
 }
 
+func TestRunFinishedEvent_Into(t *testing.T) {
+	type answer struct {
+		Status string `json:"status"`
+		Count  int    `json:"count"`
+	}
+
+	event := NewRunFinishedEventWithOptions("thread-123", "run-456", WithResult(answer{Status: "done", Count: 3}))
+	data, err := event.ToJSON()
+	require.NoError(t, err)
+
+	// Results decoded from JSON are generic values until decoded with Into
+	decoded, err := EventFromJSON(data)
+	require.NoError(t, err)
+	var got answer
+	require.NoError(t, decoded.(*RunFinishedEvent).Into(&got))
+	assert.Equal(t, answer{Status: "done", Count: 3}, got)
+
+	raw := NewRunFinishedEventWithOptions("thread-123", "run-456", WithResult(json.RawMessage(`{"status":"raw"}`)))
+	require.NoError(t, raw.Into(&got))
+	assert.Equal(t, "raw", got.Status)
+
+	assert.ErrorIs(t, NewRunFinishedEvent("thread-123", "run-456").Into(&got), ErrNoRunResult)
+	assert.Error(t, NewRunFinishedEventWithOptions("thread-123", "run-456", WithResult("text")).Into(&got))
+}
+
 func TestStateDeltaEvent_ToJSON(t *testing.T) {
 	delta := []JSONPatchOperation{
 		{Op: "add", Path: "/field", Value: "value"},
@@ -0,0 +1,526 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/schema"
+	coretypes "github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/types"
+)
+
+// assemblyKey identifies a message or tool call within the step that
+// produced it. Steps run by parallel sub-agents may reuse IDs.
+type assemblyKey struct {
+	step string
+	id   string
+}
+
+// String describes the key for error messages
+func (k assemblyKey) String() string {
+	if k.step == "" {
+		return k.id
+	}
+	return fmt.Sprintf("%s in step %s", k.id, k.step)
+}
+
+// MessageAssembler reassembles streamed text messages from
+// TEXT_MESSAGE_START, TEXT_MESSAGE_CONTENT and TEXT_MESSAGE_END events.
+// TEXT_REASONING_CONTENT events are collected into the message's Reasoning
+// field rather than its content. MESSAGE_UPDATE events replace the content
+// of a message that is still pending, or that is completed and retained but
+// not yet drained (see RetainCompletedMessages). Messages with different IDs
+// may be interleaved. Events fed with HandleInStep are scoped to their step,
+// so parallel sub-agents may reuse message IDs. A MessageAssembler is safe
+// for concurrent use.
+type MessageAssembler struct {
+	mu        sync.Mutex
+	pending   map[assemblyKey]*pendingMessage
+	retain    bool
+	completed []completedMessage
+}
+
+// completedMessage is a retained message together with the key it was
+// assembled under, so that updates find it in the right step
+type completedMessage struct {
+	key     assemblyKey
+	message Message
+}
+
+// pendingMessage accumulates the content of a message that has not ended yet
+type pendingMessage struct {
+	role      coretypes.Role
+	name      string
+	content   strings.Builder
+	reasoning strings.Builder
+}
+
+// MessageAssemblerOption defines options for creating message assemblers
+type MessageAssemblerOption func(*MessageAssembler)
+
+// RetainCompletedMessages keeps completed messages until they are drained
+// with CompletedMessages. By default completed messages are only returned by
+// Handle, so that callers who never drain do not accumulate them.
+func RetainCompletedMessages(retain bool) MessageAssemblerOption {
+	return func(a *MessageAssembler) {
+		a.retain = retain
+	}
+}
+
+// NewMessageAssembler creates a new message assembler
+func NewMessageAssembler(options ...MessageAssemblerOption) *MessageAssembler {
+	a := &MessageAssembler{
+		pending: make(map[assemblyKey]*pendingMessage),
+	}
+	for _, opt := range options {
+		opt(a)
+	}
+	return a
+}
+
+// Handle feeds an event into the assembler. When the event completes a message,
+// the assembled message is returned with done set to true. Events unrelated to
+// text messages are ignored. An error is returned when content or end events
+// reference a message that was never started, or a message is started twice.
+// A MESSAGE_UPDATE for a retained message returns the revised message with
+// done set to true; one for a message the assembler does not hold returns an
+// error wrapping ErrMessageNotFound, and messages that were not retained or
+// have been drained should be revised with ApplyMessageUpdate instead.
+func (a *MessageAssembler) Handle(event Event) (msg *Message, done bool, err error) {
+	return a.HandleInStep("", event)
+}
+
+// HandleInStep is like Handle for an event produced within the named step.
+// Message IDs only have to be unique within a step.
+func (a *MessageAssembler) HandleInStep(step string, event Event) (msg *Message, done bool, err error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	switch e := event.(type) {
+	case *TextMessageStartEvent:
+		key := assemblyKey{step, e.MessageID}
+		if _, exists := a.pending[key]; exists {
+			return nil, false, fmt.Errorf("message %s already started", key)
+		}
+		pending := &pendingMessage{role: coretypes.RoleAssistant, name: e.Name}
+		if e.Role != nil && *e.Role != "" {
+			pending.role = coretypes.Role(*e.Role)
+		}
+		a.pending[key] = pending
+
+	case *TextMessageContentEvent:
+		key := assemblyKey{step, e.MessageID}
+		pending, exists := a.pending[key]
+		if !exists {
+			return nil, false, fmt.Errorf("cannot add content to message %s that was not started", key)
+		}
+		pending.content.WriteString(e.Delta)
+
+	case *TextReasoningContentEvent:
+		key := assemblyKey{step, e.MessageID}
+		pending, exists := a.pending[key]
+		if !exists {
+			return nil, false, fmt.Errorf("cannot add reasoning to message %s that was not started", key)
+		}
+		pending.reasoning.WriteString(e.Delta)
+
+	case *TextMessageEndEvent:
+		key := assemblyKey{step, e.MessageID}
+		pending, exists := a.pending[key]
+		if !exists {
+			return nil, false, fmt.Errorf("cannot end message %s that was not started", key)
+		}
+		delete(a.pending, key)
+		msg := Message{
+			ID:        e.MessageID,
+			Role:      pending.role,
+			Name:      pending.name,
+			Content:   pending.content.String(),
+			Reasoning: pending.reasoning.String(),
+		}
+		if a.retain {
+			a.completed = append(a.completed, completedMessage{key, msg})
+		}
+		return &msg, true, nil
+
+	case *MessageUpdateEvent:
+		key := assemblyKey{step, e.MessageID}
+		if pending, exists := a.pending[key]; exists {
+			pending.content.Reset()
+			pending.content.WriteString(e.Content)
+			return nil, false, nil
+		}
+		for i := len(a.completed) - 1; i >= 0; i-- {
+			if a.completed[i].key == key {
+				a.completed[i].message.Content = e.Content
+				msg := a.completed[i].message
+				return &msg, true, nil
+			}
+		}
+		return nil, false, fmt.Errorf("cannot update message %s: %w", key, ErrMessageNotFound)
+	}
+
+	return nil, false, nil
+}
+
+// CompletedMessages returns the messages completed since the previous call,
+// in the order they ended, and forgets them. It always returns nil unless the
+// assembler was created with RetainCompletedMessages.
+func (a *MessageAssembler) CompletedMessages() []Message {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.completed == nil {
+		return nil
+	}
+	completed := make([]Message, len(a.completed))
+	for i, entry := range a.completed {
+		completed[i] = entry.message
+	}
+	a.completed = nil
+	return completed
+}
+
+// Pending returns the IDs of messages that have started but not yet ended
+func (a *MessageAssembler) Pending() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	ids := make([]string, 0, len(a.pending))
+	for key := range a.pending {
+		ids = append(ids, key.id)
+	}
+	return ids
+}
+
+// PartialText returns the content received so far for a message that has
+// started but not ended, or "" for any other ID. Backends may split a
+// multi-byte character across content events, so an incomplete UTF-8
+// sequence at the end is held back until the rest of it arrives; the message
+// returned by Handle on TEXT_MESSAGE_END always carries the full content.
+func (a *MessageAssembler) PartialText(messageID string) string {
+	return a.PartialTextInStep("", messageID)
+}
+
+// PartialTextInStep is like PartialText for a message fed with HandleInStep
+func (a *MessageAssembler) PartialTextInStep(step, messageID string) string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	pending, exists := a.pending[assemblyKey{step, messageID}]
+	if !exists {
+		return ""
+	}
+	return trimIncompleteRune(pending.content.String())
+}
+
+// trimIncompleteRune removes a truncated UTF-8 sequence from the end of s
+func trimIncompleteRune(s string) string {
+	for i := len(s) - 1; i >= 0 && i >= len(s)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(s[i]) {
+			if !utf8.FullRuneInString(s[i:]) {
+				return s[:i]
+			}
+			break
+		}
+	}
+	return s
+}
+
+// ToolCallAssembler reassembles streamed tool calls from TOOL_CALL_START,
+// TOOL_CALL_ARGS and TOOL_CALL_END events. Tool calls with different IDs may be
+// interleaved. Arguments are only exposed once the tool call has ended, so
+// callers never see partial JSON fragments. Events fed with HandleInStep are
+// scoped to their step, so parallel sub-agents may reuse tool call IDs.
+//
+// Tool calls may also be identified by stream index (the events' Index
+// field) until their ID is known. The first event that carries both assigns
+// the ID, after which later events may use either; the tool call must have
+// an ID by the time it ends.
+//
+// A ToolCallAssembler is safe for concurrent use.
+type ToolCallAssembler struct {
+	mu        sync.Mutex
+	pending   *toolCallTable[*pendingToolCall]
+	retain    bool
+	completed []ToolCall
+
+	schema    *schema.Schema
+	schemaErr error
+}
+
+// pendingToolCall accumulates the arguments of a tool call that has not ended yet
+type pendingToolCall struct {
+	name      string
+	args      strings.Builder
+	validator *schema.PartialValidator
+	err       error
+}
+
+// ToolCallAssemblerOption defines options for creating tool call assemblers
+type ToolCallAssemblerOption func(*ToolCallAssembler)
+
+// WithSchema validates the arguments of every tool call against a JSON Schema
+// for the tool's parameters. While arguments stream in, Handle fails as soon
+// as they can no longer conform (see schema.PartialValidator); the complete
+// arguments are validated when the tool call ends, with empty arguments
+// treated as an empty object. An invalid schema makes Handle fail.
+func WithSchema(parameters json.RawMessage) ToolCallAssemblerOption {
+	return func(a *ToolCallAssembler) {
+		a.schema, a.schemaErr = schema.Compile(parameters)
+	}
+}
+
+// RetainCompletedToolCalls keeps completed tool calls until they are drained
+// with CompletedToolCalls. By default completed tool calls are only returned
+// by Handle, so that callers who never drain do not accumulate them.
+func RetainCompletedToolCalls(retain bool) ToolCallAssemblerOption {
+	return func(a *ToolCallAssembler) {
+		a.retain = retain
+	}
+}
+
+// NewToolCallAssembler creates a new tool call assembler
+func NewToolCallAssembler(options ...ToolCallAssemblerOption) *ToolCallAssembler {
+	a := &ToolCallAssembler{
+		pending: newToolCallTable[*pendingToolCall](),
+	}
+	for _, opt := range options {
+		opt(a)
+	}
+	return a
+}
+
+// Handle feeds an event into the assembler. When the event completes a tool call,
+// the assembled tool call is returned with done set to true. Events unrelated to
+// tool calls are ignored. An error is returned when args or end events reference
+// a tool call that was never started, or a tool call is started twice. With
+// WithSchema, arguments that cannot match the schema fail the args event that
+// revealed it and every later event of that tool call, up to its end.
+func (a *ToolCallAssembler) Handle(event Event) (toolCall *ToolCall, done bool, err error) {
+	return a.HandleInStep("", event)
+}
+
+// HandleInStep is like Handle for an event produced within the named step.
+// Tool call IDs only have to be unique within a step.
+func (a *ToolCallAssembler) HandleInStep(step string, event Event) (toolCall *ToolCall, done bool, err error) {
+	if a.schemaErr != nil {
+		return nil, false, fmt.Errorf("invalid tool call schema: %w", a.schemaErr)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	switch e := event.(type) {
+	case *ToolCallStartEvent:
+		pending := &pendingToolCall{name: e.ToolCallName}
+		if a.schema != nil {
+			pending.validator = a.schema.NewPartialValidator()
+		}
+		if err := a.pending.start(step, e.ToolCallID, e.Index, pending); err != nil {
+			return nil, false, err
+		}
+
+	case *ToolCallArgsEvent:
+		entry, err := a.pending.lookup(step, e.ToolCallID, e.Index)
+		if err != nil {
+			return nil, false, err
+		}
+		if entry == nil {
+			return nil, false, fmt.Errorf("cannot add arguments to tool call %s that was not started",
+				describeToolCall(step, e.ToolCallID, e.Index))
+		}
+		pending := entry.value
+		if pending.err != nil {
+			return nil, false, pending.err
+		}
+		pending.args.WriteString(e.Delta)
+		if pending.validator != nil {
+			if _, err := pending.validator.Write([]byte(e.Delta)); err != nil {
+				pending.err = fmt.Errorf("tool call %s arguments cannot match schema: %w", entry.describe(), err)
+				return nil, false, pending.err
+			}
+		}
+
+	case *ToolCallEndEvent:
+		entry, err := a.pending.lookup(step, e.ToolCallID, e.Index)
+		if err != nil {
+			return nil, false, err
+		}
+		if entry == nil {
+			return nil, false, fmt.Errorf("cannot end tool call %s that was not started",
+				describeToolCall(step, e.ToolCallID, e.Index))
+		}
+		a.pending.end(entry)
+		pending := entry.value
+		if pending.err != nil {
+			return nil, false, pending.err
+		}
+		if entry.id == "" {
+			return nil, false, fmt.Errorf("tool call %s ended without an id", entry.describe())
+		}
+		if a.schema != nil {
+			if err := a.validateArguments(pending.args.String()); err != nil {
+				return nil, false, fmt.Errorf("tool call %s arguments: %w", entry.describe(), err)
+			}
+		}
+		toolCall := ToolCall{
+			ID:   entry.id,
+			Type: "function",
+			Function: Function{
+				Name:      pending.name,
+				Arguments: pending.args.String(),
+			},
+		}
+		if a.retain {
+			a.completed = append(a.completed, toolCall)
+		}
+		return &toolCall, true, nil
+	}
+
+	return nil, false, nil
+}
+
+// validateArguments checks complete arguments against the schema
+func (a *ToolCallAssembler) validateArguments(args string) error {
+	if strings.TrimSpace(args) == "" {
+		args = "{}"
+	}
+	var value any
+	if err := json.Unmarshal([]byte(args), &value); err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	return a.schema.Validate(value)
+}
+
+// CompletedToolCalls returns the tool calls completed since the previous
+// call, in the order they ended, and forgets them. It always returns nil
+// unless the assembler was created with RetainCompletedToolCalls.
+func (a *ToolCallAssembler) CompletedToolCalls() []ToolCall {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	completed := a.completed
+	a.completed = nil
+	return completed
+}
+
+// Pending returns the IDs of tool calls that have started but not yet ended.
+// Tool calls whose ID is not known yet are listed by stream index, as "#0".
+func (a *ToolCallAssembler) Pending() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	ids := make([]string, 0, a.pending.len())
+	a.pending.entries(func(entry *toolCallEntry[*pendingToolCall]) {
+		ids = append(ids, describeToolCall("", entry.id, entry.index))
+	})
+	return ids
+}
+
+// Activity is the current content of an activity message
+type Activity struct {
+	MessageID    string
+	ActivityType string
+	Content      json.RawMessage
+}
+
+// ActivityAssembler tracks the content of activity messages as it is built
+// up incrementally, so that UIs can render large structured activities while
+// they stream. Content starts with an ACTIVITY_SNAPSHOT and is then updated by
+// ACTIVITY_DELTA events (JSON Patch) or by merge patch deltas passed to
+// ApplyContentDelta. Every update returns the full content after the change.
+type ActivityAssembler struct {
+	activities map[string]*Activity
+}
+
+// NewActivityAssembler creates a new activity assembler
+func NewActivityAssembler() *ActivityAssembler {
+	return &ActivityAssembler{
+		activities: make(map[string]*Activity),
+	}
+}
+
+// Handle feeds an event into the assembler and returns the updated activity
+// with updated set to true when the event changed it. Snapshots with replace
+// set to false leave existing content untouched. Events unrelated to
+// activities are ignored. An error is returned, and the activity left as it
+// was, when a delta arrives before any snapshot, names a different activity
+// type or cannot be applied.
+func (a *ActivityAssembler) Handle(event Event) (activity *Activity, updated bool, err error) {
+	switch e := event.(type) {
+	case *ActivitySnapshotEvent:
+		if existing, exists := a.activities[e.MessageID]; exists && e.Replace != nil && !*e.Replace {
+			return existing.clone(), false, nil
+		}
+		content, err := json.Marshal(e.Content)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to encode content of activity %s: %w", e.MessageID, err)
+		}
+		current := &Activity{MessageID: e.MessageID, ActivityType: e.ActivityType, Content: content}
+		a.activities[e.MessageID] = current
+		return current.clone(), true, nil
+
+	case *ActivityDeltaEvent:
+		current, err := a.lookup(e.MessageID, e.ActivityType)
+		if err != nil {
+			return nil, false, err
+		}
+		content, err := ApplyJSONPatch(current.Content, e.Patch)
+		if err != nil {
+			return nil, false, fmt.Errorf("cannot apply delta to activity %s: %w", e.MessageID, err)
+		}
+		current.Content = content
+		return current.clone(), true, nil
+	}
+
+	return nil, false, nil
+}
+
+// ApplyContentDelta merges a JSON Merge Patch (RFC 7386) into the content of
+// an activity that has already received a snapshot and returns the updated
+// activity. The activity is left unchanged when an error is returned.
+func (a *ActivityAssembler) ApplyContentDelta(messageID, activityType string, patch json.RawMessage) (*Activity, error) {
+	current, err := a.lookup(messageID, activityType)
+	if err != nil {
+		return nil, err
+	}
+	content, err := ApplyMergePatch(current.Content, patch)
+	if err != nil {
+		return nil, fmt.Errorf("cannot apply delta to activity %s: %w", messageID, err)
+	}
+	current.Content = content
+	return current.clone(), nil
+}
+
+// Activity returns the current content of an activity message
+func (a *ActivityAssembler) Activity(messageID string) (*Activity, bool) {
+	current, exists := a.activities[messageID]
+	if !exists {
+		return nil, false
+	}
+	return current.clone(), true
+}
+
+// Remove forgets an activity message, for example once it has been rendered
+// for the last time
+func (a *ActivityAssembler) Remove(messageID string) {
+	delete(a.activities, messageID)
+}
+
+// lookup returns the tracked activity that a delta applies to
+func (a *ActivityAssembler) lookup(messageID, activityType string) (*Activity, error) {
+	current, exists := a.activities[messageID]
+	if !exists {
+		return nil, fmt.Errorf("cannot apply delta to activity %s that has no snapshot", messageID)
+	}
+	if activityType != "" && activityType != current.ActivityType {
+		return nil, fmt.Errorf("delta for activity %s has type %s, but the activity has type %s",
+			messageID, activityType, current.ActivityType)
+	}
+	return current, nil
+}
+
+// clone returns a copy whose content can be retained by the caller
+func (a *Activity) clone() *Activity {
+	copied := *a
+	copied.Content = append(json.RawMessage(nil), a.Content...)
+	return &copied
+}
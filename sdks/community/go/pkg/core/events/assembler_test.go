@@ -0,0 +1,508 @@
+package events
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+
+	coretypes "github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageAssembler(t *testing.T) {
+	t.Run("AssemblesInterleavedMessages", func(t *testing.T) {
+		a := NewMessageAssembler()
+		stream := []Event{
+			NewTextMessageStartEvent("msg-1", WithRole("assistant"), WithName("planner")),
+			NewTextMessageStartEvent("msg-2"),
+			NewTextMessageContentEvent("msg-1", "Hello, "),
+			NewTextMessageContentEvent("msg-2", "Other"),
+			NewStepStartedEvent("ignored"),
+			NewTextMessageContentEvent("msg-1", "world"),
+			NewTextMessageEndEvent("msg-2"),
+			NewTextMessageEndEvent("msg-1"),
+		}
+
+		var completed []*Message
+		for _, event := range stream {
+			msg, done, err := a.Handle(event)
+			require.NoError(t, err)
+			if done {
+				completed = append(completed, msg)
+			}
+		}
+
+		require.Len(t, completed, 2)
+		assert.Equal(t, "msg-2", completed[0].ID)
+		assert.Equal(t, "Other", completed[0].Content)
+		assert.Equal(t, "msg-1", completed[1].ID)
+		assert.Equal(t, coretypes.RoleAssistant, completed[1].Role)
+		assert.Equal(t, "planner", completed[1].Name)
+		assert.Equal(t, "Hello, world", completed[1].Content)
+		assert.Empty(t, a.Pending())
+		assert.Empty(t, a.CompletedMessages(), "completed messages are not retained by default")
+	})
+
+	t.Run("KeepsReasoningSeparate", func(t *testing.T) {
+		a := NewMessageAssembler()
+		stream := []Event{
+			NewTextMessageStartEvent("msg-1", WithRole("assistant")),
+			NewTextReasoningContentEvent("msg-1", "The user wants "),
+			NewTextReasoningContentEvent("msg-1", "a greeting."),
+			NewTextMessageContentEvent("msg-1", "Hello!"),
+		}
+		for _, event := range stream {
+			_, done, err := a.Handle(event)
+			require.NoError(t, err)
+			assert.False(t, done)
+		}
+
+		msg, done, err := a.Handle(NewTextMessageEndEvent("msg-1"))
+		require.NoError(t, err)
+		require.True(t, done)
+		assert.Equal(t, "Hello!", msg.Content)
+		assert.Equal(t, "The user wants a greeting.", msg.Reasoning)
+		assert.NoError(t, validateMessage(*msg))
+
+		_, _, err = a.Handle(NewTextReasoningContentEvent("msg-2", "x"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not started")
+	})
+
+	t.Run("RejectsContentWithoutStart", func(t *testing.T) {
+		a := NewMessageAssembler()
+		_, _, err := a.Handle(NewTextMessageContentEvent("msg-1", "x"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "msg-1")
+	})
+
+	t.Run("RejectsEndWithoutStart", func(t *testing.T) {
+		a := NewMessageAssembler()
+		_, _, err := a.Handle(NewTextMessageEndEvent("msg-1"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not started")
+	})
+
+	t.Run("RejectsDuplicateStart", func(t *testing.T) {
+		a := NewMessageAssembler()
+		_, _, err := a.Handle(NewTextMessageStartEvent("msg-1"))
+		require.NoError(t, err)
+		_, _, err = a.Handle(NewTextMessageStartEvent("msg-1"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "already started")
+		assert.Equal(t, []string{"msg-1"}, a.Pending())
+	})
+	t.Run("AppliesUpdates", func(t *testing.T) {
+		a := NewMessageAssembler(RetainCompletedMessages(true))
+		for _, event := range []Event{
+			NewTextMessageStartEvent("msg-1"),
+			NewTextMessageContentEvent("msg-1", "Helo"),
+			NewMessageUpdateEvent("msg-1", "Hel"),
+			NewTextMessageContentEvent("msg-1", "lo"),
+		} {
+			_, done, err := a.Handle(event)
+			require.NoError(t, err)
+			assert.False(t, done)
+		}
+
+		msg, done, err := a.Handle(NewTextMessageEndEvent("msg-1"))
+		require.NoError(t, err)
+		require.True(t, done)
+		assert.Equal(t, "Hello", msg.Content)
+
+		msg, done, err = a.Handle(NewMessageUpdateEvent("msg-1", "Hello!"))
+		require.NoError(t, err)
+		require.True(t, done)
+		assert.Equal(t, "Hello!", msg.Content)
+		completed := a.CompletedMessages()
+		require.Len(t, completed, 1)
+		assert.Equal(t, "Hello!", completed[0].Content)
+
+		_, _, err = a.Handle(NewMessageUpdateEvent("msg-1", "drained"))
+		assert.ErrorIs(t, err, ErrMessageNotFound)
+	})
+	t.Run("UpdatesMessageInItsOwnStep", func(t *testing.T) {
+		a := NewMessageAssembler(RetainCompletedMessages(true))
+		for _, step := range []string{"research", "summarize"} {
+			for _, event := range []Event{
+				NewTextMessageStartEvent("msg-1"),
+				NewTextMessageContentEvent("msg-1", step),
+				NewTextMessageEndEvent("msg-1"),
+			} {
+				_, _, err := a.HandleInStep(step, event)
+				require.NoError(t, err)
+			}
+		}
+
+		msg, done, err := a.HandleInStep("research", NewMessageUpdateEvent("msg-1", "revised"))
+		require.NoError(t, err)
+		require.True(t, done)
+		assert.Equal(t, "revised", msg.Content)
+		_, _, err = a.HandleInStep("other", NewMessageUpdateEvent("msg-1", "lost"))
+		assert.ErrorIs(t, err, ErrMessageNotFound)
+
+		completed := a.CompletedMessages()
+		require.Len(t, completed, 2)
+		assert.Equal(t, "revised", completed[0].Content)
+		assert.Equal(t, "summarize", completed[1].Content)
+	})
+}
+
+func TestMessageAssembler_PartialText(t *testing.T) {
+	a := NewMessageAssembler()
+	_, _, err := a.Handle(NewTextMessageStartEvent("msg-1"))
+	require.NoError(t, err)
+	assert.Equal(t, "", a.PartialText("msg-1"))
+
+	// "héllo 世界" with both multi-byte characters split across deltas
+	text := "héllo 世界"
+	deltas := []string{text[:2], text[2:9], text[9:11], text[11:]}
+	expected := []string{"h", "héllo ", "héllo 世", text}
+	for i, delta := range deltas {
+		_, _, err := a.Handle(&TextMessageContentEvent{BaseEvent: NewBaseEvent(EventTypeTextMessageContent), MessageID: "msg-1", Delta: delta})
+		require.NoError(t, err)
+		assert.Equal(t, expected[i], a.PartialText("msg-1"), "after delta %d", i)
+	}
+
+	msg, done, err := a.Handle(NewTextMessageEndEvent("msg-1"))
+	require.NoError(t, err)
+	require.True(t, done)
+	assert.Equal(t, text, msg.Content)
+	assert.Equal(t, "", a.PartialText("msg-1"))
+	assert.Equal(t, "", a.PartialText("unknown"))
+}
+
+func TestAssemblers_ParallelSteps(t *testing.T) {
+	messages := NewMessageAssembler(RetainCompletedMessages(true))
+	toolCalls := NewToolCallAssembler(RetainCompletedToolCalls(true))
+
+	// Sub-agents in two steps reuse the same IDs, fed concurrently
+	steps := []string{"research", "summarize"}
+	var wg sync.WaitGroup
+	for _, step := range steps {
+		wg.Add(1)
+		go func(step string) {
+			defer wg.Done()
+			for _, event := range []Event{
+				NewTextMessageStartEvent("msg-1"),
+				NewTextMessageContentEvent("msg-1", step+" "),
+				NewToolCallStartEvent("call-1", step),
+				NewTextMessageContentEvent("msg-1", "done"),
+				NewToolCallArgsEvent("call-1", "{}"),
+				NewTextMessageEndEvent("msg-1"),
+				NewToolCallEndEvent("call-1"),
+			} {
+				_, _, err := messages.HandleInStep(step, event)
+				assert.NoError(t, err)
+				_, _, err = toolCalls.HandleInStep(step, event)
+				assert.NoError(t, err)
+			}
+		}(step)
+	}
+	wg.Wait()
+
+	completed := messages.CompletedMessages()
+	require.Len(t, completed, 2)
+	var contents []string
+	for _, msg := range completed {
+		assert.Equal(t, "msg-1", msg.ID)
+		content, _ := msg.ContentString()
+		contents = append(contents, content)
+	}
+	assert.ElementsMatch(t, []string{"research done", "summarize done"}, contents)
+	assert.Empty(t, messages.CompletedMessages())
+	assert.Empty(t, messages.Pending())
+
+	calls := toolCalls.CompletedToolCalls()
+	require.Len(t, calls, 2)
+	assert.ElementsMatch(t, steps, []string{calls[0].Function.Name, calls[1].Function.Name})
+	assert.Empty(t, toolCalls.CompletedToolCalls())
+
+	// The same ID is distinct per step, and errors name the step
+	_, _, err := messages.HandleInStep("research", NewTextMessageStartEvent("msg-2"))
+	require.NoError(t, err)
+	_, _, err = messages.Handle(NewTextMessageContentEvent("msg-2", "x"))
+	require.Error(t, err)
+	_, _, err = messages.HandleInStep("research", NewTextMessageStartEvent("msg-2"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "msg-2 in step research")
+
+	_, _, err = messages.HandleInStep("research", NewTextMessageContentEvent("msg-2", "partial"))
+	require.NoError(t, err)
+	assert.Equal(t, "partial", messages.PartialTextInStep("research", "msg-2"))
+	assert.Equal(t, "", messages.PartialText("msg-2"))
+}
+
+func TestToolCallAssembler(t *testing.T) {
+	t.Run("AssemblesInterleavedToolCalls", func(t *testing.T) {
+		a := NewToolCallAssembler()
+		stream := []Event{
+			NewToolCallStartEvent("call-1", "search"),
+			NewToolCallStartEvent("call-2", "lookup"),
+			NewToolCallArgsEvent("call-1", `{"query":`),
+			NewToolCallArgsEvent("call-2", `{}`),
+			NewToolCallArgsEvent("call-1", `"weather"}`),
+			NewTextMessageContentEvent("msg-1", "ignored"),
+			NewToolCallEndEvent("call-1"),
+			NewToolCallEndEvent("call-2"),
+		}
+
+		var completed []*ToolCall
+		for _, event := range stream {
+			toolCall, done, err := a.Handle(event)
+			require.NoError(t, err)
+			if done {
+				completed = append(completed, toolCall)
+			}
+		}
+
+		require.Len(t, completed, 2)
+		assert.Equal(t, "call-1", completed[0].ID)
+		assert.Equal(t, "function", completed[0].Type)
+		assert.Equal(t, "search", completed[0].Function.Name)
+		assert.JSONEq(t, `{"query":"weather"}`, completed[0].Function.Arguments)
+		assert.Equal(t, "lookup", completed[1].Function.Name)
+		assert.Equal(t, "{}", completed[1].Function.Arguments)
+		assert.Empty(t, a.Pending())
+		assert.Empty(t, a.CompletedToolCalls(), "completed tool calls are not retained by default")
+	})
+
+	t.Run("RejectsArgsForUnknownToolCall", func(t *testing.T) {
+		a := NewToolCallAssembler()
+		_, _, err := a.Handle(NewToolCallArgsEvent("call-9", "{}"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "call-9")
+	})
+
+	t.Run("RejectsDuplicateStart", func(t *testing.T) {
+		a := NewToolCallAssembler()
+		_, _, err := a.Handle(NewToolCallStartEvent("call-1", "search"))
+		require.NoError(t, err)
+		_, _, err = a.Handle(NewToolCallStartEvent("call-1", "search"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "already started")
+
+		_, _, err = a.Handle(NewToolCallEndEvent("call-2"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not started")
+	})
+
+	t.Run("CorrelatesByIndexUntilIDIsAssigned", func(t *testing.T) {
+		a := NewToolCallAssembler()
+		stream := []Event{
+			NewToolCallStartEvent("", "search", WithToolCallIndex(0)),
+			NewToolCallStartEvent("", "lookup", WithToolCallIndex(1)),
+			NewToolCallArgsEventWithOptions("", `{"query":`, WithToolCallArgsIndex(0)),
+			NewToolCallArgsEventWithOptions("", `{}`, WithToolCallArgsIndex(1)),
+			// The ID is assigned mid-stream, after which either may be used
+			NewToolCallArgsEventWithOptions("call-a", `"wea`, WithToolCallArgsIndex(0)),
+			NewToolCallArgsEventWithOptions("", `ther"`, WithToolCallArgsIndex(0)),
+			NewToolCallArgsEvent("call-a", `}`),
+			NewToolCallEndEvent("call-a"),
+			// Or only on the final event
+			NewToolCallEndEventWithOptions("call-b", WithToolCallEndIndex(1)),
+		}
+
+		var completed []*ToolCall
+		for _, event := range stream {
+			if len(completed) == 0 && event.Type() == EventTypeToolCallEnd {
+				assert.ElementsMatch(t, []string{"call-a", "#1"}, a.Pending())
+			}
+			toolCall, done, err := a.Handle(event)
+			require.NoError(t, err)
+			if done {
+				completed = append(completed, toolCall)
+			}
+		}
+
+		require.Len(t, completed, 2)
+		assert.Equal(t, "call-a", completed[0].ID)
+		assert.Equal(t, "search", completed[0].Function.Name)
+		assert.JSONEq(t, `{"query":"weather"}`, completed[0].Function.Arguments)
+		assert.Equal(t, "call-b", completed[1].ID)
+		assert.Equal(t, "lookup", completed[1].Function.Name)
+		assert.Empty(t, a.Pending())
+
+		// Indexes are free for reuse once their tool call has ended
+		_, _, err := a.Handle(NewToolCallStartEvent("", "search", WithToolCallIndex(0)))
+		require.NoError(t, err)
+	})
+
+	t.Run("RejectsConflictingIndex", func(t *testing.T) {
+		a := NewToolCallAssembler()
+		for _, event := range []Event{
+			NewToolCallStartEvent("call-a", "search", WithToolCallIndex(0)),
+			NewToolCallStartEvent("", "lookup", WithToolCallIndex(1)),
+			NewToolCallStartEvent("call-c", "lookup"),
+		} {
+			_, _, err := a.Handle(event)
+			require.NoError(t, err)
+		}
+
+		_, _, err := a.Handle(NewToolCallStartEvent("", "search", WithToolCallIndex(1)))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "#1 already started")
+
+		_, _, err = a.Handle(NewToolCallArgsEventWithOptions("call-b", "{}", WithToolCallArgsIndex(0)))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "#0 already has id call-a, not call-b")
+
+		_, _, err = a.Handle(NewToolCallArgsEventWithOptions("call-a", "{}", WithToolCallArgsIndex(1)))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "call-a is not #1")
+
+		_, _, err = a.Handle(NewToolCallArgsEventWithOptions("", "{}", WithToolCallArgsIndex(2)))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "#2 that was not started")
+
+		// An index on an event for a tool call started by ID only is adopted
+		_, _, err = a.Handle(NewToolCallArgsEventWithOptions("call-c", "{}", WithToolCallArgsIndex(2)))
+		require.NoError(t, err)
+		toolCall, done, err := a.Handle(NewToolCallEndEventWithOptions("call-c", WithToolCallEndIndex(2)))
+		require.NoError(t, err)
+		require.True(t, done)
+		assert.Equal(t, "{}", toolCall.Function.Arguments)
+
+		index := 1
+		_, _, err = a.Handle(&ToolCallEndEvent{BaseEvent: NewBaseEvent(EventTypeToolCallEnd), Index: &index})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "ended without an id")
+	})
+
+	t.Run("WithSchema", func(t *testing.T) {
+		parameters := json.RawMessage(`{
+			"type": "object",
+			"required": ["query"],
+			"additionalProperties": false,
+			"properties": {"query": {"type": "string"}, "limit": {"type": "integer"}}
+		}`)
+		a := NewToolCallAssembler(WithSchema(parameters))
+
+		for _, event := range []Event{
+			NewToolCallStartEvent("call-1", "search"),
+			NewToolCallStartEvent("call-2", "search"),
+			NewToolCallStartEvent("call-3", "search"),
+			NewToolCallArgsEvent("call-1", `{"query": "wea`),
+			NewToolCallArgsEvent("call-2", `{"limit": 5`),
+		} {
+			_, _, err := a.Handle(event)
+			require.NoError(t, err)
+		}
+
+		// The prefix fails as soon as it cannot conform, and keeps failing
+		_, _, err := a.Handle(NewToolCallArgsEvent("call-3", `{"query": "x", "sort"`))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "call-3")
+		assert.Contains(t, err.Error(), "/sort: property is not allowed")
+		_, _, err = a.Handle(NewToolCallArgsEvent("call-3", `: "date"}`))
+		require.Error(t, err)
+		_, _, err = a.Handle(NewToolCallEndEvent("call-3"))
+		require.Error(t, err)
+
+		_, _, err = a.Handle(NewToolCallArgsEvent("call-1", `ther"}`))
+		require.NoError(t, err)
+		toolCall, done, err := a.Handle(NewToolCallEndEvent("call-1"))
+		require.NoError(t, err)
+		require.True(t, done)
+		assert.JSONEq(t, `{"query": "weather"}`, toolCall.Function.Arguments)
+
+		// Required properties can only be checked on end
+		_, _, err = a.Handle(NewToolCallArgsEvent("call-2", `}`))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `missing required property "query"`)
+		_, _, err = a.Handle(NewToolCallEndEvent("call-2"))
+		require.Error(t, err)
+		assert.Empty(t, a.Pending())
+
+		invalid := NewToolCallAssembler(WithSchema(json.RawMessage(`{"type": 5}`)))
+		_, _, err = invalid.Handle(NewToolCallStartEvent("call-1", "search"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid tool call schema")
+	})
+}
+
+func TestActivityAssembler(t *testing.T) {
+	t.Run("AppliesDeltasProgressively", func(t *testing.T) {
+		assembler := NewActivityAssembler()
+
+		activity, updated, err := assembler.Handle(NewActivitySnapshotEvent("act-1", "PLAN", map[string]any{"steps": []any{}}))
+		require.NoError(t, err)
+		require.True(t, updated)
+		assert.JSONEq(t, `{"steps":[]}`, string(activity.Content))
+
+		activity, err = assembler.ApplyContentDelta("act-1", "PLAN", json.RawMessage(`{"title":"Research","status":"running"}`))
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"steps":[],"title":"Research","status":"running"}`, string(activity.Content))
+
+		activity, updated, err = assembler.Handle(NewActivityDeltaEvent("act-1", "PLAN", []JSONPatchOperation{
+			{Op: "add", Path: "/steps/-", Value: "search"},
+		}))
+		require.NoError(t, err)
+		require.True(t, updated)
+		assert.JSONEq(t, `{"steps":["search"],"title":"Research","status":"running"}`, string(activity.Content))
+
+		activity, err = assembler.ApplyContentDelta("act-1", "", json.RawMessage(`{"status":null}`))
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"steps":["search"],"title":"Research"}`, string(activity.Content))
+
+		// Returned activities are copies
+		activity.Content[0] = '['
+		current, ok := assembler.Activity("act-1")
+		require.True(t, ok)
+		assert.Equal(t, "PLAN", current.ActivityType)
+		assert.JSONEq(t, `{"steps":["search"],"title":"Research"}`, string(current.Content))
+
+		assembler.Remove("act-1")
+		_, ok = assembler.Activity("act-1")
+		assert.False(t, ok)
+	})
+
+	t.Run("SnapshotReplaceFlag", func(t *testing.T) {
+		assembler := NewActivityAssembler()
+		_, _, err := assembler.Handle(NewActivitySnapshotEvent("act-1", "PLAN", map[string]any{"v": 1}))
+		require.NoError(t, err)
+
+		activity, updated, err := assembler.Handle(NewActivitySnapshotEvent("act-1", "PLAN", map[string]any{"v": 2}).WithReplace(false))
+		require.NoError(t, err)
+		assert.False(t, updated)
+		assert.JSONEq(t, `{"v":1}`, string(activity.Content))
+
+		activity, updated, err = assembler.Handle(NewActivitySnapshotEvent("act-1", "PLAN", map[string]any{"v": 3}))
+		require.NoError(t, err)
+		assert.True(t, updated)
+		assert.JSONEq(t, `{"v":3}`, string(activity.Content))
+	})
+
+	t.Run("RejectsOutOfOrderDeltas", func(t *testing.T) {
+		assembler := NewActivityAssembler()
+
+		_, err := assembler.ApplyContentDelta("act-1", "PLAN", json.RawMessage(`{"a":1}`))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no snapshot")
+
+		_, _, err = assembler.Handle(NewActivityDeltaEvent("act-1", "PLAN", []JSONPatchOperation{{Op: "add", Path: "/a", Value: 1}}))
+		require.Error(t, err)
+
+		_, _, err = assembler.Handle(NewActivitySnapshotEvent("act-1", "PLAN", map[string]any{"a": 1}))
+		require.NoError(t, err)
+
+		_, err = assembler.ApplyContentDelta("act-1", "SEARCH", json.RawMessage(`{"a":2}`))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "has type SEARCH")
+
+		_, _, err = assembler.Handle(NewActivityDeltaEvent("act-1", "PLAN", []JSONPatchOperation{{Op: "remove", Path: "/missing"}}))
+		require.Error(t, err)
+
+		_, err = assembler.ApplyContentDelta("act-1", "PLAN", json.RawMessage(`{"a":`))
+		require.Error(t, err)
+
+		// Failed deltas leave the content unchanged
+		current, _ := assembler.Activity("act-1")
+		assert.JSONEq(t, `{"a":1}`, string(current.Content))
+
+		_, updated, err := assembler.Handle(NewRunStartedEvent("thread-1", "run-1"))
+		assert.NoError(t, err)
+		assert.False(t, updated)
+	})
+}
@@ -0,0 +1,43 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// MarshalEvents encodes events as a single JSON array, each element being
+// the event's regular JSON form. It is meant for storing or comparing whole
+// event sequences; use a streaming encoder to send events as they happen.
+func MarshalEvents(evts []Event) ([]byte, error) {
+	raw := make([]json.RawMessage, len(evts))
+	for i, event := range evts {
+		if event == nil {
+			return nil, fmt.Errorf("event %d: event cannot be nil", i)
+		}
+		data, err := event.ToJSON()
+		if err != nil {
+			return nil, fmt.Errorf("event %d: failed to encode %s: %w", i, event.Type(), err)
+		}
+		raw[i] = data
+	}
+	return json.Marshal(raw)
+}
+
+// UnmarshalEvents decodes a JSON array written by MarshalEvents, choosing the
+// concrete event type of each element from its type field
+func UnmarshalEvents(data []byte) ([]Event, error) {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse event array: %w", err)
+	}
+
+	evts := make([]Event, len(raw))
+	for i, item := range raw {
+		event, err := EventFromJSON(item)
+		if err != nil {
+			return nil, fmt.Errorf("event %d: %w", i, err)
+		}
+		evts[i] = event
+	}
+	return evts, nil
+}
@@ -0,0 +1,63 @@
+package events
+
+import (
+	"errors"
+	"testing"
+
+	coretypes "github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalEvents(t *testing.T) {
+	original := []Event{
+		NewRunStartedEvent("thread-1", "run-1"),
+		NewTextMessageStartEvent("msg-1", WithRole("assistant")),
+		NewTextMessageContentEvent("msg-1", "Hello"),
+		NewTextMessageEndEvent("msg-1"),
+		NewStateSnapshotEvent(map[string]any{"count": float64(1)}),
+		NewMessagesSnapshotEvent([]Message{{ID: "msg-1", Role: coretypes.RoleAssistant, Content: "Hello"}}),
+		NewRunFinishedEvent("thread-1", "run-1"),
+	}
+
+	data, err := MarshalEvents(original)
+	require.NoError(t, err)
+	assert.Equal(t, byte('['), data[0])
+
+	decoded, err := UnmarshalEvents(data)
+	require.NoError(t, err)
+	require.Len(t, decoded, len(original))
+	for i := range original {
+		assert.Equal(t, original[i].Type(), decoded[i].Type())
+		want, err := MarshalCanonical(original[i])
+		require.NoError(t, err)
+		got, err := MarshalCanonical(decoded[i])
+		require.NoError(t, err)
+		assert.Equal(t, string(want), string(got))
+	}
+	assert.Equal(t, "Hello", decoded[2].(*TextMessageContentEvent).Delta)
+}
+
+func TestMarshalEvents_Empty(t *testing.T) {
+	data, err := MarshalEvents(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "[]", string(data))
+
+	decoded, err := UnmarshalEvents(data)
+	require.NoError(t, err)
+	assert.Empty(t, decoded)
+}
+
+func TestMarshalEvents_Errors(t *testing.T) {
+	_, err := MarshalEvents([]Event{NewRunStartedEvent("t", "r"), nil})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "event 1")
+
+	_, err = UnmarshalEvents([]byte(`{"type":"RUN_STARTED"}`))
+	assert.Error(t, err)
+
+	_, err = UnmarshalEvents([]byte(`[{"type":"RUN_STARTED","threadId":"t","runId":"r"},{"type":"NOPE"}]`))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "event 1")
+	assert.True(t, errors.Is(err, ErrUnknownEventType))
+}
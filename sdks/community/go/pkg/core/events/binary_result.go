@@ -0,0 +1,148 @@
+package events
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrChecksumMismatch is returned when a reassembled binary tool result does
+// not match the checksum of its final chunk
+var ErrChecksumMismatch = errors.New("binary result checksum mismatch")
+
+// BinaryResult is a binary tool result reassembled from
+// TOOL_CALL_RESULT_CHUNK events
+type BinaryResult struct {
+	MessageID  string
+	ToolCallID string
+	MimeType   string
+	Data       []byte
+}
+
+// BinaryResultAssembler reassembles binary tool results from
+// TOOL_CALL_RESULT_CHUNK events. Chunks of one result must arrive in
+// sequence, but results of different tool calls may be interleaved. Events
+// fed with HandleInStep are scoped to their step, so parallel sub-agents may
+// reuse tool call IDs. Completed results are returned by Handle and not kept,
+// as they may be large. A BinaryResultAssembler is safe for concurrent use.
+type BinaryResultAssembler struct {
+	mu      sync.Mutex
+	pending map[assemblyKey]*pendingBinaryResult
+	maxSize int
+}
+
+// pendingBinaryResult accumulates the chunks of a result that has not ended yet
+type pendingBinaryResult struct {
+	messageID string
+	mimeType  string
+	next      int
+	data      bytes.Buffer
+}
+
+// BinaryResultAssemblerOption defines options for creating binary result
+// assemblers
+type BinaryResultAssemblerOption func(*BinaryResultAssembler)
+
+// WithMaxResultSize fails results that grow beyond size bytes, so that a
+// misbehaving agent cannot exhaust memory. By default results are unbounded.
+func WithMaxResultSize(size int) BinaryResultAssemblerOption {
+	return func(a *BinaryResultAssembler) {
+		a.maxSize = size
+	}
+}
+
+// NewBinaryResultAssembler creates a new binary result assembler
+func NewBinaryResultAssembler(options ...BinaryResultAssemblerOption) *BinaryResultAssembler {
+	a := &BinaryResultAssembler{
+		pending: make(map[assemblyKey]*pendingBinaryResult),
+	}
+	for _, opt := range options {
+		opt(a)
+	}
+	return a
+}
+
+// Handle feeds an event into the assembler. When the event is the final
+// chunk of a result, the reassembled result is returned with done set to
+// true. Other events are ignored. An error is returned when a chunk is out
+// of sequence, disagrees with the earlier chunks of its result or exceeds
+// the size limit, and when the result does not match its checksum, in which
+// case the error wraps ErrChecksumMismatch. A failed result is dropped.
+func (a *BinaryResultAssembler) Handle(event Event) (result *BinaryResult, done bool, err error) {
+	return a.HandleInStep("", event)
+}
+
+// HandleInStep is like Handle for an event produced within the named step.
+// Tool call IDs only have to be unique within a step.
+func (a *BinaryResultAssembler) HandleInStep(step string, event Event) (result *BinaryResult, done bool, err error) {
+	chunk, ok := event.(*ToolCallResultChunkEvent)
+	if !ok {
+		return nil, false, nil
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	key := assemblyKey{step, chunk.ToolCallID}
+	pending, exists := a.pending[key]
+	if !exists {
+		pending = &pendingBinaryResult{messageID: chunk.MessageID}
+	}
+	if err := a.add(key, pending, chunk); err != nil {
+		delete(a.pending, key)
+		return nil, false, err
+	}
+	if !chunk.Final {
+		a.pending[key] = pending
+		return nil, false, nil
+	}
+
+	delete(a.pending, key)
+	data := pending.data.Bytes()
+	if checksum := ResultChecksum(data); checksum != chunk.Checksum {
+		return nil, false, fmt.Errorf("binary result of tool call %s has checksum %s, expected %s: %w",
+			key, checksum, chunk.Checksum, ErrChecksumMismatch)
+	}
+	return &BinaryResult{
+		MessageID:  pending.messageID,
+		ToolCallID: chunk.ToolCallID,
+		MimeType:   pending.mimeType,
+		Data:       data,
+	}, true, nil
+}
+
+// add appends a chunk to a pending result
+func (a *BinaryResultAssembler) add(key assemblyKey, pending *pendingBinaryResult, chunk *ToolCallResultChunkEvent) error {
+	if chunk.Sequence != pending.next {
+		return fmt.Errorf("binary result of tool call %s received chunk %d, expected %d", key, chunk.Sequence, pending.next)
+	}
+	if chunk.MessageID != pending.messageID {
+		return fmt.Errorf("binary result of tool call %s changed message from %s to %s", key, pending.messageID, chunk.MessageID)
+	}
+	if chunk.MimeType != "" {
+		if pending.mimeType != "" && pending.mimeType != chunk.MimeType {
+			return fmt.Errorf("binary result of tool call %s changed mime type from %s to %s", key, pending.mimeType, chunk.MimeType)
+		}
+		pending.mimeType = chunk.MimeType
+	}
+	if a.maxSize > 0 && pending.data.Len()+len(chunk.Data) > a.maxSize {
+		return fmt.Errorf("binary result of tool call %s exceeds %d bytes", key, a.maxSize)
+	}
+
+	pending.data.Write(chunk.Data)
+	pending.next++
+	return nil
+}
+
+// Pending returns the IDs of tool calls whose binary result has started but
+// not yet ended
+func (a *BinaryResultAssembler) Pending() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	ids := make([]string, 0, len(a.pending))
+	for key := range a.pending {
+		ids = append(ids, key.id)
+	}
+	return ids
+}
@@ -0,0 +1,110 @@
+package events
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBinaryResultAssembler(t *testing.T) {
+	t.Run("ReassemblesInterleavedResults", func(t *testing.T) {
+		image := bytes.Repeat([]byte{0x89, 'P', 'N', 'G', 0x00}, 100)
+		first := NewToolCallResultChunks("msg-1", "call-1", "image/png", image, 128)
+		second := NewToolCallResultChunks("msg-2", "call-2", "", []byte("raw"), 0)
+
+		a := NewBinaryResultAssembler()
+		stream := []*ToolCallResultChunkEvent{first[0], first[1], second[0], first[2], first[3]}
+		var results []*BinaryResult
+		for _, chunk := range stream {
+			result, done, err := a.Handle(chunk)
+			require.NoError(t, err)
+			if done {
+				results = append(results, result)
+			}
+		}
+
+		require.Len(t, results, 2)
+		assert.Equal(t, "call-2", results[0].ToolCallID)
+		assert.Equal(t, []byte("raw"), results[0].Data)
+		assert.Equal(t, &BinaryResult{MessageID: "msg-1", ToolCallID: "call-1", MimeType: "image/png", Data: image}, results[1])
+		assert.Empty(t, a.Pending())
+
+		_, done, err := a.Handle(NewToolCallResultEvent("msg-3", "call-3", "text"))
+		require.NoError(t, err)
+		assert.False(t, done)
+	})
+
+	t.Run("RejectsChecksumMismatch", func(t *testing.T) {
+		chunks := NewToolCallResultChunks("msg-1", "call-1", "", []byte("abcdef"), 3)
+		chunks[1].Data = []byte("xyz")
+
+		a := NewBinaryResultAssembler()
+		_, _, err := a.Handle(chunks[0])
+		require.NoError(t, err)
+		_, _, err = a.Handle(chunks[1])
+		assert.ErrorIs(t, err, ErrChecksumMismatch)
+		assert.Empty(t, a.Pending())
+	})
+
+	t.Run("RejectsOutOfSequenceChunks", func(t *testing.T) {
+		chunks := NewToolCallResultChunks("msg-1", "call-1", "", []byte("abcdef"), 2)
+
+		a := NewBinaryResultAssembler()
+		_, _, err := a.Handle(chunks[1])
+		assert.ErrorContains(t, err, "received chunk 1, expected 0")
+
+		_, _, err = a.Handle(chunks[0])
+		require.NoError(t, err)
+		assert.Equal(t, []string{"call-1"}, a.Pending())
+		_, _, err = a.Handle(chunks[2])
+		assert.ErrorContains(t, err, "received chunk 2, expected 1")
+		assert.Empty(t, a.Pending(), "a failed result is dropped")
+	})
+
+	t.Run("RejectsInconsistentChunks", func(t *testing.T) {
+		chunks := NewToolCallResultChunks("msg-1", "call-1", "image/png", []byte("abcdef"), 3)
+		chunks[1].MessageID = "msg-2"
+
+		a := NewBinaryResultAssembler()
+		_, _, err := a.Handle(chunks[0])
+		require.NoError(t, err)
+		_, _, err = a.Handle(chunks[1])
+		assert.ErrorContains(t, err, "changed message from msg-1 to msg-2")
+
+		chunks = NewToolCallResultChunks("msg-1", "call-1", "image/png", []byte("abcdef"), 3)
+		chunks[1].MimeType = "image/jpeg"
+		_, _, err = a.Handle(chunks[0])
+		require.NoError(t, err)
+		_, _, err = a.Handle(chunks[1])
+		assert.ErrorContains(t, err, "changed mime type")
+	})
+
+	t.Run("EnforcesMaxSize", func(t *testing.T) {
+		chunks := NewToolCallResultChunks("msg-1", "call-1", "", []byte("abcdef"), 4)
+
+		a := NewBinaryResultAssembler(WithMaxResultSize(5))
+		_, _, err := a.Handle(chunks[0])
+		require.NoError(t, err)
+		_, _, err = a.Handle(chunks[1])
+		assert.ErrorContains(t, err, "exceeds 5 bytes")
+	})
+
+	t.Run("ScopesResultsToSteps", func(t *testing.T) {
+		a := NewBinaryResultAssembler()
+		planner := NewToolCallResultChunks("msg-1", "call-1", "", []byte("plan"), 2)
+		writer := NewToolCallResultChunks("msg-2", "call-1", "", []byte("draft"), 2)
+
+		_, _, err := a.HandleInStep("planner", planner[0])
+		require.NoError(t, err)
+		for _, chunk := range writer {
+			_, _, err := a.HandleInStep("writer", chunk)
+			require.NoError(t, err)
+		}
+		result, done, err := a.HandleInStep("planner", planner[1])
+		require.NoError(t, err)
+		require.True(t, done)
+		assert.Equal(t, []byte("plan"), result.Data)
+	})
+}
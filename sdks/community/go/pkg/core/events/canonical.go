@@ -0,0 +1,92 @@
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// MarshalCanonical serializes an event to a canonical JSON form suitable for
+// signing, hashing and content-addressed storage. Object keys are sorted at
+// every level, insignificant whitespace is removed, HTML characters are not
+// escaped and numbers keep their shortest encoding, so logically identical
+// events always produce identical bytes. The result decodes with
+// EventFromJSON like any other event JSON.
+func MarshalCanonical(event Event) ([]byte, error) {
+	if event == nil {
+		return nil, fmt.Errorf("event cannot be nil")
+	}
+
+	data, err := event.ToJSON()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode event: %w", err)
+	}
+	return CanonicalJSON(data)
+}
+
+// CanonicalJSON rewrites a JSON document in the canonical form produced by
+// MarshalCanonical
+func CanonicalJSON(data []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("failed to decode JSON: %w", err)
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("failed to decode JSON: unexpected data after top-level value")
+	}
+
+	// encoding/json writes map keys in sorted order; json.Number keeps the
+	// number's original literal, so it is normalized separately
+	value, err := canonicalNumbers(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return nil, fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// canonicalNumbers replaces json.Number values with their shortest form, so
+// that 1.0, 1e0 and 1 encode identically. Integers that fit in an int64 are
+// kept exact.
+func canonicalNumbers(value any) (any, error) {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, member := range v {
+			normalized, err := canonicalNumbers(member)
+			if err != nil {
+				return nil, err
+			}
+			v[key] = normalized
+		}
+	case []any:
+		for i, item := range v {
+			normalized, err := canonicalNumbers(item)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = normalized
+		}
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i, nil
+		}
+		f, err := v.Float64()
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %s: %w", v, err)
+		}
+		if f == float64(int64(f)) && f >= -(1<<53) && f <= 1<<53 {
+			return int64(f), nil
+		}
+		return f, nil
+	}
+	return value, nil
+}
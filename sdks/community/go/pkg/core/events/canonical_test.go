@@ -0,0 +1,54 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalCanonical(t *testing.T) {
+	newEvent := func() *StateSnapshotEvent {
+		event := NewStateSnapshotEvent(map[string]any{
+			"zeta":  []any{map[string]any{"b": 1, "a": 2.5}},
+			"alpha": "<b>&</b>",
+			"mid":   map[string]any{"y": true, "x": nil},
+		})
+		event.SetTimestamp(1700000000000)
+		return event
+	}
+
+	first, err := MarshalCanonical(newEvent())
+	require.NoError(t, err)
+	for i := 0; i < 20; i++ {
+		again, err := MarshalCanonical(newEvent())
+		require.NoError(t, err)
+		assert.Equal(t, string(first), string(again))
+	}
+	assert.Equal(t,
+		`{"snapshot":{"alpha":"<b>&</b>","mid":{"x":null,"y":true},"zeta":[{"a":2.5,"b":1}]},"timestamp":1700000000000,"type":"STATE_SNAPSHOT"}`,
+		string(first))
+
+	decoded, err := EventFromJSON(first)
+	require.NoError(t, err)
+	roundTrip, err := MarshalCanonical(decoded)
+	require.NoError(t, err)
+	assert.Equal(t, string(first), string(roundTrip))
+
+	_, err = MarshalCanonical(nil)
+	assert.Error(t, err)
+}
+
+func TestCanonicalJSON(t *testing.T) {
+	a, err := CanonicalJSON([]byte(`{ "b": 1.0, "a": [1e2, -0.5, 12345678901234567890] }`))
+	require.NoError(t, err)
+	b, err := CanonicalJSON([]byte(`{"a":[100,-0.5,12345678901234567890],"b":1}`))
+	require.NoError(t, err)
+	assert.Equal(t, string(a), string(b))
+	assert.Equal(t, `{"a":[100,-0.5,12345678901234567000],"b":1}`, string(a))
+
+	_, err = CanonicalJSON([]byte(`{"a":1} {}`))
+	assert.Error(t, err)
+	_, err = CanonicalJSON([]byte(`{`))
+	assert.Error(t, err)
+}
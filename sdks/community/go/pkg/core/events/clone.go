@@ -0,0 +1,15 @@
+package events
+
+import "github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/internal/deepcopy"
+
+// CloneEvent returns a deep copy of an event, including its base fields,
+// raw event, messages and state, so that the copy can be transformed in a
+// pipeline without affecting the original or other consumers of it
+func CloneEvent(event Event) Event {
+	return deepcopy.Copy(event)
+}
+
+// CloneEvents returns deep copies of the events
+func CloneEvents(evts []Event) []Event {
+	return deepcopy.Copy(evts)
+}
@@ -0,0 +1,42 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloneEvent(t *testing.T) {
+	snapshot := NewMessagesSnapshotEvent([]Message{
+		{ID: "msg-1", Role: "user", Content: "hi"},
+		{ID: "act-1", Role: "activity", ActivityType: "PLAN", Content: map[string]any{"steps": []any{"a"}}},
+	})
+	snapshot.EventID = "7"
+	snapshot.RawEvent = map[string]any{"source": "upstream"}
+
+	clone, ok := CloneEvent(snapshot).(*MessagesSnapshotEvent)
+	require.True(t, ok)
+	assert.Equal(t, snapshot, clone)
+	assert.Equal(t, "7", clone.EventID)
+
+	clone.Messages[0].Content = "changed"
+	clone.Messages[1].Content.(map[string]any)["steps"].([]any)[0] = "changed"
+	clone.RawEvent.(map[string]any)["source"] = "changed"
+	*clone.TimestampMs = 0
+	clone.EventID = "8"
+
+	assert.Equal(t, "hi", snapshot.Messages[0].Content)
+	assert.Equal(t, "a", snapshot.Messages[1].Content.(map[string]any)["steps"].([]any)[0])
+	assert.Equal(t, "upstream", snapshot.RawEvent.(map[string]any)["source"])
+	assert.NotZero(t, *snapshot.TimestampMs)
+	assert.Equal(t, "7", snapshot.EventID)
+
+	state := NewStateSnapshotEvent(map[string]any{"count": 1})
+	evts := CloneEvents([]Event{state, nil})
+	require.Len(t, evts, 2)
+	evts[0].(*StateSnapshotEvent).Snapshot.(map[string]any)["count"] = 2
+	assert.Equal(t, 1, state.Snapshot.(map[string]any)["count"])
+	assert.Nil(t, evts[1])
+	assert.Nil(t, CloneEvent(nil))
+}
@@ -3,6 +3,7 @@ package events
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 )
 
 // RawEvent contains raw event data that should be passed through without processing
@@ -75,6 +76,16 @@ func NewCustomEvent(name string, options ...CustomEventOption) *CustomEvent {
 	return event
 }
 
+// NewTypedCustomEvent creates a custom event whose value is the JSON encoding
+// of value, so that receivers can decode it with Decode
+func NewTypedCustomEvent(name string, value any) (*CustomEvent, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal custom event %s value: %w", name, err)
+	}
+	return NewCustomEvent(name, WithValue(json.RawMessage(data))), nil
+}
+
 // CustomEventOption defines options for creating custom events
 type CustomEventOption func(*CustomEvent)
 
@@ -102,3 +113,49 @@ func (e *CustomEvent) Validate() error {
 func (e *CustomEvent) ToJSON() ([]byte, error) {
 	return json.Marshal(e)
 }
+
+// Namespace returns the part of the name before the first dot, such as
+// "myapp" for "myapp.foo". Names without a dot have no namespace.
+func (e *CustomEvent) Namespace() string {
+	namespace, _, found := strings.Cut(e.Name, ".")
+	if !found {
+		return ""
+	}
+	return namespace
+}
+
+// LocalName returns the part of the name after the first dot, such as "foo"
+// for "myapp.foo", or the whole name when it has no namespace
+func (e *CustomEvent) LocalName() string {
+	_, local, found := strings.Cut(e.Name, ".")
+	if !found {
+		return e.Name
+	}
+	return local
+}
+
+// Decode unmarshals the event value into v, which should be a pointer to the
+// application's payload type
+func (e *CustomEvent) Decode(v any) error {
+	var data []byte
+	switch value := e.Value.(type) {
+	case json.RawMessage:
+		data = value
+	case []byte:
+		data = value
+	default:
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("failed to encode custom event %s value: %w", e.Name, err)
+		}
+		data = encoded
+	}
+
+	if len(data) == 0 {
+		data = []byte("null")
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to decode custom event %s value: %w", e.Name, err)
+	}
+	return nil
+}
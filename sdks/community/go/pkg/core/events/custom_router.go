@@ -0,0 +1,84 @@
+package events
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrNoCustomEventHandler is returned by CustomEventRouter.Dispatch when no
+// handler is registered for the event's namespace and there is no fallback
+var ErrNoCustomEventHandler = errors.New("no handler for custom event namespace")
+
+// CustomEventHandler handles custom events routed to it by namespace
+type CustomEventHandler func(event *CustomEvent) error
+
+// CustomEventRouter dispatches custom events to handlers registered for their
+// namespace (see CustomEvent.Namespace), so that independently developed
+// extensions sharing a stream each see only their own events. It is safe for
+// concurrent use.
+type CustomEventRouter struct {
+	mu       sync.RWMutex
+	handlers map[string]CustomEventHandler
+	fallback CustomEventHandler
+}
+
+// NewCustomEventRouter creates a router with no handlers
+func NewCustomEventRouter() *CustomEventRouter {
+	return &CustomEventRouter{handlers: make(map[string]CustomEventHandler)}
+}
+
+// Handle registers handler for the events of namespace, replacing any handler
+// already registered for it. The empty namespace matches names without a dot.
+func (r *CustomEventRouter) Handle(namespace string, handler CustomEventHandler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers[namespace] = handler
+}
+
+// Remove unregisters the handler for namespace
+func (r *CustomEventRouter) Remove(namespace string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.handlers, namespace)
+}
+
+// HandleDefault sets the handler for events whose namespace has no handler.
+// A nil handler removes it.
+func (r *CustomEventRouter) HandleDefault(handler CustomEventHandler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.fallback = handler
+}
+
+// Dispatch passes event to the handler for its namespace and returns the
+// handler's error. It returns ErrNoCustomEventHandler (wrapped) when no
+// handler matches.
+func (r *CustomEventRouter) Dispatch(event *CustomEvent) error {
+	if event == nil {
+		return fmt.Errorf("event cannot be nil")
+	}
+
+	namespace := event.Namespace()
+	r.mu.RLock()
+	handler, ok := r.handlers[namespace]
+	if !ok {
+		handler = r.fallback
+	}
+	r.mu.RUnlock()
+
+	if handler == nil {
+		return fmt.Errorf("%w %q (event %s)", ErrNoCustomEventHandler, namespace, event.Name)
+	}
+	return handler(event)
+}
+
+// DispatchEvent dispatches event when it is a CUSTOM event and ignores all
+// other events, so that it can be called for every event of a stream
+func (r *CustomEventRouter) DispatchEvent(event Event) error {
+	custom, ok := event.(*CustomEvent)
+	if !ok {
+		return nil
+	}
+	return r.Dispatch(custom)
+}
@@ -0,0 +1,65 @@
+package events
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomEventNamespace(t *testing.T) {
+	cases := []struct {
+		name      string
+		namespace string
+		local     string
+	}{
+		{"myapp.foo", "myapp", "foo"},
+		{"myapp.foo.bar", "myapp", "foo.bar"},
+		{"foo", "", "foo"},
+		{".foo", "", "foo"},
+		{"myapp.", "myapp", ""},
+	}
+	for _, tc := range cases {
+		event := NewCustomEvent(tc.name)
+		assert.Equal(t, tc.namespace, event.Namespace(), tc.name)
+		assert.Equal(t, tc.local, event.LocalName(), tc.name)
+	}
+}
+
+func TestCustomEventRouter(t *testing.T) {
+	var routed []string
+	record := func(prefix string) CustomEventHandler {
+		return func(event *CustomEvent) error {
+			routed = append(routed, prefix+":"+event.LocalName())
+			return nil
+		}
+	}
+
+	router := NewCustomEventRouter()
+	router.Handle("app1", record("app1"))
+	router.Handle("app2", record("app2"))
+	router.Handle("", record("global"))
+
+	require.NoError(t, router.Dispatch(NewCustomEvent("app1.open")))
+	require.NoError(t, router.Dispatch(NewCustomEvent("app2.open")))
+	require.NoError(t, router.Dispatch(NewCustomEvent("ping")))
+	require.NoError(t, router.DispatchEvent(NewRunStartedEvent("t", "r")))
+	assert.Equal(t, []string{"app1:open", "app2:open", "global:ping"}, routed)
+
+	err := router.Dispatch(NewCustomEvent("app3.open"))
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrNoCustomEventHandler))
+
+	router.HandleDefault(record("default"))
+	require.NoError(t, router.DispatchEvent(NewCustomEvent("app3.open")))
+	assert.Equal(t, "default:open", routed[len(routed)-1])
+
+	router.Remove("app1")
+	require.NoError(t, router.Dispatch(NewCustomEvent("app1.close")))
+	assert.Equal(t, "default:close", routed[len(routed)-1])
+
+	handlerErr := errors.New("boom")
+	router.Handle("app2", func(*CustomEvent) error { return handlerErr })
+	assert.Equal(t, handlerErr, router.Dispatch(NewCustomEvent("app2.open")))
+}
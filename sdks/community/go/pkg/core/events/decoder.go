@@ -9,25 +9,56 @@ import (
 
 // EventDecoder handles decoding of SSE events to Go SDK event types
 type EventDecoder struct {
-	logger *logrus.Logger
+	logger         *logrus.Logger
+	strictMessages bool
+	registry       *Registry
+}
+
+// EventDecoderOption defines options for creating event decoders
+type EventDecoderOption func(*EventDecoder)
+
+// WithStrictMessages validates the messages of MESSAGES_SNAPSHOT events while
+// decoding them, so that content that does not fit the message role fails at
+// the parse boundary. The returned error wraps a MessageErrors whose entries
+// wrap *MessageValidationError.
+func WithStrictMessages(strict bool) EventDecoderOption {
+	return func(ed *EventDecoder) {
+		ed.strictMessages = strict
+	}
+}
+
+// WithRegistry decodes events of the types registered with registry using
+// their factories instead of rejecting them as unknown
+func WithRegistry(registry *Registry) EventDecoderOption {
+	return func(ed *EventDecoder) {
+		ed.registry = registry
+	}
 }
 
 // NewEventDecoder creates a new event decoder
-func NewEventDecoder(logger *logrus.Logger) *EventDecoder {
+func NewEventDecoder(logger *logrus.Logger, options ...EventDecoderOption) *EventDecoder {
 	if logger == nil {
 		logger = logrus.New()
 	}
-	return &EventDecoder{logger: logger}
+	ed := &EventDecoder{logger: logger}
+	for _, opt := range options {
+		opt(ed)
+	}
+	return ed
 }
 
 // DecodeEvent decodes a raw SSE event into the appropriate Go SDK event type
 func (ed *EventDecoder) DecodeEvent(eventName string, data []byte) (Event, error) {
-	eventType := EventType(eventName)
-
-	// Check if this is a valid event type
-	if !isValidEventType(eventType) {
+	// Reject event types that are not part of the protocol
+	eventType, err := ParseEventType(eventName)
+	if err != nil && ed.registry != nil {
+		if factory, ok := ed.registry.factory(EventType(eventName)); ok {
+			return decodeRegistered(EventType(eventName), factory, data)
+		}
+	}
+	if err != nil {
 		ed.logger.WithField("event", eventName).Warn("Unknown event type")
-		return nil, fmt.Errorf("unknown event type: %s", eventName)
+		return nil, err
 	}
 
 	// Decode based on event type
@@ -67,6 +98,13 @@ func (ed *EventDecoder) DecodeEvent(eventName string, data []byte) (Event, error
 		}
 		return &evt, nil
 
+	case EventTypeMessageUpdate:
+		var evt MessageUpdateEvent
+		if err := json.Unmarshal(data, &evt); err != nil {
+			return nil, fmt.Errorf("failed to decode MESSAGE_UPDATE: %w", err)
+		}
+		return &evt, nil
+
 	case EventTypeTextMessageContent:
 		var evt TextMessageContentEvent
 		if err := json.Unmarshal(data, &evt); err != nil {
@@ -74,6 +112,13 @@ func (ed *EventDecoder) DecodeEvent(eventName string, data []byte) (Event, error
 		}
 		return &evt, nil
 
+	case EventTypeTextReasoningContent:
+		var evt TextReasoningContentEvent
+		if err := json.Unmarshal(data, &evt); err != nil {
+			return nil, fmt.Errorf("failed to decode TEXT_REASONING_CONTENT: %w", err)
+		}
+		return &evt, nil
+
 	case EventTypeTextMessageEnd:
 		var evt TextMessageEndEvent
 		if err := json.Unmarshal(data, &evt); err != nil {
@@ -109,6 +154,13 @@ func (ed *EventDecoder) DecodeEvent(eventName string, data []byte) (Event, error
 		}
 		return &evt, nil
 
+	case EventTypeToolCallResultChunk:
+		var evt ToolCallResultChunkEvent
+		if err := json.Unmarshal(data, &evt); err != nil {
+			return nil, fmt.Errorf("failed to decode TOOL_CALL_RESULT_CHUNK: %w", err)
+		}
+		return &evt, nil
+
 	case EventTypeStateSnapshot:
 		var evt StateSnapshotEvent
 		if err := json.Unmarshal(data, &evt); err != nil {
@@ -128,6 +180,11 @@ func (ed *EventDecoder) DecodeEvent(eventName string, data []byte) (Event, error
 		if err := json.Unmarshal(data, &evt); err != nil {
 			return nil, fmt.Errorf("failed to decode MESSAGES_SNAPSHOT: %w", err)
 		}
+		if ed.strictMessages {
+			if err := ValidateMessages(evt.Messages); err != nil {
+				return nil, fmt.Errorf("failed to decode MESSAGES_SNAPSHOT: %w", err)
+			}
+		}
 		return &evt, nil
 
 	case EventTypeActivitySnapshot:
@@ -1,8 +1,10 @@
 package events
 
 import (
+	"errors"
 	"testing"
 
+	coretypes "github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/types"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -210,7 +212,7 @@ func TestEventDecoder(t *testing.T) {
 
 	t.Run("DecodeEvent_MessagesSnapshot", func(t *testing.T) {
 		decoder := NewEventDecoder(nil)
-		data := []byte(`{"messages": [{"id": "msg-1", "role": "user", "content": "Hello"}]}`)
+		data := []byte(`{"type": "MESSAGES_SNAPSHOT", "messages": [{"id": "msg-1", "role": "user", "content": "Hello"}]}`)
 
 		event, err := decoder.DecodeEvent("MESSAGES_SNAPSHOT", data)
 		require.NoError(t, err)
@@ -435,3 +437,73 @@ func TestEventDecoder(t *testing.T) {
 		assert.Nil(t, event)
 	})
 }
+
+func TestEventDecoderStrictMessages(t *testing.T) {
+	data := []byte(`{"type": "MESSAGES_SNAPSHOT", "messages": [
+		{"id": "msg-1", "role": "user", "content": "hi"},
+		{"id": "msg-2", "role": "tool", "content": "42"}
+	]}`)
+
+	// Without the option the snapshot decodes and only fails validation later
+	event, err := NewEventDecoder(nil).DecodeEvent("MESSAGES_SNAPSHOT", data)
+	require.NoError(t, err)
+	assert.Error(t, event.Validate())
+
+	_, err = NewEventDecoder(nil, WithStrictMessages(true)).DecodeEvent("MESSAGES_SNAPSHOT", data)
+	require.Error(t, err)
+
+	var validationErr *MessageValidationError
+	require.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, "toolCallId", validationErr.Field)
+	assert.Equal(t, "tool", string(validationErr.Role))
+	assert.Equal(t, "toolCallId field is required for tool messages", validationErr.Reason)
+
+	var msgErr *MessageError
+	require.True(t, errors.As(err, &msgErr))
+	assert.Equal(t, 1, msgErr.Index)
+}
+
+func TestMessageValidationError(t *testing.T) {
+	tests := []struct {
+		name  string
+		msg   Message
+		field string
+	}{
+		{"MissingID", Message{Role: "user", Content: "hi"}, "id"},
+		{"UserContent", Message{ID: "m", Role: "user", Content: 42}, "content"},
+		{"ActivityOnUser", Message{ID: "m", Role: "user", Content: "hi", ActivityType: "PLAN"}, "activityType"},
+		{"BadToolCall", Message{ID: "m", Role: "assistant", ToolCalls: []ToolCall{{ID: "c"}}}, "toolCalls[0]"},
+		{"DuplicateToolCallID", Message{ID: "m", Role: "assistant", ToolCalls: []ToolCall{
+			{ID: "c1", Type: "function", Function: Function{Name: "a"}},
+			{ID: "c2", Type: "function", Function: Function{Name: "b"}},
+			{ID: "c1", Type: "function", Function: Function{Name: "c"}},
+		}}, "toolCalls[2].id"},
+		{"AudioFieldsOnImage", Message{ID: "m", Role: "user", Content: []coretypes.InputContent{
+			{Type: "text", Text: "listen"},
+			{Type: "binary", MimeType: "image/png", Data: "AAAA", SampleRate: 16000},
+		}}, "content[1]"},
+		{"NegativeChannels", Message{ID: "m", Role: "user", Content: []coretypes.InputContent{
+			{Type: "binary", MimeType: "audio/pcm", Data: "AAAA", Channels: -1},
+		}}, "content[0]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var validationErr *MessageValidationError
+			require.True(t, errors.As(validateMessage(tt.msg), &validationErr))
+			assert.Equal(t, tt.field, validationErr.Field)
+			assert.Equal(t, tt.msg.Role, validationErr.Role)
+		})
+	}
+
+	err := validateMessage(Message{ID: "m", Role: "assistant", ToolCalls: []ToolCall{
+		{ID: "call-1", Type: "function", Function: Function{Name: "a"}},
+		{ID: "call-1", Type: "function", Function: Function{Name: "b"}},
+	}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "duplicate tool call id call-1")
+
+	assert.NoError(t, validateMessage(Message{ID: "m", Role: "user", Content: []coretypes.InputContent{
+		{Type: "binary", MimeType: "audio/pcm", Data: "AAAA", SampleRate: 16000, Channels: 1, Encoding: "pcm_s16le"},
+		{Type: "audio", Source: &coretypes.InputContentSource{Type: "data", Value: "AAAA", MimeType: "audio/wav"}, SampleRate: 44100},
+	}}))
+}
@@ -0,0 +1,107 @@
+package events
+
+import (
+	"reflect"
+
+	coretypes "github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/types"
+)
+
+// DiffMessages returns the events that bring a client holding old up to date
+// with updated. When updated only appends messages to old, each new message is
+// replayed as streaming events (text messages, tool calls, tool results,
+// reasoning messages and activity snapshots). Any other change, or an appended
+// message that cannot be expressed as streaming events, yields a single
+// MESSAGES_SNAPSHOT. Identical conversations yield no events.
+func DiffMessages(old, updated []Message) []Event {
+	if len(updated) >= len(old) && reflect.DeepEqual(old, updated[:len(old)]) {
+		var result []Event
+		for _, msg := range updated[len(old):] {
+			replayed, ok := messageEvents(msg)
+			if !ok {
+				return []Event{NewMessagesSnapshotEvent(updated)}
+			}
+			result = append(result, replayed...)
+		}
+		return result
+	}
+
+	return []Event{NewMessagesSnapshotEvent(updated)}
+}
+
+// messageEvents converts a single message into the streaming events that
+// produce it, reporting false when it has no streaming equivalent
+func messageEvents(msg Message) ([]Event, bool) {
+	// Streaming events cannot carry metadata, so only a snapshot preserves it
+	if msg.ID == "" || len(msg.Metadata) > 0 {
+		return nil, false
+	}
+
+	switch msg.Role {
+	case coretypes.RoleUser, coretypes.RoleSystem, coretypes.RoleDeveloper, coretypes.RoleAssistant:
+		// Text events would flatten assistant content parts
+		if _, ok := msg.ContentAssistantContents(); ok {
+			return nil, false
+		}
+		text, ok := msg.ContentString()
+		if !ok && msg.Content != nil {
+			return nil, false
+		}
+		if msg.Role != coretypes.RoleAssistant && (len(msg.ToolCalls) > 0 || msg.Reasoning != "") {
+			return nil, false
+		}
+
+		var result []Event
+		if text != "" || msg.Reasoning != "" || len(msg.ToolCalls) == 0 {
+			options := []TextMessageStartOption{WithRole(string(msg.Role))}
+			if msg.Name != "" {
+				options = append(options, WithName(msg.Name))
+			}
+			result = append(result, NewTextMessageStartEvent(msg.ID, options...))
+			if msg.Reasoning != "" {
+				result = append(result, NewTextReasoningContentEvent(msg.ID, msg.Reasoning))
+			}
+			if text != "" {
+				result = append(result, NewTextMessageContentEvent(msg.ID, text))
+			}
+			result = append(result, NewTextMessageEndEvent(msg.ID))
+		}
+		for _, toolCall := range msg.ToolCalls {
+			if toolCall.ID == "" || toolCall.Function.Name == "" {
+				return nil, false
+			}
+			result = append(result, NewToolCallStartEvent(toolCall.ID, toolCall.Function.Name, WithParentMessageID(msg.ID)))
+			if toolCall.Function.Arguments != "" {
+				result = append(result, NewToolCallArgsEvent(toolCall.ID, toolCall.Function.Arguments))
+			}
+			result = append(result, NewToolCallEndEvent(toolCall.ID))
+		}
+		return result, true
+
+	case coretypes.RoleTool:
+		content, ok := msg.ContentString()
+		if !ok || content == "" || msg.ToolCallID == "" || msg.Error != "" {
+			return nil, false
+		}
+		return []Event{NewToolCallResultEvent(msg.ID, msg.ToolCallID, content)}, true
+
+	case coretypes.RoleReasoning:
+		content, ok := msg.ContentString()
+		if !ok || content == "" {
+			return nil, false
+		}
+		return []Event{
+			NewReasoningMessageStartEvent(msg.ID, string(msg.Role)),
+			NewReasoningMessageContentEvent(msg.ID, content),
+			NewReasoningMessageEndEvent(msg.ID),
+		}, true
+
+	case coretypes.RoleActivity:
+		content, ok := msg.ContentActivity()
+		if !ok || msg.ActivityType == "" {
+			return nil, false
+		}
+		return []Event{NewActivitySnapshotEvent(msg.ID, msg.ActivityType, content)}, true
+	}
+
+	return nil, false
+}
@@ -0,0 +1,155 @@
+package events
+
+import (
+	"testing"
+
+	coretypes "github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func eventTypes(evts []Event) []EventType {
+	result := make([]EventType, len(evts))
+	for i, event := range evts {
+		result[i] = event.Type()
+	}
+	return result
+}
+
+func TestDiffMessages_Appended(t *testing.T) {
+	old := []Message{{ID: "msg-1", Role: coretypes.RoleUser, Content: "weather?"}}
+	updated := append(append([]Message{}, old...),
+		Message{
+			ID:      "msg-2",
+			Role:    coretypes.RoleAssistant,
+			Content: "Checking",
+			ToolCalls: []ToolCall{{
+				ID:       "call-1",
+				Type:     "function",
+				Function: Function{Name: "weather", Arguments: `{"city":"Paris"}`},
+			}},
+		},
+		Message{ID: "msg-3", Role: coretypes.RoleTool, Content: "sunny", ToolCallID: "call-1"},
+		Message{ID: "msg-4", Role: coretypes.RoleReasoning, Content: "it is sunny"},
+		Message{ID: "msg-5", Role: coretypes.RoleActivity, ActivityType: "PLAN", Content: map[string]any{"done": true}},
+	)
+
+	diff := DiffMessages(old, updated)
+	assert.Equal(t, []EventType{
+		EventTypeTextMessageStart,
+		EventTypeTextMessageContent,
+		EventTypeTextMessageEnd,
+		EventTypeToolCallStart,
+		EventTypeToolCallArgs,
+		EventTypeToolCallEnd,
+		EventTypeToolCallResult,
+		EventTypeReasoningMessageStart,
+		EventTypeReasoningMessageContent,
+		EventTypeReasoningMessageEnd,
+		EventTypeActivitySnapshot,
+	}, eventTypes(diff))
+	for _, event := range diff {
+		assert.NoError(t, event.Validate())
+	}
+
+	start := diff[0].(*TextMessageStartEvent)
+	require.NotNil(t, start.Role)
+	assert.Equal(t, "assistant", *start.Role)
+	assert.Equal(t, "msg-2", *diff[3].(*ToolCallStartEvent).ParentMessageID)
+	assert.Equal(t, `{"city":"Paris"}`, diff[4].(*ToolCallArgsEvent).Delta)
+
+	// Replaying the tool call events rebuilds the original call
+	assembler := NewToolCallAssembler()
+	var rebuilt *ToolCall
+	for _, event := range diff[3:6] {
+		call, done, err := assembler.Handle(event)
+		require.NoError(t, err)
+		if done {
+			rebuilt = call
+		}
+	}
+	require.NotNil(t, rebuilt)
+	assert.Equal(t, updated[1].ToolCalls[0], *rebuilt)
+}
+
+func TestDiffMessages_Reasoning(t *testing.T) {
+	old := []Message{{ID: "msg-1", Role: coretypes.RoleUser, Content: "6 times 7?"}}
+	msg := Message{ID: "msg-2", Role: coretypes.RoleAssistant, Content: "42", Reasoning: "6 times 7 is 42"}
+	diff := DiffMessages(old, append(append([]Message{}, old...), msg))
+	assert.Equal(t, []EventType{
+		EventTypeTextMessageStart,
+		EventTypeTextReasoningContent,
+		EventTypeTextMessageContent,
+		EventTypeTextMessageEnd,
+	}, eventTypes(diff))
+
+	assembler := NewMessageAssembler()
+	var rebuilt *Message
+	for _, event := range diff {
+		assembled, done, err := assembler.Handle(event)
+		require.NoError(t, err)
+		if done {
+			rebuilt = assembled
+		}
+	}
+	require.NotNil(t, rebuilt)
+	assert.Equal(t, msg, *rebuilt)
+}
+
+func TestDiffMessages_Metadata(t *testing.T) {
+	old := []Message{{ID: "msg-1", Role: coretypes.RoleUser, Content: "hi"}}
+	msg := Message{ID: "msg-2", Role: coretypes.RoleAssistant, Content: "hello", Metadata: map[string]any{"avatar": "bot"}}
+	updated := append(append([]Message{}, old...), msg)
+
+	// Streaming events would drop the metadata, so a snapshot is sent
+	diff := DiffMessages(old, updated)
+	require.Len(t, diff, 1)
+	snapshot, ok := diff[0].(*MessagesSnapshotEvent)
+	require.True(t, ok)
+	assert.Equal(t, "bot", snapshot.Messages[1].Metadata["avatar"])
+	assert.NoError(t, snapshot.Validate())
+}
+
+func TestDiffMessages_Unchanged(t *testing.T) {
+	messages := []Message{{ID: "msg-1", Role: coretypes.RoleUser, Content: "hi"}}
+	assert.Empty(t, DiffMessages(messages, messages))
+	assert.Empty(t, DiffMessages(nil, nil))
+}
+
+func TestDiffMessages_FallsBackToSnapshot(t *testing.T) {
+	old := []Message{
+		{ID: "msg-1", Role: coretypes.RoleUser, Content: "hi"},
+		{ID: "msg-2", Role: coretypes.RoleAssistant, Content: "hel"},
+	}
+
+	cases := map[string][]Message{
+		"Edited": {
+			old[0],
+			{ID: "msg-2", Role: coretypes.RoleAssistant, Content: "hello"},
+		},
+		"Removed": old[:1],
+		"MultimodalAppend": append(append([]Message{}, old...), Message{
+			ID:      "msg-3",
+			Role:    coretypes.RoleUser,
+			Content: []coretypes.InputContent{{Type: coretypes.InputContentTypeText, Text: "look"}},
+		}),
+		"AssistantPartsAppend": append(append([]Message{}, old...), Message{
+			ID:      "msg-3",
+			Role:    coretypes.RoleAssistant,
+			Content: []coretypes.AssistantContent{{Type: coretypes.AssistantContentTypeText, Text: "cited"}},
+		}),
+		"ToolErrorAppend": append(append([]Message{}, old...), Message{
+			ID: "msg-3", Role: coretypes.RoleTool, Content: "", ToolCallID: "call-1", Error: "boom",
+		}),
+	}
+
+	for name, updated := range cases {
+		t.Run(name, func(t *testing.T) {
+			diff := DiffMessages(old, updated)
+			require.Len(t, diff, 1)
+			snapshot, ok := diff[0].(*MessagesSnapshotEvent)
+			require.True(t, ok)
+			assert.Equal(t, updated, snapshot.Messages)
+		})
+	}
+}
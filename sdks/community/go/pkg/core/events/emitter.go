@@ -0,0 +1,82 @@
+package events
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock supplies event timestamps in Unix milliseconds. Tests can inject a
+// fake clock, such as testutil.FakeClock, to make timestamps deterministic.
+type Clock interface {
+	Now() int64
+}
+
+// systemClock reads the wall clock
+type systemClock struct{}
+
+// Now returns the current wall clock time in Unix milliseconds
+func (systemClock) Now() int64 {
+	return time.Now().UnixMilli()
+}
+
+// EventEmitter stamps events with millisecond timestamps that never decrease.
+// When the wall clock moves backward the previous timestamp is reused, so
+// consumers that sort or diff events by timestamp see a stable order.
+// It is safe for concurrent use.
+type EventEmitter struct {
+	mu    sync.Mutex
+	last  int64
+	clock Clock
+}
+
+// EventEmitterOption defines options for creating an EventEmitter
+type EventEmitterOption func(*EventEmitter)
+
+// WithClock makes the emitter read timestamps from clock instead of the
+// system clock
+func WithClock(clock Clock) EventEmitterOption {
+	return func(e *EventEmitter) {
+		e.clock = clock
+	}
+}
+
+// NewEventEmitter creates an emitter backed by the system clock unless
+// another clock is given with WithClock
+func NewEventEmitter(options ...EventEmitterOption) *EventEmitter {
+	e := &EventEmitter{clock: systemClock{}}
+	for _, opt := range options {
+		opt(e)
+	}
+	return e
+}
+
+// Emit sets the event timestamp to the current time, clamped so that it is
+// never earlier than the last emitted timestamp, and returns the event
+func (e *EventEmitter) Emit(event Event) Event {
+	if event == nil {
+		return nil
+	}
+	event.SetTimestamp(e.next())
+	return event
+}
+
+// Last returns the most recently emitted timestamp, or zero if nothing has
+// been emitted
+func (e *EventEmitter) Last() int64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.last
+}
+
+// next returns the next monotonic timestamp
+func (e *EventEmitter) next() int64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	timestamp := e.clock.Now()
+	if timestamp < e.last {
+		timestamp = e.last
+	}
+	e.last = timestamp
+	return timestamp
+}
@@ -0,0 +1,78 @@
+package events_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventEmitter_ClampsBackwardClock(t *testing.T) {
+	base := time.UnixMilli(1700000000000)
+	readings := []time.Time{
+		base,
+		base.Add(5 * time.Millisecond),
+		base.Add(-time.Second), // clock jumped backward
+		base.Add(3 * time.Millisecond),
+		base.Add(10 * time.Millisecond),
+	}
+	clock := testutil.NewFakeClock(base)
+	emitter := events.NewEventEmitter(events.WithClock(clock))
+
+	var stamps []int64
+	for _, reading := range readings {
+		clock.Set(reading)
+		event := emitter.Emit(events.NewTextMessageContentEvent("msg-1", "x"))
+		require.NotNil(t, event.Timestamp())
+		stamps = append(stamps, *event.Timestamp())
+	}
+
+	assert.Equal(t, []int64{
+		1700000000000,
+		1700000000005,
+		1700000000005,
+		1700000000005,
+		1700000000010,
+	}, stamps)
+	assert.Equal(t, int64(1700000000010), emitter.Last())
+	assert.Nil(t, emitter.Emit(nil))
+}
+
+func TestEventEmitter_FakeClock(t *testing.T) {
+	clock := testutil.NewFakeClock(time.UnixMilli(1700000000000))
+	emitter := events.NewEventEmitter(events.WithClock(clock))
+
+	first := emitter.Emit(events.NewRunStartedEvent("thread-1", "run-1"))
+	second := emitter.Emit(events.NewStepStartedEvent("plan"))
+	clock.Advance(250 * time.Millisecond)
+	third := emitter.Emit(events.NewStepFinishedEvent("plan"))
+
+	assert.Equal(t, int64(1700000000000), *first.Timestamp())
+	assert.Equal(t, int64(1700000000000), *second.Timestamp())
+	assert.Equal(t, int64(1700000000250), *third.Timestamp())
+}
+
+func TestEventEmitter_Concurrent(t *testing.T) {
+	clock := testutil.NewFakeClock(time.UnixMilli(1700000000000))
+	emitter := events.NewEventEmitter(events.WithClock(clock))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			previous := int64(0)
+			for j := 0; j < 100; j++ {
+				clock.Advance(time.Millisecond)
+				stamp := *emitter.Emit(events.NewRunStartedEvent("thread-1", "run-1")).Timestamp()
+				assert.GreaterOrEqual(t, stamp, previous)
+				previous = stamp
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,79 @@
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// equalConfig holds the settings of EventsEqual
+type equalConfig struct {
+	ignoreTimestamp bool
+	ignoreID        bool
+}
+
+// EqualOption defines options for comparing events
+type EqualOption func(*equalConfig)
+
+// IgnoreTimestamp makes EventsEqual ignore the timestamps of the events
+func IgnoreTimestamp() EqualOption {
+	return func(c *equalConfig) {
+		c.ignoreTimestamp = true
+	}
+}
+
+// IgnoreID makes EventsEqual ignore the EventIDs assigned by the transport
+func IgnoreID() EqualOption {
+	return func(c *equalConfig) {
+		c.ignoreID = true
+	}
+}
+
+// EventsEqual reports whether two events are semantically equal: they have
+// the same EventID and their JSON forms are identical once canonicalized
+// (see CanonicalJSON), so key order and number formatting do not matter.
+// Events that cannot be encoded are never equal, and two nil events are.
+func EventsEqual(a, b Event, options ...EqualOption) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	config := &equalConfig{}
+	for _, opt := range options {
+		opt(config)
+	}
+
+	if !config.ignoreID && a.GetBaseEvent().EventID != b.GetBaseEvent().EventID {
+		return false
+	}
+
+	left, err := comparableJSON(a, config)
+	if err != nil {
+		return false
+	}
+	right, err := comparableJSON(b, config)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(left, right)
+}
+
+// comparableJSON returns the canonical JSON of an event without the fields
+// the comparison ignores
+func comparableJSON(event Event, config *equalConfig) ([]byte, error) {
+	data, err := event.ToJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	if config.ignoreTimestamp {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return nil, err
+		}
+		delete(fields, "timestamp")
+		if data, err = json.Marshal(fields); err != nil {
+			return nil, err
+		}
+	}
+	return CanonicalJSON(data)
+}
@@ -0,0 +1,32 @@
+package events
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventsEqual(t *testing.T) {
+	a := NewStateSnapshotEvent(map[string]any{"count": 1, "items": []any{"x"}})
+	b := NewStateSnapshotEvent(json.RawMessage(`{"items": ["x"], "count": 1.0}`))
+	a.SetTimestamp(1000)
+	b.SetTimestamp(2000)
+
+	assert.False(t, EventsEqual(a, b))
+	assert.True(t, EventsEqual(a, b, IgnoreTimestamp()))
+
+	b.SetTimestamp(1000)
+	assert.True(t, EventsEqual(a, b), "key order and number format do not matter")
+
+	b.EventID = "42"
+	assert.False(t, EventsEqual(a, b))
+	assert.True(t, EventsEqual(a, b, IgnoreID()))
+
+	b.Snapshot = map[string]any{"count": 2, "items": []any{"x"}}
+	assert.False(t, EventsEqual(a, b, IgnoreTimestamp(), IgnoreID()))
+
+	assert.False(t, EventsEqual(NewStepStartedEvent("plan"), NewStepFinishedEvent("plan"), IgnoreTimestamp()))
+	assert.False(t, EventsEqual(a, nil))
+	assert.True(t, EventsEqual(nil, nil))
+}
@@ -0,0 +1,177 @@
+package events
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/schema"
+)
+
+// eventSchemaFiles holds one JSON Schema per event type, named after the
+// wire type, describing the event's JSON form
+//
+//go:embed schemas/*.json
+var eventSchemaFiles embed.FS
+
+// eventSchemas holds the compiled schemas, loaded on first use
+var eventSchemas struct {
+	once   sync.Once
+	err    error
+	loose  map[EventType]*schema.Schema
+	strict map[EventType]*schema.Schema
+}
+
+// SchemaOption defines options for event schema validation
+type SchemaOption func(*schemaConfig)
+
+// schemaConfig holds the settings applied by SchemaOption values
+type schemaConfig struct {
+	strict bool
+}
+
+// StrictSchema additionally rejects object fields that the schema does not
+// declare, which catches servers sending fields the SDK does not know about
+func StrictSchema() SchemaOption {
+	return func(c *schemaConfig) {
+		c.strict = true
+	}
+}
+
+// ValidateEventSchema checks the JSON form of event against the embedded
+// AG-UI JSON Schema for its type: required fields, field types and allowed
+// values. Violations are returned as a wrapped *schema.ValidationError.
+//
+// Decoding drops fields the Go types do not declare, so use
+// ValidateEventJSON on the raw payload to detect unexpected fields with
+// StrictSchema.
+func ValidateEventSchema(event Event, options ...SchemaOption) error {
+	if event == nil {
+		return fmt.Errorf("event cannot be nil")
+	}
+
+	data, err := event.ToJSON()
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+	return ValidateEventJSON(data, options...)
+}
+
+// ValidateEventJSON checks a raw event payload, as received on the wire,
+// against the embedded JSON Schema for the type named in its type field
+func ValidateEventJSON(data []byte, options ...SchemaOption) error {
+	cfg := &schemaConfig{}
+	for _, opt := range options {
+		opt(cfg)
+	}
+
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to parse event: %w", err)
+	}
+	obj, ok := doc.(map[string]any)
+	if !ok {
+		return fmt.Errorf("event must be a JSON object")
+	}
+	name, _ := obj["type"].(string)
+	eventType, err := ParseEventType(name)
+	if err != nil {
+		return err
+	}
+
+	compiled, err := eventSchema(eventType, cfg.strict)
+	if err != nil {
+		return err
+	}
+	if err := compiled.Validate(doc); err != nil {
+		return fmt.Errorf("%s event does not match schema: %w", eventType, err)
+	}
+	return nil
+}
+
+// eventSchema returns the compiled schema for an event type
+func eventSchema(eventType EventType, strict bool) (*schema.Schema, error) {
+	eventSchemas.once.Do(loadEventSchemas)
+	if eventSchemas.err != nil {
+		return nil, eventSchemas.err
+	}
+
+	schemas := eventSchemas.loose
+	if strict {
+		schemas = eventSchemas.strict
+	}
+	compiled, ok := schemas[eventType]
+	if !ok {
+		return nil, fmt.Errorf("no schema for event type %s", eventType)
+	}
+	return compiled, nil
+}
+
+// loadEventSchemas compiles every embedded schema in its loose and strict form
+func loadEventSchemas() {
+	eventSchemas.loose = make(map[EventType]*schema.Schema)
+	eventSchemas.strict = make(map[EventType]*schema.Schema)
+
+	entries, err := eventSchemaFiles.ReadDir("schemas")
+	if err != nil {
+		eventSchemas.err = fmt.Errorf("failed to read event schemas: %w", err)
+		return
+	}
+
+	for _, entry := range entries {
+		eventType := EventType(strings.TrimSuffix(entry.Name(), ".json"))
+		data, err := eventSchemaFiles.ReadFile("schemas/" + entry.Name())
+		if err != nil {
+			eventSchemas.err = fmt.Errorf("failed to read schema for %s: %w", eventType, err)
+			return
+		}
+
+		loose, err := schema.Compile(data)
+		if err != nil {
+			eventSchemas.err = fmt.Errorf("failed to compile schema for %s: %w", eventType, err)
+			return
+		}
+
+		var doc any
+		if err := json.Unmarshal(data, &doc); err != nil {
+			eventSchemas.err = fmt.Errorf("failed to parse schema for %s: %w", eventType, err)
+			return
+		}
+		closeObjects(doc)
+		strictData, err := json.Marshal(doc)
+		if err != nil {
+			eventSchemas.err = fmt.Errorf("failed to encode strict schema for %s: %w", eventType, err)
+			return
+		}
+		strict, err := schema.Compile(strictData)
+		if err != nil {
+			eventSchemas.err = fmt.Errorf("failed to compile strict schema for %s: %w", eventType, err)
+			return
+		}
+
+		eventSchemas.loose[eventType] = loose
+		eventSchemas.strict[eventType] = strict
+	}
+}
+
+// closeObjects sets additionalProperties to false on every schema in node
+// that declares properties, unless it already constrains additional ones
+func closeObjects(node any) {
+	switch n := node.(type) {
+	case map[string]any:
+		if _, ok := n["properties"]; ok {
+			if _, set := n["additionalProperties"]; !set {
+				n["additionalProperties"] = false
+			}
+		}
+		for _, child := range n {
+			closeObjects(child)
+		}
+	case []any:
+		for _, child := range n {
+			closeObjects(child)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package events
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/schema"
+	coretypes "github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventSchemasCoverAllTypes(t *testing.T) {
+	for eventType := range validEventTypes {
+		_, err := eventSchema(eventType, false)
+		assert.NoError(t, err, "missing schema for %s", eventType)
+	}
+}
+
+func TestValidateEventSchema(t *testing.T) {
+	valid := []Event{
+		NewRunStartedEvent("thread-1", "run-1"),
+		NewRunErrorEvent("boom", WithErrorCode("E1")),
+		NewTextMessageStartEvent("msg-1", WithRole("assistant")),
+		NewTextMessageContentEvent("msg-1", "Hello"),
+		NewTextReasoningContentEvent("msg-1", "Thinking"),
+		NewToolCallStartEvent("call-1", "search", WithParentMessageID("msg-1")),
+		NewToolCallResultEvent("msg-2", "call-1", "done"),
+		NewStateDeltaEvent([]JSONPatchOperation{{Op: "add", Path: "/a", Value: 1}}),
+		NewMessagesSnapshotEvent([]Message{
+			{ID: "msg-1", Role: coretypes.RoleAssistant, ToolCalls: []ToolCall{{
+				ID: "call-1", Type: "function", Function: Function{Name: "search", Arguments: "{}"},
+			}}},
+		}),
+		NewActivitySnapshotEvent("act-1", "PLAN", map[string]any{"steps": []any{}}),
+		NewReasoningEncryptedValueEvent(ReasoningEncryptedValueSubtypeToolCall, "call-1", "blob"),
+		NewCustomEvent("ping"),
+	}
+	for _, event := range valid {
+		t.Run(string(event.Type()), func(t *testing.T) {
+			assert.NoError(t, ValidateEventSchema(event))
+			assert.NoError(t, ValidateEventSchema(event, StrictSchema()))
+		})
+	}
+
+	t.Run("MissingRequiredField", func(t *testing.T) {
+		err := ValidateEventSchema(NewToolCallStartEvent("call-1", ""))
+		var validationErr *schema.ValidationError
+		require.True(t, errors.As(err, &validationErr))
+		assert.Contains(t, err.Error(), "TOOL_CALL_START")
+		assert.Contains(t, err.Error(), "/toolCallName")
+	})
+
+	t.Run("InvalidNestedValue", func(t *testing.T) {
+		err := ValidateEventSchema(NewStateDeltaEvent([]JSONPatchOperation{{Op: "merge", Path: "/a"}}))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "/delta/0/op")
+	})
+
+	t.Run("NilEvent", func(t *testing.T) {
+		assert.Error(t, ValidateEventSchema(nil))
+	})
+}
+
+func TestValidateEventJSON(t *testing.T) {
+	extra := []byte(`{"type":"STEP_STARTED","stepName":"plan","stepIndex":2}`)
+	assert.NoError(t, ValidateEventJSON(extra))
+
+	err := ValidateEventJSON(extra, StrictSchema())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "stepIndex")
+
+	nested := []byte(`{"type":"MESSAGES_SNAPSHOT","messages":[{"id":"m","role":"user","content":"hi","mood":"happy"}]}`)
+	assert.NoError(t, ValidateEventJSON(nested))
+	err = ValidateEventJSON(nested, StrictSchema())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "/messages/0")
+
+	err = ValidateEventJSON([]byte(`{"type":"RUN_STARTED","threadId":"t","runId":"r","timestamp":"yesterday"}`))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "/timestamp")
+
+	assert.True(t, errors.Is(ValidateEventJSON([]byte(`{"type":"NOT_AN_EVENT"}`)), ErrUnknownEventType))
+	assert.Error(t, ValidateEventJSON([]byte(`[]`)))
+	assert.Error(t, ValidateEventJSON([]byte(`{`)))
+}
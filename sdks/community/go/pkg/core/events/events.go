@@ -2,7 +2,9 @@ package events
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"reflect"
 	"time"
 )
 
@@ -15,6 +17,7 @@ const (
 	EventTypeTextMessageContent EventType = "TEXT_MESSAGE_CONTENT"
 	EventTypeTextMessageEnd     EventType = "TEXT_MESSAGE_END"
 	EventTypeTextMessageChunk   EventType = "TEXT_MESSAGE_CHUNK"
+	EventTypeMessageUpdate      EventType = "MESSAGE_UPDATE"
 	EventTypeToolCallStart      EventType = "TOOL_CALL_START"
 	EventTypeToolCallArgs       EventType = "TOOL_CALL_ARGS"
 	EventTypeToolCallEnd        EventType = "TOOL_CALL_END"
@@ -33,6 +36,13 @@ const (
 	EventTypeStepStarted        EventType = "STEP_STARTED"
 	EventTypeStepFinished       EventType = "STEP_FINISHED"
 
+	// EventTypeToolCallResultChunk carries part of a binary tool result
+	EventTypeToolCallResultChunk EventType = "TOOL_CALL_RESULT_CHUNK"
+
+	// EventTypeTextReasoningContent streams hidden reasoning for a text
+	// message, kept apart from its visible content
+	EventTypeTextReasoningContent EventType = "TEXT_REASONING_CONTENT"
+
 	// Thinking events are kept for backward compatibility.
 	// Deprecated: Use the REASONING_* event types instead.
 	// EventTypeThinkingStart indicates the start of a thinking phase.
@@ -53,19 +63,19 @@ const (
 
 	// Reasoning events support the reasoning message lifecycle.
 	// EventTypeReasoningStart marks the start of a reasoning phase.
-	EventTypeReasoningStart              EventType = "REASONING_START"
+	EventTypeReasoningStart EventType = "REASONING_START"
 	// EventTypeReasoningMessageStart signals the start of a reasoning message.
-	EventTypeReasoningMessageStart       EventType = "REASONING_MESSAGE_START"
+	EventTypeReasoningMessageStart EventType = "REASONING_MESSAGE_START"
 	// EventTypeReasoningMessageContent represents a chunk of reasoning message content.
-	EventTypeReasoningMessageContent     EventType = "REASONING_MESSAGE_CONTENT"
+	EventTypeReasoningMessageContent EventType = "REASONING_MESSAGE_CONTENT"
 	// EventTypeReasoningMessageEnd signals the end of a reasoning message.
-	EventTypeReasoningMessageEnd         EventType = "REASONING_MESSAGE_END"
+	EventTypeReasoningMessageEnd EventType = "REASONING_MESSAGE_END"
 	// EventTypeReasoningMessageChunk is a convenience event that streams reasoning message chunks.
-	EventTypeReasoningMessageChunk       EventType = "REASONING_MESSAGE_CHUNK"
+	EventTypeReasoningMessageChunk EventType = "REASONING_MESSAGE_CHUNK"
 	// EventTypeReasoningEnd marks the end of a reasoning phase.
-	EventTypeReasoningEnd                EventType = "REASONING_END"
+	EventTypeReasoningEnd EventType = "REASONING_END"
 	// EventTypeReasoningEncryptedValue attaches an encrypted reasoning value.
-	EventTypeReasoningEncryptedValue     EventType = "REASONING_ENCRYPTED_VALUE"
+	EventTypeReasoningEncryptedValue EventType = "REASONING_ENCRYPTED_VALUE"
 
 	// EventTypeUnknown represents an unrecognized event type
 	EventTypeUnknown EventType = "UNKNOWN"
@@ -77,11 +87,14 @@ var validEventTypes = map[EventType]bool{
 	EventTypeTextMessageContent:         true,
 	EventTypeTextMessageEnd:             true,
 	EventTypeTextMessageChunk:           true,
+	EventTypeMessageUpdate:              true,
+	EventTypeTextReasoningContent:       true,
 	EventTypeToolCallStart:              true,
 	EventTypeToolCallArgs:               true,
 	EventTypeToolCallEnd:                true,
 	EventTypeToolCallChunk:              true,
 	EventTypeToolCallResult:             true,
+	EventTypeToolCallResultChunk:        true,
 	EventTypeStateSnapshot:              true,
 	EventTypeStateDelta:                 true,
 	EventTypeMessagesSnapshot:           true,
@@ -108,6 +121,28 @@ var validEventTypes = map[EventType]bool{
 	EventTypeReasoningEncryptedValue:    true,
 }
 
+// ErrUnknownEventType is returned when an event type is not part of the AG-UI protocol.
+var ErrUnknownEventType = errors.New("unknown event type")
+
+// IsValid reports whether the event type is a known AG-UI protocol event type.
+func (t EventType) IsValid() bool {
+	return validEventTypes[t]
+}
+
+// String returns the wire representation of the event type.
+func (t EventType) String() string {
+	return string(t)
+}
+
+// ParseEventType converts a wire string into an EventType, rejecting unknown values.
+func ParseEventType(s string) (EventType, error) {
+	eventType := EventType(s)
+	if !eventType.IsValid() {
+		return EventTypeUnknown, fmt.Errorf("%w: %s", ErrUnknownEventType, s)
+	}
+	return eventType, nil
+}
+
 // Event defines the common interface for all AG-UI events
 type Event interface {
 	// Type returns the event type
@@ -125,6 +160,9 @@ type Event interface {
 	// RunID returns the run ID associated with this event
 	RunID() string
 
+	// RawJSON returns the raw source event as JSON, or nil when absent
+	RawJSON() json.RawMessage
+
 	// Validate validates the event structure and content
 	Validate() error
 
@@ -140,6 +178,11 @@ type BaseEvent struct {
 	EventType   EventType `json:"type"`
 	TimestampMs *int64    `json:"timestamp,omitempty"`
 	RawEvent    any       `json:"rawEvent,omitempty"`
+
+	// EventID is the stream-unique identifier carried by transports that
+	// support resumption, such as the SSE id field. It is not part of the
+	// event payload.
+	EventID string `json:"-"`
 }
 
 // Type returns the event type
@@ -157,8 +200,30 @@ func (b *BaseEvent) SetTimestamp(timestamp int64) {
 	b.TimestampMs = &timestamp
 }
 
+// RawJSON returns the raw source event as JSON, or nil when absent or not serializable
+func (b *BaseEvent) RawJSON() json.RawMessage {
+	switch raw := b.RawEvent.(type) {
+	case nil:
+		return nil
+	case json.RawMessage:
+		return raw
+	case []byte:
+		return json.RawMessage(raw)
+	default:
+		data, err := json.Marshal(raw)
+		if err != nil {
+			return nil
+		}
+		return data
+	}
+}
+
 // ID returns the unique identifier for this event
 func (b *BaseEvent) ID() string {
+	if b.EventID != "" {
+		return b.EventID
+	}
+
 	// Generate a unique ID based on event type and timestamp
 	if b.TimestampMs != nil {
 		return fmt.Sprintf("%s_%d", b.EventType, *b.TimestampMs)
@@ -220,9 +285,49 @@ func (b *BaseEvent) Validate() error {
 	return nil
 }
 
-// isValidEventType checks if the given event type is valid
+// isValidEventType checks if the given event type is a protocol type or one
+// registered with a Registry
 func isValidEventType(eventType EventType) bool {
-	return validEventTypes[eventType]
+	return eventType.IsValid() || isRegisteredEventType(eventType)
+}
+
+// ValidateEvent validates a single event, giving producers and consumers one
+// entry point for checking what they send or receive. Each event type checks
+// its own fields in Validate; ValidateEvent also rejects a nil event, an
+// event without its BaseEvent, and an event of this package whose type does
+// not match its Go type, such as a TextMessageStartEvent with the type
+// TOOL_CALL_START.
+func ValidateEvent(event Event) error {
+	if event == nil {
+		return fmt.Errorf("event cannot be nil")
+	}
+	if value := reflect.ValueOf(event); value.Kind() == reflect.Ptr && value.IsNil() {
+		return fmt.Errorf("event cannot be nil")
+	}
+	if based, ok := event.(interface{ GetBaseEvent() *BaseEvent }); ok && based.GetBaseEvent() == nil {
+		return fmt.Errorf("%T validation failed: base event is required", event)
+	}
+
+	if err := event.Validate(); err != nil {
+		return err
+	}
+
+	// Other packages may implement Event with types of their own, and
+	// registered types decode to whatever their factory returns
+	eventType := reflect.TypeOf(event)
+	if _, unknown := event.(*UnknownEvent); unknown || eventType.Kind() != reflect.Ptr ||
+		eventType.Elem().PkgPath() != reflect.TypeOf(BaseEvent{}).PkgPath() ||
+		isRegisteredEventType(event.Type()) {
+		return nil
+	}
+	expected, err := newEventOfType(event.Type())
+	if err != nil {
+		return err
+	}
+	if eventType != reflect.TypeOf(expected) {
+		return fmt.Errorf("%T validation failed: type %s belongs to %T", event, event.Type(), expected)
+	}
+	return nil
 }
 
 // ValidateSequence validates a sequence of events according to AG-UI protocol rules
@@ -240,7 +345,7 @@ func ValidateSequence(events []Event) error {
 	finishedRuns := make(map[string]bool)
 
 	for i, event := range events {
-		if err := event.Validate(); err != nil {
+		if err := ValidateEvent(event); err != nil {
 			return fmt.Errorf("event %d validation failed: %w", i, err)
 		}
 
@@ -309,6 +414,13 @@ func ValidateSequence(events []Event) error {
 				// Content events are valid between start and end
 			}
 
+		case EventTypeTextReasoningContent:
+			if msgEvent, ok := event.(*TextReasoningContentEvent); ok {
+				if !activeMessages[msgEvent.MessageID] {
+					return fmt.Errorf("cannot add reasoning to message %s that was not started", msgEvent.MessageID)
+				}
+			}
+
 		case EventTypeTextMessageEnd:
 			if msgEvent, ok := event.(*TextMessageEndEvent); ok {
 				if !activeMessages[msgEvent.MessageID] {
@@ -320,6 +432,11 @@ func ValidateSequence(events []Event) error {
 		case EventTypeTextMessageChunk:
 			// Chunk events are always valid in sequence context.
 
+		case EventTypeMessageUpdate:
+			// Updates may revise any earlier message, including ones from a
+			// previous run, so whether the message exists is checked where
+			// the update is applied.
+
 		case EventTypeToolCallStart:
 			if toolEvent, ok := event.(*ToolCallStartEvent); ok {
 				if activeToolCalls[toolEvent.ToolCallID] {
@@ -350,6 +467,9 @@ func ValidateSequence(events []Event) error {
 		case EventTypeToolCallResult:
 			// Tool call result events are always valid in sequence context.
 
+		case EventTypeToolCallResultChunk:
+			// Chunk order and integrity are checked by BinaryResultAssembler.
+
 		case EventTypeThinkingStart, EventTypeThinkingEnd, EventTypeThinkingTextMessageStart, EventTypeThinkingTextMessageContent, EventTypeThinkingTextMessageEnd:
 			// Thinking events are always valid in sequence context.
 
@@ -422,28 +542,75 @@ func ValidateSequence(events []Event) error {
 			// Additional validation could be added via custom validators
 
 		default:
-			// This should not happen due to prior validation, but add safety check
-			return fmt.Errorf("unknown event type in sequence: %s", event.Type())
+			// Application-defined events carry no lifecycle rules
+			if !isRegisteredEventType(event.Type()) {
+				return fmt.Errorf("unknown event type in sequence: %s", event.Type())
+			}
 		}
 	}
 
 	return nil
 }
 
-// EventFromJSON parses an event from JSON data
+// EventFromJSON parses an event from JSON data. Fields the event type does
+// not define are ignored, so newer servers can add fields without breaking
+// older clients.
 func EventFromJSON(data []byte) (Event, error) {
+	return decodeEvent(data, false)
+}
+
+// EventFromJSONStrict parses an event like EventFromJSON but fails when the
+// event, or one of the messages of a MESSAGES_SNAPSHOT, has a field its type
+// does not define. The error wraps an *UnknownFieldError naming the field.
+// Free-form values such as state, message content and custom event values
+// are not checked, and message fields must use their camelCase names. As
+// with encoding/json generally, names that differ from a known field only in
+// case are accepted.
+func EventFromJSONStrict(data []byte) (Event, error) {
+	return decodeEvent(data, true)
+}
+
+// decodeEvent parses an event, rejecting unknown fields when strict is set
+func decodeEvent(data []byte, strict bool) (Event, error) {
 	// First, parse the base event to determine the type
 	var base struct {
-		Type EventType `json:"type"`
+		Type string `json:"type"`
 	}
 
 	if err := json.Unmarshal(data, &base); err != nil {
 		return nil, fmt.Errorf("failed to parse event type: %w", err)
 	}
 
-	// Create the appropriate event type based on the type field
+	eventType, err := ParseEventType(base.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := newEventOfType(eventType)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal into the specific event type
+	if strict {
+		err = decodeStrict(data, event, "")
+		if err == nil {
+			err = checkSnapshotMessageFields(data, event)
+		}
+	} else {
+		err = json.Unmarshal(data, event)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal event: %w", err)
+	}
+
+	return event, nil
+}
+
+// newEventOfType returns an empty event of the given type to decode into
+func newEventOfType(eventType EventType) (Event, error) {
 	var event Event
-	switch base.Type {
+	switch eventType {
 	case EventTypeRunStarted:
 		event = &RunStartedEvent{}
 	case EventTypeRunFinished:
@@ -460,6 +627,10 @@ func EventFromJSON(data []byte) (Event, error) {
 		event = &TextMessageContentEvent{}
 	case EventTypeTextMessageChunk:
 		event = &TextMessageChunkEvent{}
+	case EventTypeMessageUpdate:
+		event = &MessageUpdateEvent{}
+	case EventTypeTextReasoningContent:
+		event = &TextReasoningContentEvent{}
 	case EventTypeTextMessageEnd:
 		event = &TextMessageEndEvent{}
 	case EventTypeToolCallStart:
@@ -468,8 +639,12 @@ func EventFromJSON(data []byte) (Event, error) {
 		event = &ToolCallArgsEvent{}
 	case EventTypeToolCallEnd:
 		event = &ToolCallEndEvent{}
+	case EventTypeToolCallChunk:
+		event = &ToolCallChunkEvent{}
 	case EventTypeToolCallResult:
 		event = &ToolCallResultEvent{}
+	case EventTypeToolCallResultChunk:
+		event = &ToolCallResultChunkEvent{}
 	case EventTypeStateSnapshot:
 		event = &StateSnapshotEvent{}
 	case EventTypeStateDelta:
@@ -484,6 +659,16 @@ func EventFromJSON(data []byte) (Event, error) {
 		event = &RawEvent{}
 	case EventTypeCustom:
 		event = &CustomEvent{}
+	case EventTypeThinkingStart:
+		event = &ThinkingStartEvent{}
+	case EventTypeThinkingEnd:
+		event = &ThinkingEndEvent{}
+	case EventTypeThinkingTextMessageStart:
+		event = &ThinkingTextMessageStartEvent{}
+	case EventTypeThinkingTextMessageContent:
+		event = &ThinkingTextMessageContentEvent{}
+	case EventTypeThinkingTextMessageEnd:
+		event = &ThinkingTextMessageEndEvent{}
 	case EventTypeReasoningStart:
 		event = &ReasoningStartEvent{}
 	case EventTypeReasoningMessageStart:
@@ -499,13 +684,7 @@ func EventFromJSON(data []byte) (Event, error) {
 	case EventTypeReasoningEncryptedValue:
 		event = &ReasoningEncryptedValueEvent{}
 	default:
-		return nil, fmt.Errorf("unknown event type: %s", base.Type)
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEventType, eventType)
 	}
-
-	// Unmarshal into the specific event type
-	if err := json.Unmarshal(data, event); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal event: %w", err)
-	}
-
 	return event, nil
 }
@@ -43,6 +43,36 @@ func TestBaseEvent(t *testing.T) {
 	})
 }
 
+func TestEventTypeParsing(t *testing.T) {
+	t.Run("IsValid", func(t *testing.T) {
+		for eventType := range validEventTypes {
+			assert.True(t, eventType.IsValid(), "expected %s to be valid", eventType)
+		}
+		assert.False(t, EventTypeUnknown.IsValid())
+		assert.False(t, EventType("TEXT_MESSAGE_STRAT").IsValid())
+		assert.False(t, EventType("").IsValid())
+	})
+
+	t.Run("ParseEventType", func(t *testing.T) {
+		eventType, err := ParseEventType("TEXT_MESSAGE_START")
+		require.NoError(t, err)
+		assert.Equal(t, EventTypeTextMessageStart, eventType)
+
+		eventType, err = ParseEventType("TEXT_MESSAGE_STRAT")
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrUnknownEventType)
+		assert.Contains(t, err.Error(), "TEXT_MESSAGE_STRAT")
+		assert.Equal(t, EventTypeUnknown, eventType)
+	})
+
+	t.Run("EventFromJSONCoversAllTypes", func(t *testing.T) {
+		for eventType := range validEventTypes {
+			_, err := EventFromJSON([]byte(`{"type":"` + eventType.String() + `"}`))
+			assert.NotErrorIs(t, err, ErrUnknownEventType, "EventFromJSON does not handle %s", eventType)
+		}
+	})
+}
+
 func TestRunEvents(t *testing.T) {
 	t.Run("RunStartedEvent", func(t *testing.T) {
 		threadID := "thread-123"
@@ -307,6 +337,37 @@ func TestMessageEvents(t *testing.T) {
 		event.MessageID = ""
 		assert.Error(t, event.Validate())
 	})
+
+	t.Run("MessageUpdateEvent", func(t *testing.T) {
+		event := NewMessageUpdateEvent("msg-123", "Corrected")
+
+		assert.Equal(t, EventTypeMessageUpdate, event.Type())
+		assert.Equal(t, "msg-123", event.MessageID)
+		assert.Equal(t, "Corrected", event.Content)
+		assert.NoError(t, event.Validate())
+
+		decoded, err := EventFromJSON([]byte(`{"type":"MESSAGE_UPDATE","messageId":"msg-123","content":""}`))
+		require.NoError(t, err)
+		assert.NoError(t, decoded.Validate())
+
+		event.MessageID = ""
+		assert.Error(t, event.Validate())
+	})
+
+	t.Run("ApplyMessageUpdate", func(t *testing.T) {
+		messages := []Message{
+			{ID: "msg-1", Role: "user", Content: "hi"},
+			{ID: "msg-2", Role: "assistant", Content: "Helo"},
+		}
+
+		updated, err := ApplyMessageUpdate(messages, NewMessageUpdateEvent("msg-2", "Hello"))
+		require.NoError(t, err)
+		assert.Equal(t, "Hello", updated[1].Content)
+		assert.Equal(t, "Helo", messages[1].Content)
+
+		_, err = ApplyMessageUpdate(messages, NewMessageUpdateEvent("msg-3", "x"))
+		assert.ErrorIs(t, err, ErrMessageNotFound)
+	})
 }
 
 func TestToolEvents(t *testing.T) {
@@ -365,6 +426,72 @@ func TestToolEvents(t *testing.T) {
 		event.ToolCallID = ""
 		assert.Error(t, event.Validate())
 	})
+
+	t.Run("Index", func(t *testing.T) {
+		start := NewToolCallStartEvent("", "get_weather", WithToolCallIndex(0))
+		require.NotNil(t, start.Index)
+		assert.Equal(t, 0, *start.Index)
+		assert.NoError(t, start.Validate())
+		data, err := start.ToJSON()
+		require.NoError(t, err)
+		var fields map[string]any
+		require.NoError(t, json.Unmarshal(data, &fields))
+		assert.NotContains(t, fields, "toolCallId")
+		assert.Equal(t, float64(0), fields["index"])
+		assert.NoError(t, ValidateEventSchema(start))
+
+		args := NewToolCallArgsEventWithOptions("", "{}", WithToolCallArgsIndex(0))
+		assert.NoError(t, args.Validate())
+		assert.NoError(t, ValidateEventSchema(args, StrictSchema()))
+		args.Index = nil
+		assert.Error(t, args.Validate())
+
+		end := NewToolCallEndEventWithOptions("tool-123", WithToolCallEndIndex(0))
+		assert.NoError(t, end.Validate())
+		assert.NoError(t, ValidateEventSchema(end))
+		end.ToolCallID = ""
+		assert.Error(t, end.Validate(), "the final event must carry the ID")
+
+		start = NewToolCallStartEvent("", "get_weather", WithToolCallIndex(-1))
+		err = start.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "index must not be negative")
+		assert.Error(t, ValidateEventSchema(start))
+	})
+
+	t.Run("ToolCallResultChunkEvent", func(t *testing.T) {
+		chunks := NewToolCallResultChunks("msg-1", "tool-123", "image/png", []byte("abcdefg"), 3)
+		require.Len(t, chunks, 3)
+		for i, chunk := range chunks {
+			assert.Equal(t, EventTypeToolCallResultChunk, chunk.Type())
+			assert.Equal(t, i, chunk.Sequence)
+			assert.Equal(t, i == 2, chunk.Final)
+			assert.NoError(t, chunk.Validate())
+			assert.NoError(t, ValidateEventSchema(chunk, StrictSchema()))
+		}
+		assert.Equal(t, "image/png", chunks[0].MimeType)
+		assert.Equal(t, []byte("g"), chunks[2].Data)
+		assert.Equal(t, ResultChecksum([]byte("abcdefg")), chunks[2].Checksum)
+
+		data, err := chunks[0].ToJSON()
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"data":"YWJj"`)
+		decoded, err := EventFromJSON(data)
+		require.NoError(t, err)
+		assert.Equal(t, chunks[0].Data, decoded.(*ToolCallResultChunkEvent).Data)
+
+		empty := NewToolCallResultChunks("msg-1", "tool-123", "", nil, 0)
+		require.Len(t, empty, 1)
+		assert.True(t, empty[0].Final)
+		assert.NoError(t, empty[0].Validate())
+
+		chunks[2].Checksum = ""
+		assert.Error(t, chunks[2].Validate())
+		chunks[2].Checksum = "abc"
+		assert.Error(t, chunks[2].Validate())
+		chunks[1].Sequence = -1
+		assert.Error(t, chunks[1].Validate())
+	})
 }
 
 func TestStateEvents(t *testing.T) {
@@ -594,6 +721,38 @@ func TestCustomEvents(t *testing.T) {
 		event.Name = ""
 		assert.Error(t, event.Validate())
 	})
+
+	t.Run("TypedCustomEvent", func(t *testing.T) {
+		type progress struct {
+			Step    string `json:"step"`
+			Percent int    `json:"percent"`
+		}
+
+		event, err := NewTypedCustomEvent("progress", progress{Step: "index", Percent: 40})
+		require.NoError(t, err)
+		assert.NoError(t, event.Validate())
+
+		var decoded progress
+		require.NoError(t, event.Decode(&decoded))
+		assert.Equal(t, progress{Step: "index", Percent: 40}, decoded)
+
+		data, err := event.ToJSON()
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"value":{"step":"index","percent":40}`)
+
+		// Values decoded from the wire arrive as generic JSON
+		parsed, err := EventFromJSON(data)
+		require.NoError(t, err)
+		var received progress
+		require.NoError(t, parsed.(*CustomEvent).Decode(&received))
+		assert.Equal(t, decoded, received)
+
+		var mismatched []string
+		assert.Error(t, event.Decode(&mismatched))
+
+		_, err = NewTypedCustomEvent("bad", make(chan int))
+		assert.Error(t, err)
+	})
 }
 
 func TestMessageSerialization(t *testing.T) {
@@ -645,6 +804,30 @@ func TestMessageSerialization(t *testing.T) {
 	})
 }
 
+func TestValidateEvent(t *testing.T) {
+	// A missing role means assistant, but a present one must be known
+	assert.NoError(t, ValidateEvent(NewTextMessageStartEvent("msg-1")))
+	assert.NoError(t, ValidateEvent(NewTextMessageStartEvent("msg-1", WithRole("user"))))
+	assert.ErrorContains(t, ValidateEvent(NewTextMessageStartEvent("msg-1", WithRole("robot"))), "unsupported role")
+	assert.ErrorContains(t, ValidateEvent(NewTextMessageStartEvent("msg-1", WithRole(""))), "unsupported role")
+
+	unknown, err := NewUnknownEvent([]byte(`{"type":"FUTURE_EVENT"}`))
+	require.NoError(t, err)
+	assert.NoError(t, ValidateEvent(unknown))
+
+	assert.ErrorContains(t, ValidateEvent(nil), "nil")
+	assert.ErrorContains(t, ValidateEvent((*TextMessageStartEvent)(nil)), "nil")
+	assert.ErrorContains(t, ValidateEvent(&TextMessageStartEvent{MessageID: "msg-1"}), "base event is required")
+	assert.ErrorContains(t, ValidateEvent(NewTextMessageStartEvent("")), "messageId field is required")
+	assert.ErrorContains(t, ValidateEvent(NewToolCallStartEvent("call-1", "")), "toolCallName field is required")
+	assert.ErrorContains(t, ValidateEvent(NewStateDeltaEvent([]JSONPatchOperation{{Op: "bogus", Path: "/a"}})), "invalid operation")
+
+	mismatched := NewTextMessageStartEvent("msg-1")
+	mismatched.EventType = EventTypeToolCallStart
+	assert.ErrorContains(t, ValidateEvent(mismatched), "type TOOL_CALL_START belongs to *events.ToolCallStartEvent")
+	assert.ErrorContains(t, ValidateSequence([]Event{mismatched}), "event 0 validation failed")
+}
+
 func TestEventSequenceValidation(t *testing.T) {
 	t.Run("ValidSequence", func(t *testing.T) {
 		events := []Event{
@@ -778,6 +961,7 @@ func TestJSONSerialization(t *testing.T) {
 		testEvents := []Event{
 			NewRunStartedEvent("thread-1", "run-1"),
 			NewTextMessageStartEvent("msg-1", WithRole("user")),
+			NewTextReasoningContentEvent("msg-1", "Considering"),
 			NewTextMessageContentEvent("msg-1", "Hello"),
 			NewTextMessageChunkEvent(strPtr("msg-1"), strPtr("assistant"), strPtr("Chunk")),
 			NewToolCallStartEvent("tool-1", "get_weather", WithParentMessageID("msg-1")),
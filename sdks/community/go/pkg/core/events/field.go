@@ -0,0 +1,31 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/jsonpointer"
+)
+
+// GetField returns the value at an RFC 6901 JSON Pointer in the event's JSON
+// form, for example "/type", "/messages/0/id" or "/value/user~1name". Values
+// are decoded as by encoding/json, so numbers are float64, objects are
+// map[string]any and arrays are []any. Missing paths return an error wrapping
+// jsonpointer.ErrNotFound and malformed pointers one wrapping
+// jsonpointer.ErrInvalidPointer.
+func GetField(event Event, pointer string) (any, error) {
+	if event == nil {
+		return nil, fmt.Errorf("event cannot be nil")
+	}
+
+	data, err := event.ToJSON()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to decode event: %w", err)
+	}
+	return jsonpointer.Get(doc, pointer)
+}
@@ -0,0 +1,60 @@
+package events
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/jsonpointer"
+	coretypes "github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetField(t *testing.T) {
+	snapshot := NewMessagesSnapshotEvent([]Message{
+		{ID: "msg-1", Role: coretypes.RoleUser, Content: "hi"},
+	})
+	custom := NewCustomEvent("routing", WithValue(map[string]any{"a/b": 1, "list": []any{"x", "y"}}))
+
+	tests := []struct {
+		name    string
+		event   Event
+		pointer string
+		want    any
+	}{
+		{"Type", snapshot, "/type", "MESSAGES_SNAPSHOT"},
+		{"Nested", snapshot, "/messages/0/role", "user"},
+		{"Escaped", custom, "/value/a~1b", float64(1)},
+		{"ArrayElement", custom, "/value/list/1", "y"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetField(tt.event, tt.pointer)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("WholeDocument", func(t *testing.T) {
+		got, err := GetField(snapshot, "")
+		require.NoError(t, err)
+		assert.IsType(t, map[string]any{}, got)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := GetField(snapshot, "/messages/3/id")
+		assert.True(t, errors.Is(err, jsonpointer.ErrNotFound))
+		_, err = GetField(snapshot, "/missing")
+		assert.True(t, errors.Is(err, jsonpointer.ErrNotFound))
+	})
+
+	t.Run("InvalidPointer", func(t *testing.T) {
+		_, err := GetField(snapshot, "type")
+		assert.True(t, errors.Is(err, jsonpointer.ErrInvalidPointer))
+	})
+
+	t.Run("NilEvent", func(t *testing.T) {
+		_, err := GetField(nil, "/type")
+		assert.Error(t, err)
+	})
+}
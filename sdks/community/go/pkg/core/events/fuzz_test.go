@@ -0,0 +1,47 @@
+package events_test
+
+import (
+	"testing"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/conformance"
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// FuzzDecodeEvent feeds arbitrary bytes to the event decoder, seeded with the
+// conformance fixtures. Decoding must fail with an error rather than panic,
+// and an event that decodes and validates must survive a round trip through
+// its JSON encoding. Run it with
+//
+//	go test -fuzz FuzzDecodeEvent ./pkg/core/events
+func FuzzDecodeEvent(f *testing.F) {
+	cases, err := conformance.LoadFixtures("../../conformance/testdata")
+	if err != nil {
+		f.Fatal(err)
+	}
+	for _, c := range cases {
+		f.Add([]byte(c.Event))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		_, _ = events.EventFromJSONStrict(data)
+
+		event, err := events.EventFromJSON(data)
+		if err != nil {
+			assert.Nil(t, event)
+			return
+		}
+		if events.ValidateEvent(event) != nil {
+			return
+		}
+		_ = events.Pretty(event)
+
+		encoded, err := event.ToJSON()
+		require.NoError(t, err)
+		decoded, err := events.EventFromJSON(encoded)
+		require.NoError(t, err, "%s", encoded)
+		require.NoError(t, events.ValidateEvent(decoded), "%s", encoded)
+		assert.True(t, events.EventsEqual(event, decoded), "%s", encoded)
+	})
+}
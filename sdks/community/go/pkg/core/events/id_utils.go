@@ -2,6 +2,8 @@ package events
 
 import (
 	"fmt"
+	"strconv"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
@@ -143,3 +145,51 @@ func GenerateThreadID() string {
 func GenerateStepID() string {
 	return defaultIDGenerator.GenerateStepID()
 }
+
+// EventIDGenerator assigns stream-unique, monotonically increasing event IDs
+// for transports that support resumption. IDs are decimal sequence numbers,
+// optionally preceded by a prefix, so a server can map a Last-Event-ID header
+// back to a position in the stream. It is safe for concurrent use.
+type EventIDGenerator struct {
+	prefix string
+	seq    atomic.Uint64
+}
+
+// NewEventIDGenerator creates a generator whose IDs start at 1 and carry the
+// given prefix
+func NewEventIDGenerator(prefix string) *EventIDGenerator {
+	return &EventIDGenerator{prefix: prefix}
+}
+
+// Next returns the next event ID
+func (g *EventIDGenerator) Next() string {
+	return g.prefix + strconv.FormatUint(g.seq.Add(1), 10)
+}
+
+// Assign sets the next event ID on event and returns the event
+func (g *EventIDGenerator) Assign(event Event) Event {
+	if event == nil {
+		return nil
+	}
+	event.GetBaseEvent().EventID = g.Next()
+	return event
+}
+
+// Resume continues the sequence after lastID, typically the Last-Event-ID of
+// a reconnecting client, so that new IDs sort after the ones already sent.
+// IDs that do not belong to this generator are ignored.
+func (g *EventIDGenerator) Resume(lastID string) {
+	if len(lastID) <= len(g.prefix) || lastID[:len(g.prefix)] != g.prefix {
+		return
+	}
+	seq, err := strconv.ParseUint(lastID[len(g.prefix):], 10, 64)
+	if err != nil {
+		return
+	}
+	for {
+		current := g.seq.Load()
+		if current >= seq || g.seq.CompareAndSwap(current, seq) {
+			return
+		}
+	}
+}
@@ -311,3 +311,43 @@ func TestGlobalIDGenerator(t *testing.T) {
 		assert.Equal(t, 100, len(ids))
 	})
 }
+
+func TestEventIDGenerator(t *testing.T) {
+	gen := NewEventIDGenerator("evt-")
+	assert.Equal(t, "evt-1", gen.Next())
+	assert.Equal(t, "evt-2", gen.Next())
+
+	event := gen.Assign(NewRunStartedEvent("thread-1", "run-1"))
+	assert.Equal(t, "evt-3", event.GetBaseEvent().EventID)
+	assert.Equal(t, "evt-3", event.GetBaseEvent().ID())
+	assert.Nil(t, gen.Assign(nil))
+
+	t.Run("Resume", func(t *testing.T) {
+		gen := NewEventIDGenerator("evt-")
+		gen.Resume("evt-41")
+		assert.Equal(t, "evt-42", gen.Next())
+
+		// Earlier and foreign IDs leave the sequence alone
+		gen.Resume("evt-10")
+		gen.Resume("other-100")
+		gen.Resume("evt-abc")
+		assert.Equal(t, "evt-43", gen.Next())
+	})
+
+	t.Run("Concurrent", func(t *testing.T) {
+		gen := NewEventIDGenerator("")
+		ids := make(chan string, 100)
+		for i := 0; i < 100; i++ {
+			go func() {
+				ids <- gen.Next()
+			}()
+		}
+
+		seen := make(map[string]bool)
+		for i := 0; i < 100; i++ {
+			seen[<-ids] = true
+		}
+		assert.Len(t, seen, 100)
+		assert.Equal(t, "101", gen.Next())
+	})
+}
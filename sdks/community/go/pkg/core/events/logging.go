@@ -0,0 +1,45 @@
+package events
+
+import "log/slog"
+
+// DiscardLogger is the default logger of components that accept one. It
+// drops every record.
+var DiscardLogger = slog.New(slog.DiscardHandler)
+
+// orDiscard returns logger, or DiscardLogger when it is nil
+func orDiscard(logger *slog.Logger) *slog.Logger {
+	if logger == nil {
+		return DiscardLogger
+	}
+	return logger
+}
+
+// Attribute keys of the log records written by the SDK
+const (
+	// LogKeyEventType is the event type
+	LogKeyEventType = "event_type"
+	// LogKeyEventID is the transport event ID, when the event has one
+	LogKeyEventID = "event_id"
+	// LogKeyBytes is the size of the event's JSON encoding
+	LogKeyBytes = "bytes"
+	// LogKeyError is the error that caused a warning
+	LogKeyError = "error"
+)
+
+// LogAttrs returns the standard attributes describing an event of the given
+// encoded size, for use in log records about it. bytes is omitted when
+// negative, and the event ID when empty.
+func LogAttrs(event Event, bytes int) []slog.Attr {
+	attrs := make([]slog.Attr, 0, 3)
+	if event == nil {
+		return attrs
+	}
+	attrs = append(attrs, slog.String(LogKeyEventType, string(event.Type())))
+	if base := event.GetBaseEvent(); base != nil && base.EventID != "" {
+		attrs = append(attrs, slog.String(LogKeyEventID, base.EventID))
+	}
+	if bytes >= 0 {
+		attrs = append(attrs, slog.Int(LogKeyBytes, bytes))
+	}
+	return attrs
+}
@@ -0,0 +1,97 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+
+	coretypes "github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/types"
+)
+
+// NewUserMessage creates a user message with text content
+func NewUserMessage(id, text string) (Message, error) {
+	return newMessage(Message{
+		ID:      id,
+		Role:    coretypes.RoleUser,
+		Content: text,
+	})
+}
+
+// NewAssistantMessage creates an assistant message with optional text content
+// and tool calls. An empty text leaves the content unset, as is usual for
+// messages that only call tools.
+func NewAssistantMessage(id, text string, toolCalls ...ToolCall) (Message, error) {
+	msg := Message{
+		ID:        id,
+		Role:      coretypes.RoleAssistant,
+		ToolCalls: toolCalls,
+	}
+	if text != "" {
+		msg.Content = text
+	}
+	return newMessage(msg)
+}
+
+// NewToolResult creates a tool message carrying the result of the tool call
+// identified by toolCallID
+func NewToolResult(id, toolCallID, content string) (Message, error) {
+	return newMessage(Message{
+		ID:         id,
+		Role:       coretypes.RoleTool,
+		Content:    content,
+		ToolCallID: toolCallID,
+	})
+}
+
+// ToolResultMessage creates the tool message reporting the result of call,
+// ready to be appended to the conversation before continuing the run. A
+// string result is used as the content as is; any other result is marshalled
+// to JSON. The message gets a generated ID.
+func ToolResultMessage(call ToolCall, result any) (Message, error) {
+	content, ok := result.(string)
+	if !ok {
+		data, err := json.Marshal(result)
+		if err != nil {
+			return Message{}, fmt.Errorf("failed to marshal result of tool call %s: %w", call.ID, err)
+		}
+		content = string(data)
+	}
+	return NewToolResult(GenerateMessageID(), call.ID, content)
+}
+
+// ToolErrorMessage creates the tool message reporting that call failed with
+// toolErr. The error text is set as both the Error field and the content, so
+// that models which only read content still see it.
+func ToolErrorMessage(call ToolCall, toolErr error) (Message, error) {
+	if toolErr == nil {
+		return Message{}, fmt.Errorf("tool call %s: error result requires a non-nil error", call.ID)
+	}
+	return newMessage(Message{
+		ID:         GenerateMessageID(),
+		Role:       coretypes.RoleTool,
+		Content:    toolErr.Error(),
+		ToolCallID: call.ID,
+		Error:      toolErr.Error(),
+	})
+}
+
+// NewActivityMessage creates an activity message. The content is checked
+// against the schema registered for activityType, if any.
+func NewActivityMessage(id, activityType string, content map[string]any) (Message, error) {
+	if content == nil {
+		content = map[string]any{}
+	}
+	return newMessage(Message{
+		ID:           id,
+		Role:         coretypes.RoleActivity,
+		Content:      content,
+		ActivityType: activityType,
+	})
+}
+
+// newMessage returns msg if it passes validation
+func newMessage(msg Message) (Message, error) {
+	if err := validateMessage(msg); err != nil {
+		return Message{}, err
+	}
+	return msg, nil
+}
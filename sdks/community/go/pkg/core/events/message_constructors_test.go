@@ -0,0 +1,105 @@
+package events
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	coretypes "github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageConstructors(t *testing.T) {
+	t.Run("User", func(t *testing.T) {
+		msg, err := NewUserMessage("msg-1", "hello")
+		require.NoError(t, err)
+		assert.Equal(t, coretypes.RoleUser, msg.Role)
+		text, ok := msg.ContentString()
+		assert.True(t, ok)
+		assert.Equal(t, "hello", text)
+
+		_, err = NewUserMessage("", "hello")
+		assert.Error(t, err)
+	})
+
+	t.Run("Assistant", func(t *testing.T) {
+		msg, err := NewAssistantMessage("msg-2", "hi")
+		require.NoError(t, err)
+		assert.Equal(t, "hi", msg.Content)
+
+		call := ToolCall{ID: "call-1", Type: coretypes.ToolCallTypeFunction, Function: coretypes.FunctionCall{Name: "search", Arguments: "{}"}}
+		msg, err = NewAssistantMessage("msg-3", "", call)
+		require.NoError(t, err)
+		assert.Nil(t, msg.Content)
+		assert.Equal(t, []ToolCall{call}, msg.ToolCalls)
+
+		_, err = NewAssistantMessage("msg-4", "", ToolCall{ID: "call-2"})
+		assert.Error(t, err)
+	})
+
+	t.Run("ToolResult", func(t *testing.T) {
+		msg, err := NewToolResult("msg-5", "call-1", `{"ok":true}`)
+		require.NoError(t, err)
+		assert.Equal(t, coretypes.RoleTool, msg.Role)
+		assert.Equal(t, "call-1", msg.ToolCallID)
+
+		_, err = NewToolResult("msg-6", "", "result")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "toolCallId")
+	})
+
+	t.Run("Activity", func(t *testing.T) {
+		msg, err := NewActivityMessage("msg-7", "PLAN", map[string]any{"steps": []any{"a"}})
+		require.NoError(t, err)
+		assert.Equal(t, "PLAN", msg.ActivityType)
+		content, ok := msg.ContentActivity()
+		assert.True(t, ok)
+		assert.Equal(t, []any{"a"}, content["steps"])
+
+		msg, err = NewActivityMessage("msg-8", "PLAN", nil)
+		require.NoError(t, err)
+		_, ok = msg.ContentActivity()
+		assert.True(t, ok)
+
+		_, err = NewActivityMessage("msg-9", "", nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "activityType")
+	})
+}
+
+func TestToolResultMessage(t *testing.T) {
+	call := ToolCall{ID: "call-1", Type: coretypes.ToolCallTypeFunction, Function: coretypes.FunctionCall{Name: "weather"}}
+
+	msg, err := ToolResultMessage(call, map[string]any{"temp": 21, "unit": "C"})
+	require.NoError(t, err)
+	assert.Equal(t, coretypes.RoleTool, msg.Role)
+	assert.Equal(t, "call-1", msg.ToolCallID)
+	assert.Equal(t, `{"temp":21,"unit":"C"}`, msg.Content)
+	assert.True(t, strings.HasPrefix(msg.ID, "msg-"))
+
+	msg, err = ToolResultMessage(call, "sunny")
+	require.NoError(t, err)
+	assert.Equal(t, "sunny", msg.Content)
+
+	_, err = ToolResultMessage(call, make(chan int))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "call-1")
+
+	_, err = ToolResultMessage(ToolCall{}, "orphan")
+	assert.Error(t, err)
+}
+
+func TestToolErrorMessage(t *testing.T) {
+	call := ToolCall{ID: "call-1", Type: coretypes.ToolCallTypeFunction, Function: coretypes.FunctionCall{Name: "weather"}}
+
+	msg, err := ToolErrorMessage(call, errors.New("service unavailable"))
+	require.NoError(t, err)
+	assert.Equal(t, "call-1", msg.ToolCallID)
+	assert.Equal(t, "service unavailable", msg.Error)
+	assert.Equal(t, "service unavailable", msg.Content)
+	assert.NoError(t, ValidateMessages([]Message{msg}))
+
+	_, err = ToolErrorMessage(call, nil)
+	assert.Error(t, err)
+}
@@ -2,15 +2,20 @@ package events
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+
+	coretypes "github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/types"
 )
 
 // TextMessageStartEvent indicates the start of a streaming text message
 type TextMessageStartEvent struct {
 	*BaseEvent
-	MessageID string  `json:"messageId"`
-	Role      *string `json:"role,omitempty"`
-	Name      string  `json:"name,omitempty"`
+	MessageID string `json:"messageId"`
+	// Role is one of developer, system, assistant or user. As in the
+	// TypeScript SDK, a message without a role is an assistant message.
+	Role *string `json:"role,omitempty"`
+	Name string  `json:"name,omitempty"`
 }
 
 // NewTextMessageStartEvent creates a new text message start event
@@ -63,6 +68,14 @@ func (e *TextMessageStartEvent) Validate() error {
 		return fmt.Errorf("TextMessageStartEvent validation failed: messageId field is required")
 	}
 
+	if e.Role != nil {
+		switch coretypes.Role(*e.Role) {
+		case coretypes.RoleDeveloper, coretypes.RoleSystem, coretypes.RoleAssistant, coretypes.RoleUser:
+		default:
+			return fmt.Errorf("TextMessageStartEvent validation failed: unsupported role %q", *e.Role)
+		}
+	}
+
 	return nil
 }
 
@@ -136,6 +149,46 @@ func (e *TextMessageContentEvent) ToJSON() ([]byte, error) {
 	return json.Marshal(e)
 }
 
+// TextReasoningContentEvent contains a piece of the hidden reasoning behind a
+// streaming text message. It is sent between the message's start and end
+// events and assembled separately from the visible content.
+type TextReasoningContentEvent struct {
+	*BaseEvent
+	MessageID string `json:"messageId"`
+	Delta     string `json:"delta"`
+}
+
+// NewTextReasoningContentEvent creates a new text reasoning content event
+func NewTextReasoningContentEvent(messageID, delta string) *TextReasoningContentEvent {
+	return &TextReasoningContentEvent{
+		BaseEvent: NewBaseEvent(EventTypeTextReasoningContent),
+		MessageID: messageID,
+		Delta:     delta,
+	}
+}
+
+// Validate validates the text reasoning content event
+func (e *TextReasoningContentEvent) Validate() error {
+	if err := e.BaseEvent.Validate(); err != nil {
+		return err
+	}
+
+	if e.MessageID == "" {
+		return fmt.Errorf("TextReasoningContentEvent validation failed: messageId field is required")
+	}
+
+	if e.Delta == "" {
+		return fmt.Errorf("TextReasoningContentEvent validation failed: delta field must not be empty")
+	}
+
+	return nil
+}
+
+// ToJSON serializes the event to JSON
+func (e *TextReasoningContentEvent) ToJSON() ([]byte, error) {
+	return json.Marshal(e)
+}
+
 // TextMessageEndEvent indicates the end of a streaming text message
 type TextMessageEndEvent struct {
 	*BaseEvent
@@ -255,3 +308,58 @@ func (e *TextMessageChunkEvent) Validate() error {
 func (e *TextMessageChunkEvent) ToJSON() ([]byte, error) {
 	return json.Marshal(e)
 }
+
+// ErrMessageNotFound is returned when a MESSAGE_UPDATE references a message
+// that does not exist
+var ErrMessageNotFound = errors.New("message not found")
+
+// MessageUpdateEvent replaces the content of a message that was sent earlier,
+// for agents that revise a message after streaming it. The message must
+// already exist where the update is applied, see ApplyMessageUpdate.
+type MessageUpdateEvent struct {
+	*BaseEvent
+	MessageID string `json:"messageId"`
+	Content   string `json:"content"`
+}
+
+// NewMessageUpdateEvent creates a new message update event
+func NewMessageUpdateEvent(messageID, content string) *MessageUpdateEvent {
+	return &MessageUpdateEvent{
+		BaseEvent: NewBaseEvent(EventTypeMessageUpdate),
+		MessageID: messageID,
+		Content:   content,
+	}
+}
+
+// Validate validates the message update event. Empty content is allowed, as
+// a revision may clear a message.
+func (e *MessageUpdateEvent) Validate() error {
+	if err := e.BaseEvent.Validate(); err != nil {
+		return err
+	}
+
+	if e.MessageID == "" {
+		return fmt.Errorf("MessageUpdateEvent validation failed: messageId field is required")
+	}
+
+	return nil
+}
+
+// ToJSON serializes the event to JSON
+func (e *MessageUpdateEvent) ToJSON() ([]byte, error) {
+	return json.Marshal(e)
+}
+
+// ApplyMessageUpdate returns a copy of messages with the content of the
+// message referenced by update replaced. It returns an error wrapping
+// ErrMessageNotFound when no message has the referenced ID.
+func ApplyMessageUpdate(messages []Message, update *MessageUpdateEvent) ([]Message, error) {
+	for i := range messages {
+		if messages[i].ID == update.MessageID {
+			updated := append([]Message(nil), messages...)
+			updated[i].Content = update.Content
+			return updated, nil
+		}
+	}
+	return nil, fmt.Errorf("cannot update message %s: %w", update.MessageID, ErrMessageNotFound)
+}
@@ -0,0 +1,309 @@
+package events
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	coretypes "github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/types"
+)
+
+// MessageValidationError describes why a message does not satisfy the rules
+// for its role. Field names the offending JSON field, such as "content",
+// "toolCallId" or "toolCalls[1]".
+type MessageValidationError struct {
+	Field  string
+	Role   coretypes.Role
+	Reason string
+	// Err is the underlying error, when the failure came from a nested check
+	// such as tool call or activity schema validation
+	Err error
+}
+
+// Error implements the error interface
+func (e *MessageValidationError) Error() string {
+	return e.Reason
+}
+
+// Unwrap returns the underlying error, if any
+func (e *MessageValidationError) Unwrap() error {
+	return e.Err
+}
+
+// MessageError reports why the message at a given position is invalid
+type MessageError struct {
+	Index int
+	ID    string
+	Err   error
+}
+
+// Error implements the error interface
+func (e *MessageError) Error() string {
+	if e.ID == "" {
+		return fmt.Sprintf("message %d: %v", e.Index, e.Err)
+	}
+	return fmt.Sprintf("message %d (%s): %v", e.Index, e.ID, e.Err)
+}
+
+// Unwrap returns the underlying validation error
+func (e *MessageError) Unwrap() error {
+	return e.Err
+}
+
+// MessageErrors collects the errors of every invalid message in a batch. It
+// implements Unwrap() []error, so errors.Is and errors.As see each
+// *MessageError and the validation errors they wrap.
+type MessageErrors []*MessageError
+
+// Error implements the error interface, listing one message per line
+func (e MessageErrors) Error() string {
+	lines := make([]string, len(e))
+	for i, err := range e {
+		lines[i] = err.Error()
+	}
+	return fmt.Sprintf("%d invalid messages:\n%s", len(e), strings.Join(lines, "\n"))
+}
+
+// Unwrap returns the individual message errors
+func (e MessageErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+// RoleRule checks a message with a custom role. Errors that are not a
+// *MessageValidationError are reported against the message content.
+type RoleRule func(msg Message) error
+
+// RoleLike returns a rule that validates a custom role with the rules of a
+// built-in role, for example RoleLike(coretypes.RoleSystem) for an
+// instruction role that carries string content
+func RoleLike(base coretypes.Role) RoleRule {
+	return func(msg Message) error {
+		role := msg.Role
+		msg.Role = base
+		err := validateBuiltinMessage(msg)
+		var validationErr *MessageValidationError
+		if errors.As(err, &validationErr) {
+			validationErr.Role = role
+			validationErr.Reason = strings.ReplaceAll(validationErr.Reason, string(base)+" messages", string(role)+" messages")
+		}
+		return err
+	}
+}
+
+// MessageValidator validates messages against the rules for their role. The
+// zero configuration, used by ValidateMessages and event validation, knows
+// the AG-UI roles; WithCustomRole adds deployment-specific roles and
+// WithMaxToolCalls a tool call limit per validator instance. A
+// MessageValidator is safe for concurrent use.
+type MessageValidator struct {
+	roles        map[coretypes.Role]RoleRule
+	maxToolCalls int
+}
+
+// MessageValidatorOption defines options for creating message validators
+type MessageValidatorOption func(*MessageValidator)
+
+// WithCustomRole accepts messages with role, checking them with rule after the
+// id has been checked. Registering a built-in role replaces its rules.
+func WithCustomRole(role coretypes.Role, rule RoleRule) MessageValidatorOption {
+	return func(v *MessageValidator) {
+		v.roles[role] = rule
+	}
+}
+
+// WithMaxToolCalls rejects messages with more than n tool calls, so that
+// hosts can stop runaway agents from requesting dozens of tools at once. Zero
+// or a negative n, the default, allows any number.
+func WithMaxToolCalls(n int) MessageValidatorOption {
+	return func(v *MessageValidator) {
+		v.maxToolCalls = n
+	}
+}
+
+// NewMessageValidator creates a new message validator
+func NewMessageValidator(options ...MessageValidatorOption) *MessageValidator {
+	v := &MessageValidator{roles: make(map[coretypes.Role]RoleRule)}
+	for _, opt := range options {
+		opt(v)
+	}
+	return v
+}
+
+// defaultMessageValidator applies the built-in role rules
+var defaultMessageValidator = NewMessageValidator()
+
+// ValidateMessage validates a single message. Failures are reported as
+// *MessageValidationError.
+func (v *MessageValidator) ValidateMessage(msg Message) error {
+	if err := v.validateRole(msg); err != nil {
+		return err
+	}
+
+	if v.maxToolCalls > 0 && len(msg.ToolCalls) > v.maxToolCalls {
+		return &MessageValidationError{Field: "toolCalls", Role: msg.Role,
+			Reason: fmt.Sprintf("message has %d tool calls, more than the limit of %d", len(msg.ToolCalls), v.maxToolCalls)}
+	}
+	return nil
+}
+
+// validateRole checks a message against the rules for its role
+func (v *MessageValidator) validateRole(msg Message) error {
+	rule, ok := v.roles[msg.Role]
+	if !ok || msg.Role == "" {
+		return validateBuiltinMessage(msg)
+	}
+
+	if msg.ID == "" {
+		return &MessageValidationError{Field: "id", Role: msg.Role, Reason: "message id field is required"}
+	}
+	if err := rule(msg); err != nil {
+		var validationErr *MessageValidationError
+		if errors.As(err, &validationErr) {
+			return err
+		}
+		return &MessageValidationError{Field: "content", Role: msg.Role, Reason: err.Error(), Err: err}
+	}
+	return nil
+}
+
+// ValidateMessages validates every message of a conversation instead of
+// stopping at the first problem. Besides the per-message rules, message IDs
+// must be unique. It returns nil when all messages are valid and a
+// MessageErrors otherwise.
+func (v *MessageValidator) ValidateMessages(msgs []Message) error {
+	var errs MessageErrors
+	seen := make(map[string]int, len(msgs))
+	for i, msg := range msgs {
+		if err := v.ValidateMessage(msg); err != nil {
+			errs = append(errs, &MessageError{Index: i, ID: msg.ID, Err: err})
+			continue
+		}
+		if first, ok := seen[msg.ID]; ok {
+			errs = append(errs, &MessageError{Index: i, ID: msg.ID,
+				Err: fmt.Errorf("duplicate message id %s (first seen at index %d)", msg.ID, first)})
+			continue
+		}
+		seen[msg.ID] = i
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// ValidateMessages validates a conversation with the built-in role rules; see
+// MessageValidator.ValidateMessages
+func ValidateMessages(msgs []Message) error {
+	return defaultMessageValidator.ValidateMessages(msgs)
+}
+
+// conversationConfig holds the settings applied by ConversationOption values
+type conversationConfig struct {
+	requireToolResults bool
+}
+
+// ConversationOption defines options for ValidateConversation
+type ConversationOption func(*conversationConfig)
+
+// RequireToolResults reports assistant tool calls that no later tool message
+// answers. It is off by default, since a conversation captured mid-run may
+// still be waiting for results.
+func RequireToolResults(require bool) ConversationOption {
+	return func(c *conversationConfig) {
+		c.requireToolResults = require
+	}
+}
+
+// ValidateConversation validates the messages like ValidateMessages and then
+// checks that they fit together: every tool message must answer a tool call
+// made by an earlier assistant message, each tool call must be answered at
+// most once, and tool call IDs must be unique across the conversation. It
+// returns nil when the conversation is valid and a MessageErrors ordered by
+// message index otherwise.
+func (v *MessageValidator) ValidateConversation(msgs []Message, options ...ConversationOption) error {
+	config := &conversationConfig{}
+	for _, opt := range options {
+		opt(config)
+	}
+
+	var errs MessageErrors
+	if err := v.ValidateMessages(msgs); err != nil {
+		errs = err.(MessageErrors)
+	}
+	invalid := make(map[int]bool, len(errs))
+	for _, err := range errs {
+		invalid[err.Index] = true
+	}
+
+	type callState struct {
+		index    int
+		answered bool
+	}
+	calls := make(map[string]*callState)
+	fail := func(i int, format string, args ...any) {
+		errs = append(errs, &MessageError{Index: i, ID: msgs[i].ID, Err: fmt.Errorf(format, args...)})
+	}
+
+	for i, msg := range msgs {
+		switch msg.Role {
+		case coretypes.RoleAssistant:
+			// The calls of an invalid message are still recorded, so that
+			// their results are not reported again as orphans
+			for _, toolCall := range msg.ToolCalls {
+				if call, ok := calls[toolCall.ID]; ok {
+					if !invalid[i] {
+						fail(i, "tool call id %s was already used by message %d", toolCall.ID, call.index)
+					}
+					continue
+				}
+				calls[toolCall.ID] = &callState{index: i}
+			}
+		case coretypes.RoleTool:
+			if invalid[i] {
+				continue
+			}
+			call, ok := calls[msg.ToolCallID]
+			switch {
+			case !ok:
+				fail(i, "tool result for unknown tool call %s", msg.ToolCallID)
+			case call.answered:
+				fail(i, "tool call %s already has a result", msg.ToolCallID)
+			default:
+				call.answered = true
+			}
+		}
+	}
+
+	if config.requireToolResults {
+		for i, msg := range msgs {
+			for _, toolCall := range msg.ToolCalls {
+				if call, ok := calls[toolCall.ID]; ok && call.index == i && !call.answered {
+					fail(i, "tool call %s has no result", toolCall.ID)
+				}
+			}
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	sort.SliceStable(errs, func(a, b int) bool { return errs[a].Index < errs[b].Index })
+	return errs
+}
+
+// ValidateConversation validates a conversation with the built-in role rules;
+// see MessageValidator.ValidateConversation
+func ValidateConversation(msgs []Message, options ...ConversationOption) error {
+	return defaultMessageValidator.ValidateConversation(msgs, options...)
+}
+
+// validateMessage validates a single message with the built-in role rules
+func validateMessage(msg Message) error {
+	return defaultMessageValidator.ValidateMessage(msg)
+}
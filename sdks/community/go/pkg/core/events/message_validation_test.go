@@ -0,0 +1,237 @@
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	coretypes "github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateMessages(t *testing.T) {
+	assert.NoError(t, ValidateMessages(nil))
+	assert.NoError(t, ValidateMessages([]Message{
+		{ID: "msg-1", Role: coretypes.RoleUser, Content: "hi"},
+		{ID: "msg-2", Role: coretypes.RoleAssistant, Content: "hello"},
+	}))
+
+	err := ValidateMessages([]Message{
+		{ID: "msg-1", Role: coretypes.RoleUser, Content: "hi"},
+		{ID: "msg-2", Role: coretypes.RoleTool, Content: "42"},
+		{ID: "msg-3", Role: coretypes.RoleAssistant, Content: "ok"},
+		{Role: coretypes.RoleSystem, Content: "be brief"},
+		{ID: "msg-1", Role: coretypes.RoleAssistant, Content: "again"},
+	})
+	require.Error(t, err)
+
+	var msgErrs MessageErrors
+	require.True(t, errors.As(err, &msgErrs))
+	require.Len(t, msgErrs, 3)
+	assert.Equal(t, 1, msgErrs[0].Index)
+	assert.Equal(t, "msg-2", msgErrs[0].ID)
+	assert.Contains(t, msgErrs[0].Error(), "toolCallId field is required")
+	assert.Equal(t, 3, msgErrs[1].Index)
+	assert.Equal(t, "message 3: message id field is required", msgErrs[1].Error())
+	assert.Contains(t, msgErrs[2].Error(), "duplicate message id msg-1 (first seen at index 0)")
+
+	assert.Contains(t, err.Error(), "3 invalid messages:\nmessage 1 (msg-2): ")
+
+	// Every message error is reachable through errors.As
+	var joined interface{ Unwrap() []error }
+	require.True(t, errors.As(err, &joined))
+	assert.Len(t, joined.Unwrap(), 3)
+	var msgErr *MessageError
+	require.True(t, errors.As(err, &msgErr))
+	assert.Equal(t, 1, msgErr.Index)
+}
+
+func TestMessagesSnapshotEvent_ToMessages(t *testing.T) {
+	valid := []Message{
+		{ID: "msg-1", Role: coretypes.RoleUser, Content: "hi"},
+		{ID: "msg-2", Role: coretypes.RoleAssistant, Content: "hello"},
+	}
+	msgs, err := NewMessagesSnapshotEvent(valid).ToMessages()
+	require.NoError(t, err)
+	assert.Equal(t, valid, msgs)
+
+	event := NewMessagesSnapshotEvent([]Message{
+		{ID: "msg-1", Role: coretypes.RoleUser, Content: "hi"},
+		{ID: "", Role: coretypes.RoleUser, Content: "no id"},
+		{ID: "msg-3"},
+	})
+	msgs, err = event.ToMessages()
+	assert.Nil(t, msgs)
+
+	var msgErrs MessageErrors
+	require.True(t, errors.As(err, &msgErrs))
+	require.Len(t, msgErrs, 2)
+	assert.Equal(t, 1, msgErrs[0].Index)
+	assert.Equal(t, 2, msgErrs[1].Index)
+
+	// The raw field stays available
+	assert.Len(t, event.Messages, 3)
+}
+
+func TestValidateMessages_AssistantContentParts(t *testing.T) {
+	parts := []coretypes.AssistantContent{
+		{Type: coretypes.AssistantContentTypeText, Text: "See "},
+		{Type: coretypes.AssistantContentTypeCitation, Text: "[1]", URL: "https://example.com"},
+	}
+	assert.NoError(t, ValidateMessages([]Message{
+		{ID: "msg-1", Role: coretypes.RoleAssistant, Content: "legacy string"},
+		{ID: "msg-2", Role: coretypes.RoleAssistant, Content: parts},
+	}))
+
+	var decoded MessagesSnapshotEvent
+	require.NoError(t, json.Unmarshal([]byte(`{"type":"MESSAGES_SNAPSHOT","messages":[
+		{"id":"msg-1","role":"assistant","content":[{"type":"code","text":"x := 1","language":"go"}]}
+	]}`), &decoded))
+	assert.NoError(t, decoded.Validate())
+
+	var validationErr *MessageValidationError
+	err := validateMessage(Message{ID: "msg-3", Role: coretypes.RoleAssistant, Content: []coretypes.AssistantContent{
+		parts[0],
+		{Type: coretypes.AssistantContentTypeCitation, Text: "[2]"},
+	}})
+	require.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, "content[1]", validationErr.Field)
+	assert.Contains(t, err.Error(), "url field is required")
+
+	err = validateMessage(Message{ID: "msg-4", Role: coretypes.RoleAssistant, Content: map[string]any{"text": "hi"}})
+	assert.ErrorContains(t, err, "must be a string or content part array")
+}
+
+func TestValidateMessages_Reasoning(t *testing.T) {
+	assert.NoError(t, ValidateMessages([]Message{
+		{ID: "msg-1", Role: coretypes.RoleAssistant, Content: "42", Reasoning: "6 times 7"},
+	}))
+
+	err := ValidateMessages([]Message{
+		{ID: "msg-1", Role: coretypes.RoleUser, Content: "hi", Reasoning: "hidden"},
+	})
+	var validationErr *MessageValidationError
+	require.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, "reasoning", validationErr.Field)
+}
+
+func TestMessageValidator_CustomRoles(t *testing.T) {
+	const instruction coretypes.Role = "instruction"
+	const audit coretypes.Role = "audit"
+
+	validator := NewMessageValidator(
+		WithCustomRole(instruction, RoleLike(coretypes.RoleSystem)),
+		WithCustomRole(audit, func(msg Message) error {
+			if _, ok := msg.Content.(map[string]interface{}); !ok {
+				return errors.New("audit content must be an object")
+			}
+			return nil
+		}),
+	)
+
+	msg := Message{ID: "msg-1", Role: instruction, Content: "be brief"}
+	assert.NoError(t, validator.ValidateMessage(msg))
+	assert.Error(t, ValidateMessages([]Message{msg}), "default validator rejects unknown roles")
+
+	var validationErr *MessageValidationError
+	err := validator.ValidateMessage(Message{ID: "msg-2", Role: instruction})
+	require.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, instruction, validationErr.Role)
+	assert.Equal(t, "content", validationErr.Field)
+
+	err = validator.ValidateMessage(Message{ID: "msg-3", Role: audit, Content: "text"})
+	require.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, audit, validationErr.Role)
+	assert.Equal(t, "content", validationErr.Field)
+	assert.Equal(t, "audit content must be an object", err.Error())
+
+	err = validator.ValidateMessage(Message{Role: audit, Content: map[string]interface{}{}})
+	require.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, "id", validationErr.Field)
+
+	assert.NoError(t, validator.ValidateMessages([]Message{
+		msg,
+		{ID: "msg-4", Role: audit, Content: map[string]interface{}{"action": "login"}},
+		{ID: "msg-5", Role: coretypes.RoleUser, Content: "hi"},
+	}))
+}
+
+func TestMessageValidator_MaxToolCalls(t *testing.T) {
+	msg := Message{ID: "msg-1", Role: coretypes.RoleAssistant, ToolCalls: []ToolCall{
+		{ID: "call-1", Type: "function", Function: Function{Name: "search", Arguments: "{}"}},
+		{ID: "call-2", Type: "function", Function: Function{Name: "search", Arguments: "{}"}},
+		{ID: "call-3", Type: "function", Function: Function{Name: "search", Arguments: "{}"}},
+	}}
+
+	assert.NoError(t, NewMessageValidator().ValidateMessage(msg))
+	assert.NoError(t, NewMessageValidator(WithMaxToolCalls(0)).ValidateMessage(msg))
+	assert.NoError(t, NewMessageValidator(WithMaxToolCalls(3)).ValidateMessage(msg))
+
+	validator := NewMessageValidator(WithMaxToolCalls(2))
+	var validationErr *MessageValidationError
+	err := validator.ValidateMessage(msg)
+	require.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, "toolCalls", validationErr.Field)
+	assert.Equal(t, "message has 3 tool calls, more than the limit of 2", err.Error())
+
+	err = validator.ValidateConversation([]Message{{ID: "msg-0", Role: coretypes.RoleUser, Content: "go"}, msg})
+	var messageErrs MessageErrors
+	require.True(t, errors.As(err, &messageErrs))
+	require.Len(t, messageErrs, 1)
+	assert.Equal(t, 1, messageErrs[0].Index)
+}
+
+func TestValidateConversation(t *testing.T) {
+	call := func(id string) ToolCall {
+		return ToolCall{ID: id, Type: "function", Function: Function{Name: "lookup", Arguments: "{}"}}
+	}
+	assistant := func(id string, calls ...ToolCall) Message {
+		return Message{ID: id, Role: coretypes.RoleAssistant, ToolCalls: calls}
+	}
+	result := func(id, callID string) Message {
+		return Message{ID: id, Role: coretypes.RoleTool, Content: "ok", ToolCallID: callID}
+	}
+
+	valid := []Message{
+		{ID: "msg-1", Role: coretypes.RoleUser, Content: "hi"},
+		assistant("msg-2", call("call-1"), call("call-2")),
+		result("msg-3", "call-1"),
+		result("msg-4", "call-2"),
+	}
+	assert.NoError(t, ValidateConversation(valid))
+	assert.NoError(t, ValidateConversation(valid, RequireToolResults(true)))
+
+	// A pending call is only reported on request
+	pending := valid[:3]
+	assert.NoError(t, ValidateConversation(pending))
+	err := ValidateConversation(pending, RequireToolResults(true))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "message 1 (msg-2): tool call call-2 has no result")
+
+	err = ValidateConversation([]Message{
+		result("msg-1", "call-1"),
+		assistant("msg-2", call("call-1")),
+		result("msg-3", "call-1"),
+		result("msg-4", "call-1"),
+		assistant("msg-5", call("call-1")),
+	})
+	var errs MessageErrors
+	require.True(t, errors.As(err, &errs))
+	require.Len(t, errs, 3)
+	assert.Equal(t, 0, errs[0].Index)
+	assert.Contains(t, errs[0].Error(), "tool result for unknown tool call call-1")
+	assert.Equal(t, 3, errs[1].Index)
+	assert.Contains(t, errs[1].Error(), "tool call call-1 already has a result")
+	assert.Equal(t, 4, errs[2].Index)
+	assert.Contains(t, errs[2].Error(), "tool call id call-1 was already used by message 1")
+
+	// Per-message failures are reported once, without correlation noise
+	err = ValidateConversation([]Message{
+		assistant("msg-1", call("call-1"), call("call-1")),
+		result("msg-2", "call-1"),
+	})
+	require.True(t, errors.As(err, &errs))
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "duplicate tool call id call-1")
+}
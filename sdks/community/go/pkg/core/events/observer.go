@@ -0,0 +1,17 @@
+package events
+
+// Observer receives a callback for every event an encoder writes or a decoder
+// reads, with the size in bytes of the event's JSON payload. It is meant for
+// metrics such as per-type event counters and size histograms, so
+// implementations must be cheap and safe for concurrent use.
+type Observer interface {
+	ObserveEvent(eventType EventType, bytes int)
+}
+
+// ObserverFunc adapts a function to the Observer interface
+type ObserverFunc func(eventType EventType, bytes int)
+
+// ObserveEvent implements Observer
+func (f ObserverFunc) ObserveEvent(eventType EventType, bytes int) {
+	f(eventType, bytes)
+}
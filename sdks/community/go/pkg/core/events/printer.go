@@ -0,0 +1,307 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultPrinterMaxValueLength is how many characters of a JSON value, such
+// as a state snapshot or tool result, a Printer shows before truncating it
+const DefaultPrinterMaxValueLength = 80
+
+// ANSI escape sequences used by a Printer with color enabled
+const (
+	ansiReset   = "\x1b[0m"
+	ansiRed     = "\x1b[31m"
+	ansiGreen   = "\x1b[32m"
+	ansiYellow  = "\x1b[33m"
+	ansiBlue    = "\x1b[34m"
+	ansiMagenta = "\x1b[35m"
+	ansiCyan    = "\x1b[36m"
+	ansiGray    = "\x1b[90m"
+)
+
+// Printer renders events as compact, single-line, human-readable text for
+// CLI tools that tail a stream: text content shows its delta, a finished tool
+// call shows as "call name(args)" and state deltas as a summary of their
+// operations. It remembers the name and arguments of open tool calls, so one
+// Printer should be used per stream. It is safe for concurrent use.
+type Printer struct {
+	color          bool
+	maxValueLength int
+
+	mu        sync.Mutex
+	toolCalls *toolCallTable[*printerToolCall]
+}
+
+// printerToolCall is a tool call whose end has not been printed yet
+type printerToolCall struct {
+	name string
+	args strings.Builder
+}
+
+// PrinterOption defines options for creating a Printer
+type PrinterOption func(*Printer)
+
+// WithColor highlights event types with ANSI colors by category: lifecycle,
+// errors, text, tool calls, state, reasoning and everything else
+func WithColor() PrinterOption {
+	return func(p *Printer) {
+		p.color = true
+	}
+}
+
+// WithMaxValueLength sets how many characters of a JSON value are shown
+// before it is truncated. Zero or less shows values in full. The default is
+// DefaultPrinterMaxValueLength.
+func WithMaxValueLength(n int) PrinterOption {
+	return func(p *Printer) {
+		p.maxValueLength = n
+	}
+}
+
+// NewPrinter creates a Printer with the given options
+func NewPrinter(options ...PrinterOption) *Printer {
+	p := &Printer{
+		maxValueLength: DefaultPrinterMaxValueLength,
+		toolCalls:      newToolCallTable[*printerToolCall](),
+	}
+	for _, opt := range options {
+		opt(p)
+	}
+	return p
+}
+
+// Pretty renders a single event with a new default Printer. Tool call ends
+// are shown without their name and arguments, which only a Printer that saw
+// the whole call can recall.
+func Pretty(event Event) string {
+	return NewPrinter().Format(event)
+}
+
+// Format renders event as one line of text, without a trailing newline
+func (p *Printer) Format(event Event) string {
+	if event == nil {
+		return ""
+	}
+	detail := p.detail(event)
+	label := string(event.Type())
+	if p.color {
+		label = eventColor(event.Type()) + label + ansiReset
+	}
+	if detail == "" {
+		return label
+	}
+	return label + " " + detail
+}
+
+// detail renders the event specific part of a line
+func (p *Printer) detail(event Event) string {
+	switch e := event.(type) {
+	case *RunStartedEvent:
+		return fmt.Sprintf("thread=%s run=%s", e.ThreadID(), e.RunID())
+	case *RunFinishedEvent:
+		parts := []string{fmt.Sprintf("thread=%s run=%s", e.ThreadID(), e.RunID())}
+		if e.Outcome != nil {
+			parts = append(parts, "outcome="+string(e.Outcome.Type))
+		}
+		if e.Result != nil {
+			parts = append(parts, "result="+p.value(e.Result))
+		}
+		return strings.Join(parts, " ")
+	case *RunErrorEvent:
+		if e.Code != nil && *e.Code != "" {
+			return fmt.Sprintf("[%s] %s", *e.Code, e.Message)
+		}
+		return e.Message
+	case *StepStartedEvent:
+		return e.StepName
+	case *StepFinishedEvent:
+		return e.StepName
+
+	case *TextMessageStartEvent:
+		if e.Role != nil {
+			return fmt.Sprintf("%s (%s)", e.MessageID, *e.Role)
+		}
+		return e.MessageID
+	case *TextMessageContentEvent:
+		return e.MessageID + " " + strconv.Quote(e.Delta)
+	case *TextMessageEndEvent:
+		return e.MessageID
+	case *TextMessageChunkEvent:
+		return joinNonEmpty(deref(e.MessageID), quoteNonNil(e.Delta))
+	case *MessageUpdateEvent:
+		return e.MessageID + " " + p.truncate(strconv.Quote(e.Content))
+
+	case *ToolCallStartEvent:
+		p.mu.Lock()
+		_ = p.toolCalls.start("", e.ToolCallID, e.Index, &printerToolCall{name: e.ToolCallName})
+		p.mu.Unlock()
+		return describeToolCall("", e.ToolCallID, e.Index) + " " + e.ToolCallName
+	case *ToolCallArgsEvent:
+		p.mu.Lock()
+		if entry, _ := p.toolCalls.lookup("", e.ToolCallID, e.Index); entry != nil {
+			entry.value.args.WriteString(e.Delta)
+		}
+		p.mu.Unlock()
+		return describeToolCall("", e.ToolCallID, e.Index) + " " + strconv.Quote(e.Delta)
+	case *ToolCallEndEvent:
+		p.mu.Lock()
+		entry, _ := p.toolCalls.lookup("", e.ToolCallID, e.Index)
+		if entry != nil {
+			p.toolCalls.end(entry)
+		}
+		p.mu.Unlock()
+		if entry == nil {
+			return e.ToolCallID
+		}
+		call := entry.value
+		return fmt.Sprintf("%s call %s(%s)", e.ToolCallID, call.name, p.truncate(call.args.String()))
+	case *ToolCallResultChunkEvent:
+		summary := fmt.Sprintf("%s #%d %d bytes", e.ToolCallID, e.Sequence, len(e.Data))
+		if e.Final {
+			summary += " (final)"
+		}
+		return summary
+	case *ToolCallChunkEvent:
+		return joinNonEmpty(deref(e.ToolCallID), deref(e.ToolCallName), quoteNonNil(e.Delta))
+	case *ToolCallResultEvent:
+		return fmt.Sprintf("%s -> %s", e.ToolCallID, p.truncate(strconv.Quote(e.Content)))
+
+	case *StateSnapshotEvent:
+		return p.value(e.Snapshot)
+	case *StateDeltaEvent:
+		if e.PatchFormat() == StatePatchMergePatch {
+			return "merge " + p.truncate(string(e.MergePatch))
+		}
+		return summarizePatch(e.Delta)
+	case *MessagesSnapshotEvent:
+		summary := fmt.Sprintf("%d messages", len(e.Messages))
+		if e.Partial {
+			summary += fmt.Sprintf(" (partial, cursor=%s)", e.Cursor)
+		}
+		return summary
+	case *ActivitySnapshotEvent:
+		return fmt.Sprintf("%s %s %s", e.MessageID, e.ActivityType, p.value(e.Content))
+	case *ActivityDeltaEvent:
+		return fmt.Sprintf("%s %s %s", e.MessageID, e.ActivityType, summarizePatch(e.Patch))
+
+	case *CustomEvent:
+		if e.Value == nil {
+			return e.Name
+		}
+		return e.Name + " " + p.value(e.Value)
+	case *RawEvent:
+		return joinNonEmpty(deref(e.Source), p.value(e.Event))
+	}
+
+	return p.fields(event)
+}
+
+// fields renders the JSON fields of an event without a dedicated format,
+// leaving out the type and timestamp already shown or not worth showing
+func (p *Printer) fields(event Event) string {
+	data, err := event.ToJSON()
+	if err != nil {
+		return ""
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return ""
+	}
+	delete(fields, "type")
+	delete(fields, "timestamp")
+	delete(fields, "rawEvent")
+	if len(fields) == 0 {
+		return ""
+	}
+	return p.value(fields)
+}
+
+// value renders v as compact JSON, truncated to the configured length
+func (p *Printer) value(v any) string {
+	var data []byte
+	switch raw := v.(type) {
+	case json.RawMessage:
+		data = raw
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprintf("%v", v)
+		}
+		data = encoded
+	}
+	return p.truncate(string(data))
+}
+
+// truncate shortens s to the configured length, marking the cut with "…"
+func (p *Printer) truncate(s string) string {
+	if p.maxValueLength <= 0 {
+		return s
+	}
+	runes := []rune(s)
+	if len(runes) <= p.maxValueLength {
+		return s
+	}
+	return string(runes[:p.maxValueLength]) + "…"
+}
+
+// summarizePatch lists the operation and path of each JSON Patch operation
+func summarizePatch(ops []JSONPatchOperation) string {
+	parts := make([]string, len(ops))
+	for i, op := range ops {
+		parts[i] = op.Op + " " + op.Path
+	}
+	return strings.Join(parts, ", ")
+}
+
+// eventColor returns the ANSI color for the category of an event type
+func eventColor(eventType EventType) string {
+	name := string(eventType)
+	switch {
+	case eventType == EventTypeRunError:
+		return ansiRed
+	case strings.HasPrefix(name, "RUN_"), strings.HasPrefix(name, "STEP_"):
+		return ansiCyan
+	case strings.HasPrefix(name, "TEXT_MESSAGE_"):
+		return ansiGreen
+	case strings.HasPrefix(name, "TOOL_CALL_"):
+		return ansiYellow
+	case strings.HasPrefix(name, "STATE_"), strings.HasPrefix(name, "MESSAGES_"), strings.HasPrefix(name, "ACTIVITY_"):
+		return ansiBlue
+	case strings.HasPrefix(name, "REASONING_"), strings.HasPrefix(name, "THINKING_"), eventType == EventTypeTextReasoningContent:
+		return ansiMagenta
+	default:
+		return ansiGray
+	}
+}
+
+// joinNonEmpty joins the non-empty parts with spaces
+func joinNonEmpty(parts ...string) string {
+	kept := parts[:0]
+	for _, part := range parts {
+		if part != "" {
+			kept = append(kept, part)
+		}
+	}
+	return strings.Join(kept, " ")
+}
+
+// deref returns the string s points to, or "" for nil
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// quoteNonNil quotes the string s points to, or returns "" for nil
+func quoteNonNil(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return strconv.Quote(*s)
+}
@@ -0,0 +1,60 @@
+package events
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrinter(t *testing.T) {
+	p := NewPrinter()
+
+	lines := []struct {
+		event Event
+		want  string
+	}{
+		{NewRunStartedEvent("thread-1", "run-1"), "RUN_STARTED thread=thread-1 run=run-1"},
+		{NewStepStartedEvent("plan"), "STEP_STARTED plan"},
+		{NewTextMessageStartEvent("msg-1", WithRole("assistant")), "TEXT_MESSAGE_START msg-1 (assistant)"},
+		{NewTextMessageContentEvent("msg-1", "Hello\n"), `TEXT_MESSAGE_CONTENT msg-1 "Hello\n"`},
+		{NewMessageUpdateEvent("msg-1", "Hello"), `MESSAGE_UPDATE msg-1 "Hello"`},
+		{NewToolCallStartEvent("call-1", "search"), "TOOL_CALL_START call-1 search"},
+		{NewToolCallArgsEvent("call-1", `{"q":`), `TOOL_CALL_ARGS call-1 "{\"q\":"`},
+		{NewToolCallArgsEvent("call-1", `"go"}`), `TOOL_CALL_ARGS call-1 "\"go\"}"`},
+		{NewToolCallEndEvent("call-1"), `TOOL_CALL_END call-1 call search({"q":"go"})`},
+		{NewToolCallEndEvent("call-1"), "TOOL_CALL_END call-1"},
+		{NewToolCallStartEvent("", "lookup", WithToolCallIndex(0)), "TOOL_CALL_START #0 lookup"},
+		{NewToolCallArgsEventWithOptions("", "{}", WithToolCallArgsIndex(0)), `TOOL_CALL_ARGS #0 "{}"`},
+		{NewToolCallEndEventWithOptions("call-2", WithToolCallEndIndex(0)), "TOOL_CALL_END call-2 call lookup({})"},
+		{NewToolCallResultEvent("msg-2", "call-1", "3 hits"), `TOOL_CALL_RESULT call-1 -> "3 hits"`},
+		{NewToolCallResultChunks("msg-3", "call-3", "image/png", []byte("png"), 0)[0], "TOOL_CALL_RESULT_CHUNK call-3 #0 3 bytes (final)"},
+		{NewStateSnapshotEvent(map[string]any{"count": 1}), `STATE_SNAPSHOT {"count":1}`},
+		{NewStateDeltaEvent([]JSONPatchOperation{
+			{Op: "replace", Path: "/count", Value: 2},
+			{Op: "remove", Path: "/draft"},
+		}), "STATE_DELTA replace /count, remove /draft"},
+		{NewStateMergePatchEvent(json.RawMessage(`{"count":3}`)), `STATE_DELTA merge {"count":3}`},
+		{NewMessagesSnapshotPage([]Message{{ID: "m", Role: "user", Content: "hi"}}, "page-1"), "MESSAGES_SNAPSHOT 1 messages (partial, cursor=page-1)"},
+		{NewCustomEvent("app.ping", WithValue(map[string]any{"n": 1})), `CUSTOM app.ping {"n":1}`},
+		{NewReasoningMessageContentEvent("r-1", "hmm"), `REASONING_MESSAGE_CONTENT {"delta":"hmm","messageId":"r-1"}`},
+		{NewRunErrorEvent("boom", WithErrorCode(RunErrorCodeInternal)), "RUN_ERROR [INTERNAL] boom"},
+	}
+	for _, line := range lines {
+		assert.Equal(t, line.want, p.Format(line.event))
+	}
+
+	assert.Equal(t, "", p.Format(nil))
+	assert.Equal(t, "TEXT_MESSAGE_END msg-1", Pretty(NewTextMessageEndEvent("msg-1")))
+}
+
+func TestPrinter_Options(t *testing.T) {
+	long := NewStateSnapshotEvent(map[string]any{"text": strings.Repeat("x", 100)})
+	assert.Equal(t, `STATE_SNAPSHOT {"text":"x…`, NewPrinter(WithMaxValueLength(10)).Format(long))
+	assert.Len(t, NewPrinter(WithMaxValueLength(0)).Format(long), len(`STATE_SNAPSHOT {"text":""}`)+100)
+
+	colored := NewPrinter(WithColor())
+	assert.Equal(t, "\x1b[31mRUN_ERROR\x1b[0m boom", colored.Format(NewRunErrorEvent("boom")))
+	assert.Equal(t, "\x1b[33mTOOL_CALL_END\x1b[0m call-1", colored.Format(NewToolCallEndEvent("call-1")))
+}
@@ -0,0 +1,152 @@
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// EventFactory decodes the JSON of an application-defined event into its Go
+// type. The returned event must report the type it was registered for.
+type EventFactory func(data json.RawMessage) (Event, error)
+
+var (
+	registeredTypesMu sync.RWMutex
+	registeredTypes   = map[EventType]int{}
+)
+
+// Registry maps application-defined event types to the factories that decode
+// them, so that applications can add proprietary events without forking the
+// SDK. Standard AG-UI types always decode to their own Go types and cannot be
+// registered. Events of a registered type pass BaseEvent validation and
+// ValidateSequence while the type is registered with any registry. A Registry
+// is safe for concurrent use.
+type Registry struct {
+	mu        sync.RWMutex
+	factories map[EventType]EventFactory
+}
+
+// NewRegistry creates an empty event type registry
+func NewRegistry() *Registry {
+	return &Registry{factories: make(map[EventType]EventFactory)}
+}
+
+// Register adds an event type decoded by factory. Registering an existing
+// type replaces its factory.
+func (r *Registry) Register(eventType EventType, factory EventFactory) error {
+	if eventType == "" {
+		return fmt.Errorf("event type is required")
+	}
+	if eventType.IsValid() {
+		return fmt.Errorf("cannot register standard event type %s", eventType)
+	}
+	if factory == nil {
+		return fmt.Errorf("factory for event type %s is required", eventType)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.factories[eventType]; !exists {
+		registeredTypesMu.Lock()
+		registeredTypes[eventType]++
+		registeredTypesMu.Unlock()
+	}
+	r.factories[eventType] = factory
+	return nil
+}
+
+// Unregister removes an event type
+func (r *Registry) Unregister(eventType EventType) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.factories[eventType]; !exists {
+		return
+	}
+	delete(r.factories, eventType)
+
+	registeredTypesMu.Lock()
+	defer registeredTypesMu.Unlock()
+	if registeredTypes[eventType]--; registeredTypes[eventType] == 0 {
+		delete(registeredTypes, eventType)
+	}
+}
+
+// Types returns the registered event types in sorted order
+func (r *Registry) Types() []EventType {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	types := make([]EventType, 0, len(r.factories))
+	for eventType := range r.factories {
+		types = append(types, eventType)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	return types
+}
+
+// Decode parses an event like EventFromJSON, decoding events of a registered
+// type with their factory. Other unrecognized types still fail with an error
+// wrapping ErrUnknownEventType.
+func (r *Registry) Decode(data []byte) (Event, error) {
+	return r.decode(data, EventFromJSON)
+}
+
+// DecodeStrict parses an event like EventFromJSONStrict. Events of a
+// registered type are decoded by their factory, which decides itself whether
+// to reject unknown fields.
+func (r *Registry) DecodeStrict(data []byte) (Event, error) {
+	return r.decode(data, EventFromJSONStrict)
+}
+
+// decode tries the standard decoder before the registered factories
+func (r *Registry) decode(data []byte, standard func([]byte) (Event, error)) (Event, error) {
+	event, err := standard(data)
+	if !errors.Is(err, ErrUnknownEventType) {
+		return event, err
+	}
+
+	var base struct {
+		Type EventType `json:"type"`
+	}
+	if json.Unmarshal(data, &base) != nil {
+		return nil, err
+	}
+	factory, ok := r.factory(base.Type)
+	if !ok {
+		return nil, err
+	}
+	return decodeRegistered(base.Type, factory, data)
+}
+
+// factory returns the factory registered for an event type
+func (r *Registry) factory(eventType EventType) (EventFactory, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	factory, ok := r.factories[eventType]
+	return factory, ok
+}
+
+// decodeRegistered decodes an event with the factory registered for its type
+func decodeRegistered(eventType EventType, factory EventFactory, data []byte) (Event, error) {
+	event, err := factory(json.RawMessage(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", eventType, err)
+	}
+	if event == nil {
+		return nil, fmt.Errorf("failed to decode %s: factory returned no event", eventType)
+	}
+	if event.Type() != eventType {
+		return nil, fmt.Errorf("failed to decode %s: factory returned a %s event", eventType, event.Type())
+	}
+	return event, nil
+}
+
+// isRegisteredEventType reports whether an application-defined event type is
+// registered with any registry
+func isRegisteredEventType(eventType EventType) bool {
+	registeredTypesMu.RLock()
+	defer registeredTypesMu.RUnlock()
+	return registeredTypes[eventType] > 0
+}
@@ -0,0 +1,104 @@
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const eventTypePing EventType = "APP_PING"
+
+// pingEvent is an application-defined event for registry tests
+type pingEvent struct {
+	*BaseEvent
+	Seq int `json:"seq"`
+}
+
+// ToJSON serializes the event to JSON
+func (e *pingEvent) ToJSON() ([]byte, error) {
+	return json.Marshal(e)
+}
+
+// decodePing is the EventFactory of pingEvent
+func decodePing(data json.RawMessage) (Event, error) {
+	event := &pingEvent{BaseEvent: NewBaseEvent(eventTypePing)}
+	if err := json.Unmarshal(data, event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry()
+	assert.Error(t, r.Register("", decodePing))
+	assert.ErrorContains(t, r.Register(EventTypeCustom, decodePing), "cannot register standard event type CUSTOM")
+	assert.Error(t, r.Register(eventTypePing, nil))
+
+	ping := []byte(`{"type":"APP_PING","seq":3}`)
+	_, err := r.Decode(ping)
+	assert.True(t, errors.Is(err, ErrUnknownEventType))
+	assert.Error(t, (&pingEvent{BaseEvent: NewBaseEvent(eventTypePing)}).Validate())
+
+	require.NoError(t, r.Register(eventTypePing, decodePing))
+	defer r.Unregister(eventTypePing)
+	assert.Equal(t, []EventType{eventTypePing}, r.Types())
+
+	event, err := r.Decode(ping)
+	require.NoError(t, err)
+	require.IsType(t, &pingEvent{}, event)
+	assert.Equal(t, 3, event.(*pingEvent).Seq)
+	assert.NoError(t, ValidateEvent(event))
+	assert.NoError(t, ValidateSequence([]Event{NewRunStartedEvent("thread-1", "run-1"), event}))
+
+	event, err = r.DecodeStrict(ping)
+	require.NoError(t, err)
+	assert.Equal(t, eventTypePing, event.Type())
+
+	event, err = r.Decode([]byte(`{"type":"STEP_STARTED","stepName":"plan"}`))
+	require.NoError(t, err)
+	assert.IsType(t, &StepStartedEvent{}, event)
+
+	_, err = r.Decode([]byte(`{"type":"APP_PING","seq":"three"}`))
+	assert.ErrorContains(t, err, "failed to decode APP_PING")
+
+	_, err = r.Decode([]byte(`{"type":"APP_OTHER"}`))
+	assert.True(t, errors.Is(err, ErrUnknownEventType))
+
+	require.NoError(t, r.Register("APP_PONG", func(json.RawMessage) (Event, error) {
+		return NewStepStartedEvent("plan"), nil
+	}))
+	_, err = r.Decode([]byte(`{"type":"APP_PONG"}`))
+	assert.ErrorContains(t, err, "factory returned a STEP_STARTED event")
+
+	r.Unregister("APP_PONG")
+	r.Unregister("APP_PONG")
+	assert.Equal(t, []EventType{eventTypePing}, r.Types())
+}
+
+func TestRegistry_Unregister(t *testing.T) {
+	first, second := NewRegistry(), NewRegistry()
+	require.NoError(t, first.Register(eventTypePing, decodePing))
+	require.NoError(t, second.Register(eventTypePing, decodePing))
+	ping := &pingEvent{BaseEvent: NewBaseEvent(eventTypePing)}
+
+	first.Unregister(eventTypePing)
+	assert.NoError(t, ping.Validate(), "still registered with the second registry")
+	second.Unregister(eventTypePing)
+	assert.Error(t, ping.Validate())
+}
+
+func TestEventDecoder_WithRegistry(t *testing.T) {
+	r := NewRegistry()
+	require.NoError(t, r.Register(eventTypePing, decodePing))
+	defer r.Unregister(eventTypePing)
+
+	_, err := NewEventDecoder(nil).DecodeEvent("APP_PING", []byte(`{"seq":1}`))
+	assert.Error(t, err)
+
+	event, err := NewEventDecoder(nil, WithRegistry(r)).DecodeEvent("APP_PING", []byte(`{"seq":1}`))
+	require.NoError(t, err)
+	assert.Equal(t, 1, event.(*pingEvent).Seq)
+}
@@ -0,0 +1,90 @@
+package events
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Well-known run error codes
+const (
+	RunErrorCodeTimeout      = "TIMEOUT"
+	RunErrorCodeRateLimited  = "RATE_LIMITED"
+	RunErrorCodeInvalidInput = "INVALID_INPUT"
+	RunErrorCodeCancelled    = "CANCELLED"
+	RunErrorCodeInternal     = "INTERNAL"
+)
+
+// Sentinel errors for the well-known run error codes, for use with errors.Is
+var (
+	ErrRunTimeout      = errors.New("run timed out")
+	ErrRunRateLimited  = errors.New("run rate limited")
+	ErrRunInvalidInput = errors.New("invalid run input")
+	ErrRunCancelled    = errors.New("run cancelled")
+	ErrRunInternal     = errors.New("internal run error")
+)
+
+var (
+	runErrorCodesMu sync.RWMutex
+	runErrorCodes   = map[string]error{
+		RunErrorCodeTimeout:      ErrRunTimeout,
+		RunErrorCodeRateLimited:  ErrRunRateLimited,
+		RunErrorCodeInvalidInput: ErrRunInvalidInput,
+		RunErrorCodeCancelled:    ErrRunCancelled,
+		RunErrorCodeInternal:     ErrRunInternal,
+	}
+)
+
+// RegisterRunErrorCode associates an error code with a sentinel error so that
+// errors returned by RunErrorEvent.AsError match it with errors.Is.
+// Registering an existing code replaces its sentinel.
+func RegisterRunErrorCode(code string, sentinel error) {
+	runErrorCodesMu.Lock()
+	defer runErrorCodesMu.Unlock()
+	runErrorCodes[code] = sentinel
+}
+
+// LookupRunErrorCode returns the sentinel error registered for a code
+func LookupRunErrorCode(code string) (error, bool) {
+	runErrorCodesMu.RLock()
+	defer runErrorCodesMu.RUnlock()
+	sentinel, ok := runErrorCodes[code]
+	return sentinel, ok
+}
+
+// RunError is the Go error form of a RUN_ERROR event
+type RunError struct {
+	Code    string
+	Message string
+	Details map[string]any
+	RunID   string
+}
+
+// Error implements the error interface
+func (e *RunError) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("run error: %s", e.Message)
+	}
+	return fmt.Sprintf("run error [%s]: %s", e.Code, e.Message)
+}
+
+// Unwrap returns the sentinel error registered for the error code, if any
+func (e *RunError) Unwrap() error {
+	sentinel, _ := LookupRunErrorCode(e.Code)
+	return sentinel
+}
+
+// AsError converts the event into a *RunError. The returned error wraps the
+// sentinel registered for its code, so callers can match it with errors.Is,
+// and can be unwrapped with errors.As to access the code and details.
+func (e *RunErrorEvent) AsError() error {
+	runErr := &RunError{
+		Message: e.Message,
+		Details: e.Details,
+		RunID:   e.RunIDValue,
+	}
+	if e.Code != nil {
+		runErr.Code = *e.Code
+	}
+	return runErr
+}
@@ -0,0 +1,61 @@
+package events
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunErrorEventAsError(t *testing.T) {
+	t.Run("WellKnownCode", func(t *testing.T) {
+		event := NewRunErrorEvent("too many requests",
+			WithErrorCode(RunErrorCodeRateLimited),
+			WithErrorDetails(map[string]any{"retryAfter": 30}),
+			WithRunID("run-1"),
+		)
+
+		err := event.AsError()
+		assert.ErrorIs(t, err, ErrRunRateLimited)
+		assert.False(t, errors.Is(err, ErrRunTimeout))
+		assert.Equal(t, "run error [RATE_LIMITED]: too many requests", err.Error())
+
+		var runErr *RunError
+		require.True(t, errors.As(err, &runErr))
+		assert.Equal(t, RunErrorCodeRateLimited, runErr.Code)
+		assert.Equal(t, 30, runErr.Details["retryAfter"])
+		assert.Equal(t, "run-1", runErr.RunID)
+	})
+
+	t.Run("UnknownAndMissingCode", func(t *testing.T) {
+		err := NewRunErrorEvent("boom", WithErrorCode("SOMETHING_ELSE")).AsError()
+		assert.Nil(t, errors.Unwrap(err))
+
+		err = NewRunErrorEvent("boom").AsError()
+		assert.Equal(t, "run error: boom", err.Error())
+	})
+
+	t.Run("CustomCode", func(t *testing.T) {
+		errQuota := errors.New("quota exceeded")
+		RegisterRunErrorCode("TEST_QUOTA", errQuota)
+		err := NewRunErrorEvent("out of quota", WithErrorCode("TEST_QUOTA")).AsError()
+		assert.ErrorIs(t, err, errQuota)
+	})
+
+	t.Run("DetailsRoundTrip", func(t *testing.T) {
+		event := NewRunErrorEvent("bad input",
+			WithErrorCode(RunErrorCodeInvalidInput),
+			WithErrorDetails(map[string]any{"field": "messages"}),
+		)
+		data, err := event.ToJSON()
+		require.NoError(t, err)
+
+		decoded, err := EventFromJSON(data)
+		require.NoError(t, err)
+		runError, ok := decoded.(*RunErrorEvent)
+		require.True(t, ok)
+		assert.Equal(t, "messages", runError.Details["field"])
+		assert.ErrorIs(t, runError.AsError(), ErrRunInvalidInput)
+	})
+}
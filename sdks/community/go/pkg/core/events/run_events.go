@@ -2,6 +2,7 @@ package events
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/types"
@@ -111,6 +112,10 @@ type RunFinishedOutcome struct {
 	Interrupts []types.Interrupt `json:"interrupts,omitempty"`
 }
 
+// ErrNoRunResult is returned by RunFinishedEvent.Into when the run finished
+// without a result
+var ErrNoRunResult = errors.New("run finished without a result")
+
 // RunFinishedEvent indicates that an agent run has finished successfully
 type RunFinishedEvent struct {
 	*BaseEvent
@@ -228,12 +233,41 @@ func (e *RunFinishedEvent) ToJSON() ([]byte, error) {
 	return json.Marshal(e)
 }
 
+// Into decodes the run result into v, which should be a pointer to the typed
+// result. It returns ErrNoRunResult when the run finished without a result.
+func (e *RunFinishedEvent) Into(v any) error {
+	var data []byte
+	switch result := e.Result.(type) {
+	case nil:
+		return ErrNoRunResult
+	case json.RawMessage:
+		data = result
+	case []byte:
+		data = result
+	default:
+		encoded, err := json.Marshal(result)
+		if err != nil {
+			return fmt.Errorf("failed to encode run result: %w", err)
+		}
+		data = encoded
+	}
+
+	if len(data) == 0 || string(data) == "null" {
+		return ErrNoRunResult
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to decode run result: %w", err)
+	}
+	return nil
+}
+
 // RunErrorEvent indicates that an agent run has encountered an error
 type RunErrorEvent struct {
 	*BaseEvent
-	Code       *string `json:"code,omitempty"`
-	Message    string  `json:"message"`
-	RunIDValue string  `json:"runId,omitempty"`
+	Code       *string        `json:"code,omitempty"`
+	Message    string         `json:"message"`
+	Details    map[string]any `json:"details,omitempty"`
+	RunIDValue string         `json:"runId,omitempty"`
 }
 
 // NewRunErrorEvent creates a new run error event
@@ -260,6 +294,13 @@ func WithErrorCode(code string) RunErrorOption {
 	}
 }
 
+// WithErrorDetails sets structured details describing the error
+func WithErrorDetails(details map[string]any) RunErrorOption {
+	return func(e *RunErrorEvent) {
+		e.Details = details
+	}
+}
+
 // WithRunID sets the run ID for the error
 func WithRunID(runID string) RunErrorOption {
 	return func(e *RunErrorEvent) {
@@ -0,0 +1,252 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+// runPhase is the lifecycle phase tracked by SequenceValidator
+type runPhase int
+
+const (
+	runPhaseIdle runPhase = iota
+	runPhaseRunning
+	runPhaseFinished
+	runPhaseErrored
+)
+
+// SequenceError describes an event that arrived in an invalid position of an event stream
+type SequenceError struct {
+	EventType EventType
+	Reason    string
+	Expected  string
+}
+
+// Error implements the error interface
+func (e *SequenceError) Error() string {
+	return fmt.Sprintf("invalid %s event: %s, expected %s", e.EventType, e.Reason, e.Expected)
+}
+
+// SequenceValidator checks events one at a time against the AG-UI run lifecycle.
+// A stream must begin with RUN_STARTED (or RUN_ERROR), steps must be properly
+// nested, messages, tool calls and reasoning messages must be started before
+// they receive content or end, and a run may only finish once everything it
+// opened has been closed. After RUN_FINISHED a new run may be started; after
+// RUN_ERROR no further events are accepted.
+//
+// It can be used on the server to catch bugs before emitting events, or on the
+// client to reject malformed streams. A SequenceValidator is not safe for
+// concurrent use.
+type SequenceValidator struct {
+	phase             runPhase
+	runID             string
+	steps             []string
+	messages          map[string]bool
+	reasoningMessages map[string]bool
+	toolCalls         *toolCallTable[struct{}]
+
+	logger *slog.Logger
+}
+
+// SequenceValidatorOption defines options for creating sequence validators
+type SequenceValidatorOption func(*SequenceValidator)
+
+// WithSequenceLogger logs every rejected event as a warning with the event's
+// type and ID (see LogAttrs) and the error under LogKeyError. A nil logger
+// restores the default, which discards all records.
+func WithSequenceLogger(logger *slog.Logger) SequenceValidatorOption {
+	return func(v *SequenceValidator) {
+		v.logger = orDiscard(logger)
+	}
+}
+
+// NewSequenceValidator creates a validator expecting the start of a run
+func NewSequenceValidator(options ...SequenceValidatorOption) *SequenceValidator {
+	v := &SequenceValidator{logger: DiscardLogger}
+	for _, opt := range options {
+		opt(v)
+	}
+	v.Reset()
+	return v
+}
+
+// Reset discards all tracked state so the validator expects a new stream
+func (v *SequenceValidator) Reset() {
+	v.phase = runPhaseIdle
+	v.runID = ""
+	v.resetRunState()
+}
+
+// resetRunState clears the state that is scoped to a single run
+func (v *SequenceValidator) resetRunState() {
+	v.steps = nil
+	v.messages = make(map[string]bool)
+	v.reasoningMessages = make(map[string]bool)
+	v.toolCalls = newToolCallTable[struct{}]()
+}
+
+// Check validates the next event of the stream and advances the state machine.
+// Events that fail validation do not change the validator state.
+func (v *SequenceValidator) Check(event Event) error {
+	err := v.check(event)
+	if err != nil {
+		attrs := append(LogAttrs(event, -1), slog.Any(LogKeyError, err))
+		v.logger.LogAttrs(context.Background(), slog.LevelWarn, "event rejected by sequence validator", attrs...)
+	}
+	return err
+}
+
+// check implements Check
+func (v *SequenceValidator) check(event Event) error {
+	if err := ValidateEvent(event); err != nil {
+		return err
+	}
+
+	eventType := event.Type()
+	violation := func(reason, expected string, args ...any) error {
+		return &SequenceError{EventType: eventType, Reason: fmt.Sprintf(reason, args...), Expected: expected}
+	}
+
+	switch v.phase {
+	case runPhaseErrored:
+		return violation("run already terminated by RUN_ERROR", "end of stream")
+	case runPhaseIdle, runPhaseFinished:
+		if eventType != EventTypeRunStarted && eventType != EventTypeRunError {
+			return violation("no run is active", string(EventTypeRunStarted))
+		}
+	}
+
+	switch e := event.(type) {
+	case *RunStartedEvent:
+		if v.phase == runPhaseRunning {
+			return violation("run %s is still active", "RUN_FINISHED or RUN_ERROR", v.runID)
+		}
+		v.phase = runPhaseRunning
+		v.runID = e.RunID()
+		v.resetRunState()
+
+	case *RunFinishedEvent:
+		if e.RunID() != v.runID {
+			return violation("run %s does not match active run %s", "RUN_FINISHED for run "+v.runID, e.RunID(), v.runID)
+		}
+		if err := v.checkNothingOpen(violation); err != nil {
+			return err
+		}
+		v.phase = runPhaseFinished
+
+	case *RunErrorEvent:
+		v.phase = runPhaseErrored
+
+	case *StepStartedEvent:
+		for _, name := range v.steps {
+			if name == e.StepName {
+				return violation("step %s already started", "STEP_FINISHED for step "+name, e.StepName)
+			}
+		}
+		v.steps = append(v.steps, e.StepName)
+
+	case *StepFinishedEvent:
+		if len(v.steps) == 0 {
+			return violation("step %s was not started", "STEP_STARTED for step "+e.StepName, e.StepName)
+		}
+		innermost := v.steps[len(v.steps)-1]
+		if innermost != e.StepName {
+			return violation("step %s overlaps step %s", "STEP_FINISHED for step "+innermost, e.StepName, innermost)
+		}
+		v.steps = v.steps[:len(v.steps)-1]
+
+	case *TextMessageStartEvent:
+		if v.messages[e.MessageID] {
+			return violation("message %s already started", "TEXT_MESSAGE_CONTENT or TEXT_MESSAGE_END", e.MessageID)
+		}
+		v.messages[e.MessageID] = true
+
+	case *TextMessageContentEvent:
+		if !v.messages[e.MessageID] {
+			return violation("message %s was not started", "TEXT_MESSAGE_START", e.MessageID)
+		}
+
+	case *TextReasoningContentEvent:
+		if !v.messages[e.MessageID] {
+			return violation("message %s was not started", "TEXT_MESSAGE_START", e.MessageID)
+		}
+
+	case *TextMessageEndEvent:
+		if !v.messages[e.MessageID] {
+			return violation("message %s was not started", "TEXT_MESSAGE_START", e.MessageID)
+		}
+		delete(v.messages, e.MessageID)
+
+	case *ToolCallStartEvent:
+		if err := v.toolCalls.start("", e.ToolCallID, e.Index, struct{}{}); err != nil {
+			return violation("%v", "TOOL_CALL_ARGS or TOOL_CALL_END", err)
+		}
+
+	case *ToolCallArgsEvent:
+		entry, err := v.toolCalls.lookup("", e.ToolCallID, e.Index)
+		if err != nil {
+			return violation("%v", "TOOL_CALL_ARGS for the same tool call", err)
+		}
+		if entry == nil {
+			return violation("tool call %s was not started", "TOOL_CALL_START", describeToolCall("", e.ToolCallID, e.Index))
+		}
+
+	case *ToolCallEndEvent:
+		entry, err := v.toolCalls.lookup("", e.ToolCallID, e.Index)
+		if err != nil {
+			return violation("%v", "TOOL_CALL_END for the same tool call", err)
+		}
+		if entry == nil {
+			return violation("tool call %s was not started", "TOOL_CALL_START", describeToolCall("", e.ToolCallID, e.Index))
+		}
+		v.toolCalls.end(entry)
+
+	case *ReasoningMessageStartEvent:
+		if v.reasoningMessages[e.MessageID] {
+			return violation("reasoning message %s already started", "REASONING_MESSAGE_CONTENT or REASONING_MESSAGE_END", e.MessageID)
+		}
+		v.reasoningMessages[e.MessageID] = true
+
+	case *ReasoningMessageContentEvent:
+		if !v.reasoningMessages[e.MessageID] {
+			return violation("reasoning message %s was not started", "REASONING_MESSAGE_START", e.MessageID)
+		}
+
+	case *ReasoningMessageEndEvent:
+		if !v.reasoningMessages[e.MessageID] {
+			return violation("reasoning message %s was not started", "REASONING_MESSAGE_START", e.MessageID)
+		}
+		delete(v.reasoningMessages, e.MessageID)
+	}
+
+	return nil
+}
+
+// ActiveSteps returns the names of the steps that have started but not yet
+// finished, outermost first, so that a UI can render the current step nesting
+func (v *SequenceValidator) ActiveSteps() []string {
+	return append([]string(nil), v.steps...)
+}
+
+// checkNothingOpen reports the first step, message or tool call that is still open
+func (v *SequenceValidator) checkNothingOpen(violation func(reason, expected string, args ...any) error) error {
+	if len(v.steps) > 0 {
+		name := v.steps[len(v.steps)-1]
+		return violation("step %s is still active", "STEP_FINISHED for step "+name, name)
+	}
+	for id := range v.messages {
+		return violation("message %s is still open", "TEXT_MESSAGE_END for message "+id, id)
+	}
+	var openToolCall string
+	v.toolCalls.entries(func(entry *toolCallEntry[struct{}]) {
+		openToolCall = entry.describe()
+	})
+	if openToolCall != "" {
+		return violation("tool call %s is still open", "TOOL_CALL_END for tool call "+openToolCall, openToolCall)
+	}
+	for id := range v.reasoningMessages {
+		return violation("reasoning message %s is still open", "REASONING_MESSAGE_END for message "+id, id)
+	}
+	return nil
+}
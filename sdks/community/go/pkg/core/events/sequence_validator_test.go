@@ -0,0 +1,223 @@
+package events
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSequenceValidator(t *testing.T) {
+	t.Run("ValidStream", func(t *testing.T) {
+		v := NewSequenceValidator()
+		stream := []Event{
+			NewRunStartedEvent("thread-1", "run-1"),
+			NewStepStartedEvent("plan"),
+			NewStepStartedEvent("search"),
+			NewToolCallStartEvent("tool-1", "search"),
+			NewToolCallArgsEvent("tool-1", "{}"),
+			NewToolCallEndEvent("tool-1"),
+			NewStepFinishedEvent("search"),
+			NewTextMessageStartEvent("msg-1"),
+			NewTextMessageContentEvent("msg-1", "Hello"),
+			NewTextMessageEndEvent("msg-1"),
+			NewStepFinishedEvent("plan"),
+			NewRunFinishedEvent("thread-1", "run-1"),
+			NewRunStartedEvent("thread-1", "run-2"),
+			NewRunErrorEvent("boom"),
+		}
+		for _, event := range stream {
+			require.NoError(t, v.Check(event), event.Type())
+		}
+	})
+
+	cases := []struct {
+		name      string
+		stream    []Event
+		eventType EventType
+		expected  string
+	}{
+		{
+			name:      "ContentWithoutStart",
+			stream:    []Event{NewRunStartedEvent("t", "r"), NewTextMessageContentEvent("msg-1", "x")},
+			eventType: EventTypeTextMessageContent,
+			expected:  "TEXT_MESSAGE_START",
+		},
+		{
+			name:      "FinishedBeforeStarted",
+			stream:    []Event{NewRunFinishedEvent("t", "r")},
+			eventType: EventTypeRunFinished,
+			expected:  "RUN_STARTED",
+		},
+		{
+			name: "OverlappingSteps",
+			stream: []Event{
+				NewRunStartedEvent("t", "r"),
+				NewStepStartedEvent("a"),
+				NewStepStartedEvent("b"),
+				NewStepFinishedEvent("a"),
+			},
+			eventType: EventTypeStepFinished,
+			expected:  "STEP_FINISHED for step b",
+		},
+		{
+			name: "StepFinishedWithoutStart",
+			stream: []Event{
+				NewRunStartedEvent("t", "r"),
+				NewStepFinishedEvent("plan"),
+			},
+			eventType: EventTypeStepFinished,
+			expected:  "STEP_STARTED for step plan",
+		},
+		{
+			name: "StepStartedTwice",
+			stream: []Event{
+				NewRunStartedEvent("t", "r"),
+				NewStepStartedEvent("plan"),
+				NewStepStartedEvent("plan"),
+			},
+			eventType: EventTypeStepStarted,
+			expected:  "STEP_FINISHED for step plan",
+		},
+		{
+			name: "FinishedWithActiveStep",
+			stream: []Event{
+				NewRunStartedEvent("t", "r"),
+				NewStepStartedEvent("plan"),
+				NewStepStartedEvent("search"),
+				NewStepFinishedEvent("search"),
+				NewRunFinishedEvent("t", "r"),
+			},
+			eventType: EventTypeRunFinished,
+			expected:  "STEP_FINISHED for step plan",
+		},
+		{
+			name: "FinishedWithOpenMessage",
+			stream: []Event{
+				NewRunStartedEvent("t", "r"),
+				NewTextMessageStartEvent("msg-1"),
+				NewRunFinishedEvent("t", "r"),
+			},
+			eventType: EventTypeRunFinished,
+			expected:  "TEXT_MESSAGE_END for message msg-1",
+		},
+		{
+			name: "EventAfterRunError",
+			stream: []Event{
+				NewRunStartedEvent("t", "r"),
+				NewRunErrorEvent("boom"),
+				NewRunStartedEvent("t", "r2"),
+			},
+			eventType: EventTypeRunStarted,
+			expected:  "end of stream",
+		},
+		{
+			name: "NestedRunStarted",
+			stream: []Event{
+				NewRunStartedEvent("t", "r"),
+				NewRunStartedEvent("t", "r2"),
+			},
+			eventType: EventTypeRunStarted,
+			expected:  "RUN_FINISHED or RUN_ERROR",
+		},
+		{
+			name: "ToolCallIndexNotStarted",
+			stream: []Event{
+				NewRunStartedEvent("t", "r"),
+				NewToolCallStartEvent("", "search", WithToolCallIndex(0)),
+				NewToolCallArgsEventWithOptions("", "{}", WithToolCallArgsIndex(1)),
+			},
+			eventType: EventTypeToolCallArgs,
+			expected:  "TOOL_CALL_START",
+		},
+		{
+			name: "ToolCallIndexStillOpen",
+			stream: []Event{
+				NewRunStartedEvent("t", "r"),
+				NewToolCallStartEvent("", "search", WithToolCallIndex(0)),
+				NewRunFinishedEvent("t", "r"),
+			},
+			eventType: EventTypeRunFinished,
+			expected:  "TOOL_CALL_END for tool call #0",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			v := NewSequenceValidator()
+			var err error
+			for i, event := range tc.stream {
+				err = v.Check(event)
+				if i < len(tc.stream)-1 {
+					require.NoError(t, err)
+				}
+			}
+			require.Error(t, err)
+
+			var seqErr *SequenceError
+			require.True(t, errors.As(err, &seqErr))
+			assert.Equal(t, tc.eventType, seqErr.EventType)
+			assert.Equal(t, tc.expected, seqErr.Expected)
+			assert.Contains(t, err.Error(), string(tc.eventType))
+		})
+	}
+
+	t.Run("ActiveSteps", func(t *testing.T) {
+		v := NewSequenceValidator()
+		require.NoError(t, v.Check(NewRunStartedEvent("t", "r")))
+		require.NoError(t, v.Check(NewStepStartedEvent("plan")))
+		require.NoError(t, v.Check(NewStepStartedEvent("search")))
+		assert.Equal(t, []string{"plan", "search"}, v.ActiveSteps())
+
+		require.NoError(t, v.Check(NewStepFinishedEvent("search")))
+		assert.Equal(t, []string{"plan"}, v.ActiveSteps())
+
+		err := v.Check(NewRunFinishedEvent("t", "r"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "step plan is still active")
+	})
+
+	t.Run("ToolCallIndexReconciledWithID", func(t *testing.T) {
+		v := NewSequenceValidator()
+		for _, event := range []Event{
+			NewRunStartedEvent("t", "r"),
+			NewToolCallStartEvent("", "search", WithToolCallIndex(0)),
+			NewToolCallArgsEventWithOptions("", "{", WithToolCallArgsIndex(0)),
+			NewToolCallArgsEventWithOptions("call-1", "}", WithToolCallArgsIndex(0)),
+			NewToolCallEndEvent("call-1"),
+			NewToolCallStartEvent("", "search", WithToolCallIndex(0)),
+			NewToolCallEndEventWithOptions("call-2", WithToolCallEndIndex(0)),
+		} {
+			require.NoError(t, v.Check(event), event.Type())
+		}
+
+		require.NoError(t, v.Check(NewToolCallStartEvent("call-3", "search", WithToolCallIndex(1))))
+		err := v.Check(NewToolCallEndEventWithOptions("call-4", WithToolCallEndIndex(1)))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "#1 already has id call-3")
+		require.NoError(t, v.Check(NewToolCallEndEvent("call-3")))
+		require.NoError(t, v.Check(NewRunFinishedEvent("t", "r")))
+	})
+
+	t.Run("RejectedEventDoesNotChangeState", func(t *testing.T) {
+		v := NewSequenceValidator()
+		require.NoError(t, v.Check(NewRunStartedEvent("t", "r")))
+		require.Error(t, v.Check(NewTextMessageEndEvent("msg-1")))
+		require.NoError(t, v.Check(NewRunFinishedEvent("t", "r")))
+	})
+
+	t.Run("Logger", func(t *testing.T) {
+		var buf bytes.Buffer
+		v := NewSequenceValidator(WithSequenceLogger(slog.New(slog.NewTextHandler(&buf, nil))))
+		require.NoError(t, v.Check(NewRunStartedEvent("t", "r")))
+		assert.Empty(t, buf.String())
+
+		require.Error(t, v.Check(NewTextMessageEndEvent("msg-1")))
+		assert.Contains(t, buf.String(), "level=WARN")
+		assert.Contains(t, buf.String(), LogKeyEventType+"=TEXT_MESSAGE_END")
+		assert.Contains(t, buf.String(), LogKeyError+"=")
+	})
+}
@@ -0,0 +1,138 @@
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/jsonpointer"
+)
+
+// ErrStateUnchanged is returned by NewStateDelta when the old and new states
+// are identical, so that callers can skip emitting an empty delta
+var ErrStateUnchanged = errors.New("state unchanged")
+
+// NewStateDelta creates a state delta event with the JSON Patch (RFC 6902)
+// operations that turn oldState into newState. Both states are marshalled to
+// JSON first, so structs, maps and json.RawMessage values may be mixed.
+// Objects are compared member by member; arrays element by element, with
+// elements added or removed at the end. When the states are equal it returns
+// ErrStateUnchanged. A delta cannot replace the whole state, so a change of
+// the root's kind, for example from an object to an array, or of a scalar
+// root is an error and calls for a state snapshot instead.
+func NewStateDelta(oldState, newState any) (*StateDeltaEvent, error) {
+	before, err := decodeState(oldState)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode old state: %w", err)
+	}
+	after, err := decodeState(newState)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode new state: %w", err)
+	}
+
+	var ops []JSONPatchOperation
+	diffJSON(nil, before, after, &ops)
+	if len(ops) == 0 {
+		return nil, ErrStateUnchanged
+	}
+	if ops[0].Path == "" {
+		return nil, fmt.Errorf("a state delta cannot replace the whole state, send a state snapshot instead")
+	}
+	return NewStateDeltaEvent(ops), nil
+}
+
+// decodeState converts a state value to its generic JSON form, keeping
+// numbers as json.Number so that patch values are written back unchanged
+func decodeState(state any) (any, error) {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// diffJSON appends the operations that turn before into after at path
+func diffJSON(path []string, before, after any, ops *[]JSONPatchOperation) {
+	if reflect.DeepEqual(before, after) {
+		return
+	}
+
+	switch b := before.(type) {
+	case map[string]any:
+		if a, ok := after.(map[string]any); ok {
+			diffObjects(path, b, a, ops)
+			return
+		}
+	case []any:
+		if a, ok := after.([]any); ok {
+			diffArrays(path, b, a, ops)
+			return
+		}
+	}
+
+	*ops = append(*ops, JSONPatchOperation{Op: "replace", Path: jsonpointer.Format(path), Value: patchValue(after)})
+}
+
+// diffObjects removes, updates and adds members in sorted key order
+func diffObjects(path []string, before, after map[string]any, ops *[]JSONPatchOperation) {
+	for _, key := range sortedKeys(before) {
+		child := append(path[:len(path):len(path)], key)
+		if value, ok := after[key]; ok {
+			diffJSON(child, before[key], value, ops)
+		} else {
+			*ops = append(*ops, JSONPatchOperation{Op: "remove", Path: jsonpointer.Format(child)})
+		}
+	}
+	for _, key := range sortedKeys(after) {
+		if _, ok := before[key]; !ok {
+			child := append(path[:len(path):len(path)], key)
+			*ops = append(*ops, JSONPatchOperation{Op: "add", Path: jsonpointer.Format(child), Value: patchValue(after[key])})
+		}
+	}
+}
+
+// diffArrays updates the common elements, then removes surplus elements from
+// the end or appends new ones
+func diffArrays(path []string, before, after []any, ops *[]JSONPatchOperation) {
+	common := len(before)
+	if len(after) < common {
+		common = len(after)
+	}
+	for i := 0; i < common; i++ {
+		diffJSON(append(path[:len(path):len(path)], fmt.Sprint(i)), before[i], after[i], ops)
+	}
+	for i := len(before) - 1; i >= common; i-- {
+		*ops = append(*ops, JSONPatchOperation{Op: "remove", Path: jsonpointer.Format(append(path[:len(path):len(path)], fmt.Sprint(i)))})
+	}
+	for i := common; i < len(after); i++ {
+		*ops = append(*ops, JSONPatchOperation{Op: "add", Path: jsonpointer.Format(append(path[:len(path):len(path)], "-")), Value: patchValue(after[i])})
+	}
+}
+
+// patchValue returns the operation value for a decoded JSON value. JSON null
+// is kept as a raw null, since a nil Value would be omitted from the operation.
+func patchValue(value any) any {
+	if value == nil {
+		return json.RawMessage("null")
+	}
+	return value
+}
+
+// sortedKeys returns the keys of an object in sorted order
+func sortedKeys(obj map[string]any) []string {
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,101 @@
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewStateDelta(t *testing.T) {
+	type todo struct {
+		Title string `json:"title"`
+		Done  bool   `json:"done"`
+	}
+	type state struct {
+		Todos []todo          `json:"todos"`
+		Meta  map[string]any  `json:"meta,omitempty"`
+		Big   int64           `json:"big"`
+		Raw   json.RawMessage `json:"raw,omitempty"`
+	}
+
+	tests := []struct {
+		name     string
+		old, new any
+	}{
+		{"ChangedField",
+			state{Todos: []todo{{Title: "a"}}},
+			state{Todos: []todo{{Title: "a", Done: true}}}},
+		{"AppendedAndRemovedMembers",
+			state{Meta: map[string]any{"x": 1, "a/b": "slash"}},
+			state{Meta: map[string]any{"y": 2, "a~b": "tilde"}}},
+		{"GrowingArray",
+			state{Todos: []todo{{Title: "a"}}},
+			state{Todos: []todo{{Title: "a"}, {Title: "b"}, {Title: "c"}}}},
+		{"ShrinkingArray",
+			state{Todos: []todo{{Title: "a"}, {Title: "b"}, {Title: "c"}}},
+			state{Todos: []todo{{Title: "b"}}}},
+		{"LargeIntegers",
+			state{Big: 1},
+			state{Big: 9007199254740993}},
+		{"TypeChange",
+			map[string]any{"v": []any{1}},
+			map[string]any{"v": map[string]any{"k": 1}}},
+		{"RawMessages",
+			json.RawMessage(`{"a":{"b":[1,2]}}`),
+			json.RawMessage(`{"a":{"b":[1,3]},"c":null}`)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delta, err := NewStateDelta(tt.old, tt.new)
+			require.NoError(t, err)
+			require.NoError(t, delta.Validate())
+
+			before, err := json.Marshal(tt.old)
+			require.NoError(t, err)
+			after, err := json.Marshal(tt.new)
+			require.NoError(t, err)
+
+			// Send the delta over the wire before applying it
+			data, err := delta.ToJSON()
+			require.NoError(t, err)
+			parsed, err := EventFromJSON(data)
+			require.NoError(t, err)
+
+			patched, err := ApplyStateDelta(before, *parsed.(*StateDeltaEvent))
+			require.NoError(t, err)
+			assert.JSONEq(t, string(after), string(patched))
+		})
+	}
+}
+
+func TestNewStateDelta_MinimalOperations(t *testing.T) {
+	delta, err := NewStateDelta(
+		map[string]any{"a": 1, "b": map[string]any{"c": "x", "d": true}},
+		map[string]any{"a": 1, "b": map[string]any{"c": "y", "d": true}, "e": []any{}},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []JSONPatchOperation{
+		{Op: "replace", Path: "/b/c", Value: "y"},
+		{Op: "add", Path: "/e", Value: []any{}},
+	}, delta.Delta)
+}
+
+func TestNewStateDelta_RootKindChange(t *testing.T) {
+	_, err := NewStateDelta([]int{1}, map[string]any{"a": 1})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "snapshot")
+}
+
+func TestNewStateDelta_Unchanged(t *testing.T) {
+	delta, err := NewStateDelta(map[string]any{"a": []int{1, 2}}, json.RawMessage(`{"a":[1,2]}`))
+	assert.Nil(t, delta)
+	assert.True(t, errors.Is(err, ErrStateUnchanged))
+
+	_, err = NewStateDelta(map[string]any{"bad": make(chan int)}, nil)
+	require.Error(t, err)
+	assert.False(t, errors.Is(err, ErrStateUnchanged))
+}
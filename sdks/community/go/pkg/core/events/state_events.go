@@ -40,6 +40,44 @@ func NewStateSnapshotEvent(snapshot any) *StateSnapshotEvent {
 	}
 }
 
+// NewStateSnapshot creates a state snapshot event from a typed state value,
+// such as a struct or map. The state is marshalled to JSON right away, so
+// later changes to it do not affect the event, and marshalling failures are
+// reported here rather than when the event is sent.
+func NewStateSnapshot(state any) (*StateSnapshotEvent, error) {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode state snapshot: %w", err)
+	}
+	if string(data) == "null" {
+		return nil, fmt.Errorf("state snapshot cannot be null")
+	}
+	return NewStateSnapshotEvent(json.RawMessage(data)), nil
+}
+
+// Into decodes the snapshot into v, which should be a pointer to the typed
+// state, for example a struct matching the agent's state shape
+func (e *StateSnapshotEvent) Into(v any) error {
+	var data []byte
+	switch snapshot := e.Snapshot.(type) {
+	case json.RawMessage:
+		data = snapshot
+	case []byte:
+		data = snapshot
+	default:
+		encoded, err := json.Marshal(snapshot)
+		if err != nil {
+			return fmt.Errorf("failed to encode state snapshot: %w", err)
+		}
+		data = encoded
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to decode state snapshot: %w", err)
+	}
+	return nil
+}
+
 // Validate validates the state snapshot event
 func (e *StateSnapshotEvent) Validate() error {
 	if err := e.BaseEvent.Validate(); err != nil {
@@ -66,10 +104,25 @@ type JSONPatchOperation struct {
 	From  string `json:"from,omitempty"`  // Source path for move, copy operations
 }
 
-// StateDeltaEvent contains incremental state changes using JSON Patch
+// StatePatchFormat names the patch style of a STATE_DELTA event
+type StatePatchFormat string
+
+const (
+	// StatePatchJSONPatch is a list of JSON Patch (RFC 6902) operations,
+	// carried in the delta field
+	StatePatchJSONPatch StatePatchFormat = "json-patch"
+	// StatePatchMergePatch is a JSON Merge Patch (RFC 7386) document,
+	// carried in the mergePatch field
+	StatePatchMergePatch StatePatchFormat = "merge-patch"
+)
+
+// StateDeltaEvent contains incremental state changes, either as JSON Patch
+// operations in Delta or as a JSON Merge Patch in MergePatch. Exactly one of
+// the two is set; PatchFormat reports which.
 type StateDeltaEvent struct {
 	*BaseEvent
-	Delta []JSONPatchOperation `json:"delta"`
+	Delta      []JSONPatchOperation `json:"delta,omitempty"`
+	MergePatch json.RawMessage      `json:"mergePatch,omitempty"`
 }
 
 // NewStateDeltaEvent creates a new state delta event
@@ -80,12 +133,39 @@ func NewStateDeltaEvent(delta []JSONPatchOperation) *StateDeltaEvent {
 	}
 }
 
+// NewStateMergePatchEvent creates a state delta event carrying a JSON Merge
+// Patch, for agents that describe updates as the changed parts of the state
+func NewStateMergePatchEvent(patch json.RawMessage) *StateDeltaEvent {
+	return &StateDeltaEvent{
+		BaseEvent:  NewBaseEvent(EventTypeStateDelta),
+		MergePatch: patch,
+	}
+}
+
+// PatchFormat reports how the event describes the state change
+func (e *StateDeltaEvent) PatchFormat() StatePatchFormat {
+	if len(e.MergePatch) > 0 {
+		return StatePatchMergePatch
+	}
+	return StatePatchJSONPatch
+}
+
 // Validate validates the state delta event
 func (e *StateDeltaEvent) Validate() error {
 	if err := e.BaseEvent.Validate(); err != nil {
 		return err
 	}
 
+	if len(e.MergePatch) > 0 {
+		if len(e.Delta) > 0 {
+			return fmt.Errorf("StateDeltaEvent validation failed: delta and mergePatch fields are mutually exclusive")
+		}
+		if !json.Valid(e.MergePatch) {
+			return fmt.Errorf("StateDeltaEvent validation failed: mergePatch field must be valid JSON")
+		}
+		return nil
+	}
+
 	if len(e.Delta) == 0 {
 		return fmt.Errorf("StateDeltaEvent validation failed: delta field must contain at least one operation")
 	}
@@ -130,10 +210,17 @@ func (e *StateDeltaEvent) ToJSON() ([]byte, error) {
 	return json.Marshal(e)
 }
 
-// MessagesSnapshotEvent contains a snapshot of all messages
+// MessagesSnapshotEvent contains a snapshot of all messages. For long
+// conversations an agent may send only the most recent messages as a partial
+// snapshot; Cursor then identifies the page of messages that precede them,
+// which a client can request to backfill the history.
 type MessagesSnapshotEvent struct {
 	*BaseEvent
 	Messages []Message `json:"messages"`
+	// Cursor identifies the page before this one. It is set only on partial snapshots.
+	Cursor string `json:"cursor,omitempty"`
+	// Partial reports that earlier messages exist that are not in the snapshot.
+	Partial bool `json:"partial,omitempty"`
 }
 
 // NewMessagesSnapshotEvent creates a new messages snapshot event
@@ -150,89 +237,212 @@ func (e *MessagesSnapshotEvent) Validate() error {
 		return err
 	}
 
-	// Validate each message
+	// Validate each message and reject duplicate IDs, which would make the
+	// replaced conversation ambiguous
+	seen := make(map[string]int, len(e.Messages))
 	for i, msg := range e.Messages {
 		if err := validateMessage(msg); err != nil {
 			return fmt.Errorf("invalid message at index %d: %w", i, err)
 		}
+		if first, ok := seen[msg.ID]; ok {
+			return fmt.Errorf("invalid message at index %d: duplicate message id %s (first seen at index %d)", i, msg.ID, first)
+		}
+		seen[msg.ID] = i
+	}
+
+	if e.Partial && e.Cursor == "" {
+		return fmt.Errorf("MessagesSnapshotEvent validation failed: cursor field is required for partial snapshots")
+	}
+	if !e.Partial && e.Cursor != "" {
+		return fmt.Errorf("MessagesSnapshotEvent validation failed: cursor is only valid for partial snapshots")
 	}
 
 	return nil
 }
 
-// validateMessage validates a single message
-func validateMessage(msg Message) error {
+// NewMessagesSnapshotPage creates a partial messages snapshot holding one page
+// of a longer conversation. cursor identifies the page before it.
+func NewMessagesSnapshotPage(messages []Message, cursor string) *MessagesSnapshotEvent {
+	event := NewMessagesSnapshotEvent(messages)
+	event.Cursor = cursor
+	event.Partial = true
+	return event
+}
+
+// MergeSnapshotPages stitches the pages of a paginated messages snapshot into
+// one conversation. Pages are given oldest first, so a client backfilling a
+// conversation passes the earlier pages it fetched before the snapshot it
+// started from. Only the first page may be complete; every later one must be
+// partial. Messages that appear on more than one page, as happens when pages
+// overlap at their boundaries, are kept once, using the last occurrence as
+// Dedup does.
+func MergeSnapshotPages(pages ...MessagesSnapshotEvent) ([]Message, error) {
+	var messages []Message
+	for i, page := range pages {
+		if i > 0 && !page.Partial {
+			return nil, fmt.Errorf("page %d is a complete snapshot and must come first", i)
+		}
+		for j, msg := range page.Messages {
+			if err := validateMessage(msg); err != nil {
+				return nil, fmt.Errorf("invalid message at index %d of page %d: %w", j, i, err)
+			}
+		}
+		messages = append(messages, page.Messages...)
+	}
+
+	return dedupMessages(messages), nil
+}
+
+// ToMessages returns the snapshot's messages after validating every one of
+// them. When any message is invalid it returns nil and a MessageErrors
+// describing all problems, as ValidateMessages does. The Messages field
+// remains available for callers that need the unvalidated slice.
+func (e *MessagesSnapshotEvent) ToMessages() ([]Message, error) {
+	if err := ValidateMessages(e.Messages); err != nil {
+		return nil, err
+	}
+	return e.Messages, nil
+}
+
+// Dedup returns a copy of the snapshot that keeps only the last occurrence of
+// each message ID. Surviving messages stay in their original relative order.
+func (e *MessagesSnapshotEvent) Dedup() *MessagesSnapshotEvent {
+	deduped := &MessagesSnapshotEvent{Messages: dedupMessages(e.Messages), Cursor: e.Cursor, Partial: e.Partial}
+	if e.BaseEvent != nil {
+		base := *e.BaseEvent
+		deduped.BaseEvent = &base
+	} else {
+		deduped.BaseEvent = NewBaseEvent(EventTypeMessagesSnapshot)
+	}
+	return deduped
+}
+
+// dedupMessages keeps only the last occurrence of each message ID
+func dedupMessages(msgs []Message) []Message {
+	last := make(map[string]int, len(msgs))
+	for i, msg := range msgs {
+		last[msg.ID] = i
+	}
+
+	messages := make([]Message, 0, len(last))
+	for i, msg := range msgs {
+		if last[msg.ID] == i {
+			messages = append(messages, msg)
+		}
+	}
+	return messages
+}
+
+// validateBuiltinMessage validates a single message against the rules for the
+// AG-UI roles. Failures are reported as *MessageValidationError.
+func validateBuiltinMessage(msg Message) error {
+	invalid := func(field, format string, args ...any) error {
+		return &MessageValidationError{Field: field, Role: msg.Role, Reason: fmt.Sprintf(format, args...)}
+	}
+
 	if msg.ID == "" {
-		return fmt.Errorf("message id field is required")
+		return invalid("id", "message id field is required")
 	}
 
 	if msg.Role == "" {
-		return fmt.Errorf("message role field is required")
+		return invalid("role", "message role field is required")
 	}
 
 	if msg.ActivityType != "" && msg.Role != coretypes.RoleActivity {
-		return fmt.Errorf("activityType is only valid for activity messages")
+		return invalid("activityType", "activityType is only valid for activity messages")
 	}
 
 	switch msg.Role {
 	case coretypes.RoleDeveloper, coretypes.RoleSystem:
 		if _, ok := msg.ContentString(); !ok {
-			return fmt.Errorf("content field must be a string for %s messages", msg.Role)
+			return invalid("content", "content field must be a string for %s messages", msg.Role)
 		}
 	case coretypes.RoleAssistant:
+		if parts, ok := msg.ContentAssistantContents(); ok {
+			for i, part := range parts {
+				if err := part.Validate(); err != nil {
+					return &MessageValidationError{Field: fmt.Sprintf("content[%d]", i), Role: msg.Role, Reason: err.Error(), Err: err}
+				}
+			}
+			break
+		}
 		if msg.Content != nil {
 			if _, ok := msg.ContentString(); !ok {
-				return fmt.Errorf("content field must be a string for assistant messages")
+				return invalid("content", "content field must be a string or content part array for assistant messages")
 			}
 		}
 	case coretypes.RoleReasoning:
 		if _, ok := msg.ContentString(); !ok {
-			return fmt.Errorf("content field must be a string for reasoning messages")
+			return invalid("content", "content field must be a string for reasoning messages")
 		}
 	case coretypes.RoleUser:
 		if _, ok := msg.ContentString(); ok {
 			break
 		}
-		if _, ok := msg.ContentInputContents(); ok {
+		if parts, ok := msg.ContentInputContents(); ok {
+			for i, part := range parts {
+				if err := part.ValidateAudio(); err != nil {
+					return &MessageValidationError{Field: fmt.Sprintf("content[%d]", i), Role: msg.Role, Reason: err.Error(), Err: err}
+				}
+			}
 			break
 		}
-		return fmt.Errorf("content field must be a string or input content array for user messages")
+		return invalid("content", "content field must be a string or input content array for user messages")
 	case coretypes.RoleTool:
 		if _, ok := msg.ContentString(); !ok {
-			return fmt.Errorf("content field must be a string for tool messages")
+			return invalid("content", "content field must be a string for tool messages")
 		}
 		if msg.ToolCallID == "" {
-			return fmt.Errorf("toolCallId field is required for tool messages")
+			return invalid("toolCallId", "toolCallId field is required for tool messages")
 		}
 	case coretypes.RoleActivity:
 		if msg.ActivityType == "" {
-			return fmt.Errorf("activityType field is required for activity messages")
+			return invalid("activityType", "activityType field is required for activity messages")
+		}
+		content, ok := msg.ContentActivity()
+		if !ok {
+			return invalid("content", "content field must be a map for activity messages")
 		}
-		if _, ok := msg.ContentActivity(); !ok {
-			return fmt.Errorf("content field must be a map for activity messages")
+		if err := validateActivityContent(msg.ActivityType, content); err != nil {
+			return &MessageValidationError{Field: "content", Role: msg.Role, Reason: err.Error(), Err: err}
 		}
 	default:
-		return fmt.Errorf("unsupported message role: %s", msg.Role)
+		return invalid("role", "unsupported message role: %s", msg.Role)
 	}
 
 	if msg.Role != coretypes.RoleAssistant && len(msg.ToolCalls) > 0 {
-		return fmt.Errorf("toolCalls are only valid for assistant messages")
+		return invalid("toolCalls", "toolCalls are only valid for assistant messages")
+	}
+
+	if msg.Role != coretypes.RoleAssistant && msg.Reasoning != "" {
+		return invalid("reasoning", "reasoning is only valid for assistant messages")
 	}
 
 	if msg.Role != coretypes.RoleTool {
 		if msg.ToolCallID != "" {
-			return fmt.Errorf("toolCallId is only valid for tool messages")
+			return invalid("toolCallId", "toolCallId is only valid for tool messages")
 		}
 		if msg.Error != "" {
-			return fmt.Errorf("error is only valid for tool messages")
+			return invalid("error", "error is only valid for tool messages")
 		}
 	}
 
-	// Validate tool calls if present
+	// Validate tool calls if present. Tool results are matched to their call
+	// by ID, so the IDs must be unique within the message.
+	seenToolCalls := make(map[string]int, len(msg.ToolCalls))
 	for i, toolCall := range msg.ToolCalls {
 		if err := validateToolCall(toolCall); err != nil {
-			return fmt.Errorf("invalid tool call at index %d: %w", i, err)
+			return &MessageValidationError{
+				Field:  fmt.Sprintf("toolCalls[%d]", i),
+				Role:   msg.Role,
+				Reason: fmt.Sprintf("invalid tool call at index %d: %v", i, err),
+				Err:    err,
+			}
+		}
+		if first, ok := seenToolCalls[toolCall.ID]; ok {
+			return invalid(fmt.Sprintf("toolCalls[%d].id", i), "duplicate tool call id %s (also used at index %d)", toolCall.ID, first)
 		}
+		seenToolCalls[toolCall.ID] = i
 	}
 
 	return nil
@@ -252,6 +462,10 @@ func validateToolCall(toolCall ToolCall) error {
 		return fmt.Errorf("function name field is required")
 	}
 
+	if toolCall.Function.Arguments != "" && !json.Valid([]byte(toolCall.Function.Arguments)) {
+		return fmt.Errorf("tool call %s function arguments must be valid JSON", toolCall.ID)
+	}
+
 	return nil
 }
 
@@ -226,3 +226,134 @@ func TestMessageMarshalJSON_IncludesOptionalFields_Tool(t *testing.T) {
 	assert.Equal(t, "tool-123", decoded["toolCallId"])
 	assert.Equal(t, "boom", decoded["error"])
 }
+
+func TestValidateMessage_ToolCallArgumentsMustBeJSON(t *testing.T) {
+	msg := Message{
+		ID:   "msg-1",
+		Role: "assistant",
+		ToolCalls: []ToolCall{{
+			ID:       "call-1",
+			Type:     "function",
+			Function: Function{Name: "search", Arguments: `{"query":"weather"}`},
+		}},
+	}
+	assert.NoError(t, validateMessage(msg))
+
+	msg.ToolCalls[0].Function.Arguments = ""
+	assert.NoError(t, validateMessage(msg))
+
+	msg.ToolCalls[0].Function.Arguments = `{"query":"wea`
+	err := validateMessage(msg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "call-1")
+}
+
+func TestMessagesSnapshotEvent_DuplicateIDs(t *testing.T) {
+	event := NewMessagesSnapshotEvent([]Message{
+		{ID: "msg-1", Role: coretypes.RoleUser, Content: "hi"},
+		{ID: "msg-2", Role: coretypes.RoleAssistant, Content: "draft"},
+		{ID: "msg-3", Role: coretypes.RoleUser, Content: "more"},
+		{ID: "msg-2", Role: coretypes.RoleAssistant, Content: "final"},
+	})
+
+	err := event.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "duplicate message id msg-2")
+
+	deduped := event.Dedup()
+	require.NoError(t, deduped.Validate())
+	require.Len(t, deduped.Messages, 3)
+	assert.Equal(t, "msg-1", deduped.Messages[0].ID)
+	assert.Equal(t, "msg-3", deduped.Messages[1].ID)
+	assert.Equal(t, "msg-2", deduped.Messages[2].ID)
+	assert.Equal(t, "final", deduped.Messages[2].Content)
+	assert.Equal(t, event.Timestamp(), deduped.Timestamp())
+
+	// The original snapshot is left untouched
+	assert.Len(t, event.Messages, 4)
+}
+
+func TestMergeSnapshotPages(t *testing.T) {
+	oldest := NewMessagesSnapshotEvent([]Message{
+		{ID: "msg-1", Role: coretypes.RoleUser, Content: "hi"},
+		{ID: "msg-2", Role: coretypes.RoleAssistant, Content: "hello"},
+	})
+	middle := NewMessagesSnapshotPage([]Message{
+		{ID: "msg-2", Role: coretypes.RoleAssistant, Content: "hello"},
+		{ID: "msg-3", Role: coretypes.RoleUser, Content: "more"},
+	}, "page-1")
+	latest := NewMessagesSnapshotPage([]Message{
+		{ID: "msg-4", Role: coretypes.RoleAssistant, Content: "done"},
+	}, "page-2")
+	require.NoError(t, middle.Validate())
+
+	data, err := latest.ToJSON()
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"cursor":"page-2","partial":true`)
+	require.NoError(t, ValidateEventJSON(data, StrictSchema()))
+
+	messages, err := MergeSnapshotPages(*oldest, *middle, *latest)
+	require.NoError(t, err)
+	var ids []string
+	for _, msg := range messages {
+		ids = append(ids, msg.ID)
+	}
+	assert.Equal(t, []string{"msg-1", "msg-2", "msg-3", "msg-4"}, ids)
+
+	messages, err = MergeSnapshotPages()
+	require.NoError(t, err)
+	assert.Empty(t, messages)
+
+	_, err = MergeSnapshotPages(*middle, *oldest)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "page 1 is a complete snapshot")
+
+	bad := NewMessagesSnapshotPage([]Message{{ID: "msg-5", Role: coretypes.RoleUser}}, "page-3")
+	_, err = MergeSnapshotPages(*oldest, *bad)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "index 0 of page 1")
+
+	noCursor := NewMessagesSnapshotEvent(nil)
+	noCursor.Partial = true
+	assert.Error(t, noCursor.Validate())
+	stray := NewMessagesSnapshotEvent(nil)
+	stray.Cursor = "page-1"
+	assert.Error(t, stray.Validate())
+}
+
+func TestNewStateSnapshot(t *testing.T) {
+	type agentState struct {
+		Step  int      `json:"step"`
+		Notes []string `json:"notes"`
+	}
+
+	state := agentState{Step: 2, Notes: []string{"a"}}
+	event, err := NewStateSnapshot(state)
+	require.NoError(t, err)
+	assert.Equal(t, EventTypeStateSnapshot, event.Type())
+	require.NoError(t, event.Validate())
+
+	// Later changes to the state do not leak into the event
+	state.Notes[0] = "changed"
+
+	var decoded agentState
+	require.NoError(t, event.Into(&decoded))
+	assert.Equal(t, agentState{Step: 2, Notes: []string{"a"}}, decoded)
+
+	// Snapshots decoded from the wire hold generic values
+	data, err := event.ToJSON()
+	require.NoError(t, err)
+	parsed, err := EventFromJSON(data)
+	require.NoError(t, err)
+	decoded = agentState{}
+	require.NoError(t, parsed.(*StateSnapshotEvent).Into(&decoded))
+	assert.Equal(t, 2, decoded.Step)
+
+	_, err = NewStateSnapshot(nil)
+	assert.Error(t, err)
+	_, err = NewStateSnapshot(map[string]any{"bad": make(chan int)})
+	assert.Error(t, err)
+
+	var wrongShape []string
+	assert.Error(t, event.Into(&wrongShape))
+}
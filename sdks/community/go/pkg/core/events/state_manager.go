@@ -0,0 +1,103 @@
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/jsonpointer"
+)
+
+// ErrNoSnapshot is returned when a state delta arrives before any state snapshot.
+// Clients should request a fresh snapshot when they see this error.
+var ErrNoSnapshot = errors.New("state delta received before any state snapshot")
+
+// StateManager materializes agent state from STATE_SNAPSHOT and STATE_DELTA
+// events. It is safe for concurrent use: events may be handled on one goroutine
+// while the state is read from others.
+type StateManager struct {
+	mu          sync.RWMutex
+	state       json.RawMessage
+	hasSnapshot bool
+}
+
+// NewStateManager creates a new state manager with no state
+func NewStateManager() *StateManager {
+	return &StateManager{}
+}
+
+// Handle applies a state event to the current state. Snapshots replace the
+// state; deltas are applied as JSON Patch operations or as a JSON Merge Patch,
+// according to their PatchFormat. Other events are ignored.
+// If a delta cannot be applied, the previous state is kept and an error is returned.
+func (m *StateManager) Handle(event Event) error {
+	switch e := event.(type) {
+	case *StateSnapshotEvent:
+		state, err := json.Marshal(e.Snapshot)
+		if err != nil {
+			return fmt.Errorf("failed to encode state snapshot: %w", err)
+		}
+		m.mu.Lock()
+		m.state = state
+		m.hasSnapshot = true
+		m.mu.Unlock()
+
+	case *StateDeltaEvent:
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if !m.hasSnapshot {
+			return ErrNoSnapshot
+		}
+		state, err := ApplyStateDelta(m.state, *e)
+		if err != nil {
+			return err
+		}
+		m.state = state
+	}
+
+	return nil
+}
+
+// HasSnapshot reports whether a state snapshot has been received
+func (m *StateManager) HasSnapshot() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.hasSnapshot
+}
+
+// Current returns a copy of the current state, or nil if no snapshot has been received
+func (m *StateManager) Current() json.RawMessage {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.state == nil {
+		return nil
+	}
+	return append(json.RawMessage(nil), m.state...)
+}
+
+// Get returns the decoded value at the given JSON Pointer path in the current state
+func (m *StateManager) Get(path string) (any, error) {
+	m.mu.RLock()
+	state := m.state
+	hasSnapshot := m.hasSnapshot
+	m.mu.RUnlock()
+
+	if !hasSnapshot {
+		return nil, ErrNoSnapshot
+	}
+
+	var doc any
+	if err := json.Unmarshal(state, &doc); err != nil {
+		return nil, fmt.Errorf("failed to decode state: %w", err)
+	}
+	return jsonpointer.Get(doc, path)
+}
+
+// Reset discards the current state
+func (m *StateManager) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.state = nil
+	m.hasSnapshot = false
+}
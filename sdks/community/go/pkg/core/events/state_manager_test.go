@@ -0,0 +1,96 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateManager(t *testing.T) {
+	t.Run("SnapshotThenDeltas", func(t *testing.T) {
+		m := NewStateManager()
+		assert.Nil(t, m.Current())
+
+		require.NoError(t, m.Handle(NewStateSnapshotEvent(map[string]any{"counter": 1, "items": []string{}})))
+		require.NoError(t, m.Handle(NewStateDeltaEvent([]JSONPatchOperation{
+			{Op: "replace", Path: "/counter", Value: 2},
+			{Op: "add", Path: "/items/-", Value: "first"},
+		})))
+		require.NoError(t, m.Handle(NewTextMessageStartEvent("ignored")))
+
+		assert.JSONEq(t, `{"counter":2,"items":["first"]}`, string(m.Current()))
+
+		value, err := m.Get("/items/0")
+		require.NoError(t, err)
+		assert.Equal(t, "first", value)
+
+		// A new snapshot replaces the state entirely
+		require.NoError(t, m.Handle(NewStateSnapshotEvent(map[string]any{"fresh": true})))
+		assert.JSONEq(t, `{"fresh":true}`, string(m.Current()))
+	})
+
+	t.Run("MergePatchDelta", func(t *testing.T) {
+		m := NewStateManager()
+		require.NoError(t, m.Handle(NewStateSnapshotEvent(map[string]any{"counter": 1, "draft": "x"})))
+		require.NoError(t, m.Handle(NewStateMergePatchEvent(json.RawMessage(`{"counter":2,"draft":null}`))))
+		assert.JSONEq(t, `{"counter":2}`, string(m.Current()))
+	})
+
+	t.Run("DeltaWithoutSnapshot", func(t *testing.T) {
+		m := NewStateManager()
+		err := m.Handle(NewStateDeltaEvent([]JSONPatchOperation{{Op: "add", Path: "/a", Value: 1}}))
+		assert.ErrorIs(t, err, ErrNoSnapshot)
+
+		_, err = m.Get("/a")
+		assert.ErrorIs(t, err, ErrNoSnapshot)
+	})
+
+	t.Run("FailedDeltaKeepsState", func(t *testing.T) {
+		m := NewStateManager()
+		require.NoError(t, m.Handle(NewStateSnapshotEvent(map[string]any{"a": 1})))
+		err := m.Handle(NewStateDeltaEvent([]JSONPatchOperation{
+			{Op: "replace", Path: "/a", Value: 2},
+			{Op: "remove", Path: "/missing"},
+		}))
+		require.Error(t, err)
+		assert.JSONEq(t, `{"a":1}`, string(m.Current()))
+
+		m.Reset()
+		assert.False(t, m.HasSnapshot())
+	})
+
+	t.Run("ConcurrentAccess", func(t *testing.T) {
+		m := NewStateManager()
+		require.NoError(t, m.Handle(NewStateSnapshotEvent(map[string]any{"log": []string{}})))
+
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(2)
+			go func(i int) {
+				defer wg.Done()
+				for j := 0; j < 25; j++ {
+					assert.NoError(t, m.Handle(NewStateDeltaEvent([]JSONPatchOperation{
+						{Op: "add", Path: "/log/-", Value: fmt.Sprintf("%d-%d", i, j)},
+					})))
+				}
+			}(i)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 25; j++ {
+					_, err := m.Get("/log")
+					assert.NoError(t, err)
+					_ = m.Current()
+				}
+			}()
+		}
+		wg.Wait()
+
+		log, err := m.Get("/log")
+		require.NoError(t, err)
+		assert.Len(t, log, 100)
+	})
+}
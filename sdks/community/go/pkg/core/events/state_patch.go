@@ -0,0 +1,365 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/jsonpointer"
+)
+
+// ApplyStateDelta applies a state delta event to a prior state snapshot and
+// returns the resulting state, using JSON Patch (RFC 6902) or JSON Merge Patch
+// (RFC 7386) according to the event's PatchFormat. The input state is not
+// modified. An empty state is treated as JSON null.
+func ApplyStateDelta(state json.RawMessage, delta StateDeltaEvent) (json.RawMessage, error) {
+	if delta.PatchFormat() == StatePatchMergePatch {
+		return ApplyStateMergePatch(state, delta.MergePatch)
+	}
+	return ApplyJSONPatch(state, delta.Delta)
+}
+
+// ApplyStateMergePatch applies a JSON Merge Patch (RFC 7386) to a state
+// snapshot and returns the resulting state; see ApplyMergePatch
+func ApplyStateMergePatch(state, patch json.RawMessage) (json.RawMessage, error) {
+	result, err := ApplyMergePatch(state, patch)
+	if err != nil {
+		return nil, fmt.Errorf("state merge patch failed: %w", err)
+	}
+	return result, nil
+}
+
+// ApplyJSONPatch applies a sequence of JSON Patch operations to a JSON document.
+// Operations are applied in order and the patch is atomic: if any operation
+// fails, an error is returned and no partial result is produced.
+func ApplyJSONPatch(doc json.RawMessage, ops []JSONPatchOperation) (json.RawMessage, error) {
+	var root any
+	if len(doc) > 0 {
+		if err := json.Unmarshal(doc, &root); err != nil {
+			return nil, fmt.Errorf("failed to decode state: %w", err)
+		}
+	}
+
+	for i, op := range ops {
+		var err error
+		root, err = applyPatchOperation(root, op)
+		if err != nil {
+			return nil, fmt.Errorf("JSON patch operation %d (%s %s) failed: %w", i, op.Op, op.Path, err)
+		}
+	}
+
+	result, err := json.Marshal(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode state: %w", err)
+	}
+	return result, nil
+}
+
+// ApplyMergePatch applies a JSON Merge Patch (RFC 7386) to a JSON document and
+// returns the result. Object members in the patch are merged recursively, null
+// members remove the corresponding key, and any other patch value replaces the
+// target. The input document is not modified; an empty document is treated as
+// JSON null.
+func ApplyMergePatch(doc, patch json.RawMessage) (json.RawMessage, error) {
+	var root any
+	if len(doc) > 0 {
+		if err := json.Unmarshal(doc, &root); err != nil {
+			return nil, fmt.Errorf("failed to decode document: %w", err)
+		}
+	}
+
+	var patchValue any
+	if err := json.Unmarshal(patch, &patchValue); err != nil {
+		return nil, fmt.Errorf("failed to decode merge patch: %w", err)
+	}
+
+	result, err := json.Marshal(mergePatch(root, patchValue))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode document: %w", err)
+	}
+	return result, nil
+}
+
+// mergePatch implements the MergePatch algorithm of RFC 7386 on decoded values
+func mergePatch(target, patch any) any {
+	patchObject, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]any)
+	if !ok {
+		targetObject = make(map[string]any, len(patchObject))
+	}
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+			continue
+		}
+		targetObject[key] = mergePatch(targetObject[key], value)
+	}
+	return targetObject
+}
+
+// applyPatchOperation applies a single operation to the decoded document and returns the new root
+func applyPatchOperation(root any, op JSONPatchOperation) (any, error) {
+	path, err := jsonpointer.Parse(op.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.Op {
+	case "add":
+		value, err := normalizeJSONValue(op.Value)
+		if err != nil {
+			return nil, err
+		}
+		return patchAdd(root, path, value)
+
+	case "remove":
+		root, _, err = patchRemove(root, path)
+		return root, err
+
+	case "replace":
+		value, err := normalizeJSONValue(op.Value)
+		if err != nil {
+			return nil, err
+		}
+		if len(path) == 0 {
+			return value, nil
+		}
+		return patchReplace(root, path, value)
+
+	case "move":
+		from, err := jsonpointer.Parse(op.From)
+		if err != nil {
+			return nil, err
+		}
+		if isProperPrefix(from, path) {
+			return nil, fmt.Errorf("cannot move %s into one of its children", op.From)
+		}
+		root, value, err := patchRemove(root, from)
+		if err != nil {
+			return nil, fmt.Errorf("from: %w", err)
+		}
+		return patchAdd(root, path, value)
+
+	case "copy":
+		from, err := jsonpointer.Parse(op.From)
+		if err != nil {
+			return nil, err
+		}
+		value, err := jsonpointer.GetTokens(root, from)
+		if err != nil {
+			return nil, fmt.Errorf("from: %w: %s", err, op.From)
+		}
+		// Deep copy so later operations on either location do not alias
+		value, err = normalizeJSONValue(value)
+		if err != nil {
+			return nil, err
+		}
+		return patchAdd(root, path, value)
+
+	case "test":
+		expected, err := normalizeJSONValue(op.Value)
+		if err != nil {
+			return nil, err
+		}
+		actual, err := jsonpointer.GetTokens(root, path)
+		if err != nil {
+			return nil, fmt.Errorf("test failed: %w: %s", err, op.Path)
+		}
+		if !reflect.DeepEqual(actual, expected) {
+			return nil, fmt.Errorf("test failed: value at %s is %s, expected %s", op.Path, describeJSON(actual), describeJSON(expected))
+		}
+		return root, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported operation %q", op.Op)
+	}
+}
+
+// patchAdd inserts value at path, appending to arrays for the "-" token
+func patchAdd(root any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	return updateParent(root, path, func(parent any, key string) (any, error) {
+		switch node := parent.(type) {
+		case map[string]any:
+			node[key] = value
+			return node, nil
+		case []any:
+			if key == "-" {
+				return append(node, value), nil
+			}
+			idx, err := jsonpointer.ArrayIndex(key, len(node))
+			if err != nil {
+				return nil, err
+			}
+			node = append(node, nil)
+			copy(node[idx+1:], node[idx:])
+			node[idx] = value
+			return node, nil
+		default:
+			return nil, fmt.Errorf("cannot add member %q to %s", key, describeJSONKind(parent))
+		}
+	})
+}
+
+// patchRemove removes the value at path and returns the new root along with the removed value
+func patchRemove(root any, path []string) (any, any, error) {
+	if len(path) == 0 {
+		return nil, nil, fmt.Errorf("cannot remove the document root")
+	}
+	var removed any
+	root, err := updateParent(root, path, func(parent any, key string) (any, error) {
+		switch node := parent.(type) {
+		case map[string]any:
+			value, ok := node[key]
+			if !ok {
+				return nil, fmt.Errorf("%w: member %q does not exist", jsonpointer.ErrNotFound, key)
+			}
+			removed = value
+			delete(node, key)
+			return node, nil
+		case []any:
+			idx, err := existingArrayIndex(key, node)
+			if err != nil {
+				return nil, err
+			}
+			removed = node[idx]
+			return append(node[:idx], node[idx+1:]...), nil
+		default:
+			return nil, fmt.Errorf("cannot remove member %q from %s", key, describeJSONKind(parent))
+		}
+	})
+	return root, removed, err
+}
+
+// patchReplace replaces an existing value at path
+func patchReplace(root any, path []string, value any) (any, error) {
+	return updateParent(root, path, func(parent any, key string) (any, error) {
+		switch node := parent.(type) {
+		case map[string]any:
+			if _, ok := node[key]; !ok {
+				return nil, fmt.Errorf("%w: member %q does not exist", jsonpointer.ErrNotFound, key)
+			}
+			node[key] = value
+			return node, nil
+		case []any:
+			idx, err := existingArrayIndex(key, node)
+			if err != nil {
+				return nil, err
+			}
+			node[idx] = value
+			return node, nil
+		default:
+			return nil, fmt.Errorf("cannot replace member %q of %s", key, describeJSONKind(parent))
+		}
+	})
+}
+
+// updateParent walks to the container holding the last token of path and
+// replaces it with the result of fn, rebuilding the chain of containers so
+// that slice reallocations are reflected in the returned root.
+func updateParent(node any, path []string, fn func(parent any, key string) (any, error)) (any, error) {
+	if len(path) == 1 {
+		return fn(node, path[0])
+	}
+
+	switch n := node.(type) {
+	case map[string]any:
+		child, ok := n[path[0]]
+		if !ok {
+			return nil, fmt.Errorf("%w: member %q does not exist", jsonpointer.ErrNotFound, path[0])
+		}
+		updated, err := updateParent(child, path[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		n[path[0]] = updated
+		return n, nil
+	case []any:
+		idx, err := existingArrayIndex(path[0], n)
+		if err != nil {
+			return nil, err
+		}
+		updated, err := updateParent(n[idx], path[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		n[idx] = updated
+		return n, nil
+	default:
+		return nil, fmt.Errorf("%w: cannot traverse into %s", jsonpointer.ErrNotFound, describeJSONKind(node))
+	}
+}
+
+// existingArrayIndex parses an array index that must refer to an existing element
+func existingArrayIndex(token string, arr []any) (int, error) {
+	idx, err := jsonpointer.ArrayIndex(token, len(arr))
+	if err != nil {
+		return 0, err
+	}
+	if idx >= len(arr) {
+		return 0, fmt.Errorf("%w: array index %d out of bounds", jsonpointer.ErrNotFound, idx)
+	}
+	return idx, nil
+}
+
+// isProperPrefix reports whether prefix is a proper prefix of path
+func isProperPrefix(prefix, path []string) bool {
+	if len(prefix) >= len(path) {
+		return false
+	}
+	for i := range prefix {
+		if prefix[i] != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeJSONValue round-trips a value through JSON so that it has the same
+// representation as decoded state (float64 numbers, map[string]any objects).
+func normalizeJSONValue(value any) (any, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid patch value: %w", err)
+	}
+	var normalized any
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return nil, fmt.Errorf("invalid patch value: %w", err)
+	}
+	return normalized, nil
+}
+
+// describeJSON renders a decoded value for error messages
+func describeJSON(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(data)
+}
+
+// describeJSONKind names the JSON kind of a decoded value for error messages
+func describeJSONKind(value any) string {
+	switch value.(type) {
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	case nil:
+		return "null"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
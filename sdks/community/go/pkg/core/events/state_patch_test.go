@@ -0,0 +1,138 @@
+package events
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/jsonpointer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyStateDelta(t *testing.T) {
+	state := json.RawMessage(`{"user":{"name":"Ada"},"items":["a","b"],"count":1}`)
+
+	t.Run("AllOperations", func(t *testing.T) {
+		delta := NewStateDeltaEvent([]JSONPatchOperation{
+			{Op: "test", Path: "/count", Value: 1},
+			{Op: "add", Path: "/items/1", Value: "x"},
+			{Op: "add", Path: "/items/-", Value: "z"},
+			{Op: "remove", Path: "/items/0"},
+			{Op: "replace", Path: "/count", Value: 2},
+			{Op: "copy", From: "/user", Path: "/owner"},
+			{Op: "move", From: "/user/name", Path: "/owner/fullName"},
+			{Op: "add", Path: "/user/tags", Value: []string{"admin"}},
+		})
+
+		result, err := ApplyStateDelta(state, *delta)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"user":{"tags":["admin"]},
+			"owner":{"name":"Ada","fullName":"Ada"},
+			"items":["x","b","z"],
+			"count":2
+		}`, string(result))
+
+		// The input snapshot is left untouched
+		assert.JSONEq(t, `{"user":{"name":"Ada"},"items":["a","b"],"count":1}`, string(state))
+	})
+
+	t.Run("TestOperationMismatch", func(t *testing.T) {
+		delta := NewStateDeltaEvent([]JSONPatchOperation{
+			{Op: "replace", Path: "/count", Value: 5},
+			{Op: "test", Path: "/user/name", Value: "Grace"},
+		})
+
+		_, err := ApplyStateDelta(state, *delta)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "test failed")
+		assert.Contains(t, err.Error(), `"Ada"`)
+		assert.Contains(t, err.Error(), `"Grace"`)
+	})
+
+	t.Run("MalformedPaths", func(t *testing.T) {
+		for _, op := range []JSONPatchOperation{
+			{Op: "add", Path: "count", Value: 1},
+			{Op: "add", Path: "/items/01", Value: 1},
+			{Op: "remove", Path: "/missing"},
+			{Op: "replace", Path: "/items/5", Value: 1},
+			{Op: "add", Path: "/count/deeper", Value: 1},
+			{Op: "move", From: "/user", Path: "/user/child"},
+		} {
+			_, err := ApplyStateDelta(state, *NewStateDeltaEvent([]JSONPatchOperation{op}))
+			assert.Error(t, err, "%s %s", op.Op, op.Path)
+		}
+
+		_, err := ApplyStateDelta(state, *NewStateDeltaEvent([]JSONPatchOperation{{Op: "remove", Path: "/missing"}}))
+		assert.ErrorIs(t, err, jsonpointer.ErrNotFound)
+	})
+
+	t.Run("ReplaceRootOfEmptyState", func(t *testing.T) {
+		result, err := ApplyStateDelta(nil, *NewStateDeltaEvent([]JSONPatchOperation{
+			{Op: "add", Path: "", Value: map[string]any{"ready": true}},
+		}))
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"ready":true}`, string(result))
+	})
+}
+
+func TestApplyMergePatch(t *testing.T) {
+	// Cases from RFC 7386 Appendix A
+	cases := []struct{ doc, patch, expected string }{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"foo"}`, `"bar"`, `"bar"`},
+		{`{"e":null}`, `{"a":1}`, `{"e":null,"a":1}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{``, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+	for _, tc := range cases {
+		result, err := ApplyMergePatch(json.RawMessage(tc.doc), json.RawMessage(tc.patch))
+		require.NoError(t, err, "%s + %s", tc.doc, tc.patch)
+		assert.JSONEq(t, tc.expected, string(result), "%s + %s", tc.doc, tc.patch)
+	}
+
+	_, err := ApplyMergePatch(json.RawMessage(`{}`), json.RawMessage(`{`))
+	assert.Error(t, err)
+}
+
+func TestApplyStateMergePatch(t *testing.T) {
+	state := json.RawMessage(`{"counter":1,"user":{"name":"ada","token":"x"}}`)
+	result, err := ApplyStateMergePatch(state, json.RawMessage(`{"counter":2,"user":{"token":null}}`))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"counter":2,"user":{"name":"ada"}}`, string(result))
+	assert.JSONEq(t, `{"counter":1,"user":{"name":"ada","token":"x"}}`, string(state))
+
+	_, err = ApplyStateMergePatch(state, json.RawMessage(`{`))
+	assert.Error(t, err)
+
+	// STATE_DELTA events dispatch on their patch format
+	event := NewStateMergePatchEvent(json.RawMessage(`{"counter":3}`))
+	assert.Equal(t, StatePatchMergePatch, event.PatchFormat())
+	require.NoError(t, event.Validate())
+	result, err = ApplyStateDelta(state, *event)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"counter":3,"user":{"name":"ada","token":"x"}}`, string(result))
+
+	// The merge patch survives a JSON round trip and matches the event schema
+	data, err := event.ToJSON()
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), `"delta"`)
+	require.NoError(t, ValidateEventJSON(data, StrictSchema()))
+	decoded, err := EventFromJSON(data)
+	require.NoError(t, err)
+	assert.Equal(t, StatePatchMergePatch, decoded.(*StateDeltaEvent).PatchFormat())
+	assert.JSONEq(t, `{"counter":3}`, string(decoded.(*StateDeltaEvent).MergePatch))
+
+	both := NewStateMergePatchEvent(json.RawMessage(`{}`))
+	both.Delta = []JSONPatchOperation{{Op: "remove", Path: "/a"}}
+	assert.Error(t, both.Validate())
+	assert.Error(t, NewStateMergePatchEvent(json.RawMessage(`{`)).Validate())
+	assert.Equal(t, StatePatchJSONPatch, NewStateDeltaEvent(nil).PatchFormat())
+}
@@ -0,0 +1,139 @@
+package events
+
+import "fmt"
+
+// toolCallTable tracks open tool calls that are identified by ID, by stream
+// index while their ID is not known yet, or by both. An event that carries
+// both assigns the ID to the tool call started under the index, after which
+// events may use either.
+type toolCallTable[V any] struct {
+	byID    map[assemblyKey]*toolCallEntry[V]
+	byIndex map[toolCallIndexKey]*toolCallEntry[V]
+}
+
+// toolCallIndexKey identifies a tool call by its stream index within a step
+type toolCallIndexKey struct {
+	step  string
+	index int
+}
+
+// toolCallEntry is an open tool call and the value tracked for it
+type toolCallEntry[V any] struct {
+	step  string
+	id    string
+	index *int
+	value V
+}
+
+// newToolCallTable creates an empty tool call table
+func newToolCallTable[V any]() *toolCallTable[V] {
+	return &toolCallTable[V]{
+		byID:    make(map[assemblyKey]*toolCallEntry[V]),
+		byIndex: make(map[toolCallIndexKey]*toolCallEntry[V]),
+	}
+}
+
+// start adds a tool call, failing when its ID or index is already in use
+func (t *toolCallTable[V]) start(step, id string, index *int, value V) error {
+	if id != "" {
+		if _, exists := t.byID[assemblyKey{step, id}]; exists {
+			return fmt.Errorf("tool call %s already started", describeToolCall(step, id, nil))
+		}
+	}
+	if index != nil {
+		if _, exists := t.byIndex[toolCallIndexKey{step, *index}]; exists {
+			return fmt.Errorf("tool call %s already started", describeToolCall(step, "", index))
+		}
+	}
+
+	entry := &toolCallEntry[V]{step: step, id: id, index: index, value: value}
+	if id != "" {
+		t.byID[assemblyKey{step, id}] = entry
+	}
+	if index != nil {
+		t.byIndex[toolCallIndexKey{step, *index}] = entry
+	}
+	return nil
+}
+
+// lookup finds an open tool call by ID, falling back to the index, and
+// assigns id to a tool call known only by its index. It returns nil when no
+// such tool call is open, and an error when the ID and index refer to
+// different tool calls.
+func (t *toolCallTable[V]) lookup(step, id string, index *int) (*toolCallEntry[V], error) {
+	var entry *toolCallEntry[V]
+	if id != "" {
+		entry = t.byID[assemblyKey{step, id}]
+	}
+	if index != nil {
+		byIndex := t.byIndex[toolCallIndexKey{step, *index}]
+		switch {
+		case entry == nil && byIndex != nil && byIndex.id != "" && id != "":
+			return nil, fmt.Errorf("tool call %s already has id %s, not %s",
+				describeToolCall(step, "", index), byIndex.id, id)
+		case entry == nil:
+			entry = byIndex
+		case entry.index == nil && byIndex == nil:
+			entry.index = index
+			t.byIndex[toolCallIndexKey{step, *index}] = entry
+		case byIndex != entry:
+			return nil, fmt.Errorf("tool call %s is not %s",
+				describeToolCall(step, id, nil), describeToolCall(step, "", index))
+		}
+	}
+
+	if entry != nil && entry.id == "" && id != "" {
+		entry.id = id
+		t.byID[assemblyKey{step, id}] = entry
+	}
+	return entry, nil
+}
+
+// end removes a tool call
+func (t *toolCallTable[V]) end(entry *toolCallEntry[V]) {
+	if entry.id != "" {
+		delete(t.byID, assemblyKey{entry.step, entry.id})
+	}
+	if entry.index != nil {
+		delete(t.byIndex, toolCallIndexKey{entry.step, *entry.index})
+	}
+}
+
+// entries calls fn for every open tool call
+func (t *toolCallTable[V]) entries(fn func(entry *toolCallEntry[V])) {
+	for _, entry := range t.byID {
+		fn(entry)
+	}
+	for _, entry := range t.byIndex {
+		if entry.id == "" {
+			fn(entry)
+		}
+	}
+}
+
+// len returns the number of open tool calls
+func (t *toolCallTable[V]) len() int {
+	n := len(t.byID)
+	for _, entry := range t.byIndex {
+		if entry.id == "" {
+			n++
+		}
+	}
+	return n
+}
+
+// describe names the tool call for error messages
+func (e *toolCallEntry[V]) describe() string {
+	return describeToolCall(e.step, e.id, e.index)
+}
+
+// describeToolCall names a tool call by ID when known and by index otherwise
+func describeToolCall(step, id string, index *int) string {
+	if id != "" || index == nil {
+		return assemblyKey{step, id}.String()
+	}
+	if step != "" {
+		return fmt.Sprintf("#%d in step %s", *index, step)
+	}
+	return fmt.Sprintf("#%d", *index)
+}
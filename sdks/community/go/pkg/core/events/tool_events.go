@@ -1,6 +1,8 @@
 package events
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 )
@@ -8,9 +10,13 @@ import (
 // ToolCallStartEvent indicates the start of a tool call
 type ToolCallStartEvent struct {
 	*BaseEvent
-	ToolCallID      string  `json:"toolCallId"`
+	ToolCallID      string  `json:"toolCallId,omitempty"`
 	ToolCallName    string  `json:"toolCallName"`
 	ParentMessageID *string `json:"parentMessageId,omitempty"`
+	// Index identifies the tool call within the stream for providers that
+	// number tool calls and only assign the ID on a later event. The
+	// ToolCallID may be empty while the index is set.
+	Index *int `json:"index,omitempty"`
 }
 
 // NewToolCallStartEvent creates a new tool call start event
@@ -47,14 +53,21 @@ func WithAutoToolCallID() ToolCallStartOption {
 	}
 }
 
+// WithToolCallIndex sets the stream index of the tool call
+func WithToolCallIndex(index int) ToolCallStartOption {
+	return func(e *ToolCallStartEvent) {
+		e.Index = &index
+	}
+}
+
 // Validate validates the tool call start event
 func (e *ToolCallStartEvent) Validate() error {
 	if err := e.BaseEvent.Validate(); err != nil {
 		return err
 	}
 
-	if e.ToolCallID == "" {
-		return fmt.Errorf("ToolCallStartEvent validation failed: toolCallId field is required")
+	if err := validateToolCallRef("ToolCallStartEvent", e.ToolCallID, e.Index); err != nil {
+		return err
 	}
 
 	if e.ToolCallName == "" {
@@ -72,8 +85,11 @@ func (e *ToolCallStartEvent) ToJSON() ([]byte, error) {
 // ToolCallArgsEvent contains streaming tool call arguments
 type ToolCallArgsEvent struct {
 	*BaseEvent
-	ToolCallID string `json:"toolCallId"`
+	ToolCallID string `json:"toolCallId,omitempty"`
 	Delta      string `json:"delta"`
+	// Index identifies the tool call by its stream index, see
+	// ToolCallStartEvent.Index
+	Index *int `json:"index,omitempty"`
 }
 
 // NewToolCallArgsEvent creates a new tool call args event
@@ -112,14 +128,21 @@ func WithAutoToolCallIDArgs() ToolCallArgsOption {
 	}
 }
 
+// WithToolCallArgsIndex sets the stream index of the tool call the arguments belong to
+func WithToolCallArgsIndex(index int) ToolCallArgsOption {
+	return func(e *ToolCallArgsEvent) {
+		e.Index = &index
+	}
+}
+
 // Validate validates the tool call args event
 func (e *ToolCallArgsEvent) Validate() error {
 	if err := e.BaseEvent.Validate(); err != nil {
 		return err
 	}
 
-	if e.ToolCallID == "" {
-		return fmt.Errorf("ToolCallArgsEvent validation failed: toolCallId field is required")
+	if err := validateToolCallRef("ToolCallArgsEvent", e.ToolCallID, e.Index); err != nil {
+		return err
 	}
 
 	if e.Delta == "" {
@@ -138,6 +161,9 @@ func (e *ToolCallArgsEvent) ToJSON() ([]byte, error) {
 type ToolCallEndEvent struct {
 	*BaseEvent
 	ToolCallID string `json:"toolCallId"`
+	// Index optionally repeats the stream index of the tool call, which
+	// assigns ToolCallID to a tool call that was streamed by index only
+	Index *int `json:"index,omitempty"`
 }
 
 // NewToolCallEndEvent creates a new tool call end event
@@ -174,6 +200,13 @@ func WithAutoToolCallIDEnd() ToolCallEndOption {
 	}
 }
 
+// WithToolCallEndIndex sets the stream index of the tool call being ended
+func WithToolCallEndIndex(index int) ToolCallEndOption {
+	return func(e *ToolCallEndEvent) {
+		e.Index = &index
+	}
+}
+
 // Validate validates the tool call end event
 func (e *ToolCallEndEvent) Validate() error {
 	if err := e.BaseEvent.Validate(); err != nil {
@@ -184,6 +217,25 @@ func (e *ToolCallEndEvent) Validate() error {
 		return fmt.Errorf("ToolCallEndEvent validation failed: toolCallId field is required")
 	}
 
+	if e.Index != nil && *e.Index < 0 {
+		return fmt.Errorf("ToolCallEndEvent validation failed: index must not be negative")
+	}
+
+	return nil
+}
+
+// validateToolCallRef checks that an event identifies its tool call by ID,
+// stream index or both
+func validateToolCallRef(eventName, toolCallID string, index *int) error {
+	if index == nil {
+		if toolCallID == "" {
+			return fmt.Errorf("%s validation failed: toolCallId field is required", eventName)
+		}
+		return nil
+	}
+	if *index < 0 {
+		return fmt.Errorf("%s validation failed: index must not be negative", eventName)
+	}
 	return nil
 }
 
@@ -239,6 +291,103 @@ func (e *ToolCallResultEvent) ToJSON() ([]byte, error) {
 	return json.Marshal(e)
 }
 
+// DefaultResultChunkSize is the chunk size used by NewToolCallResultChunks
+// when none is given
+const DefaultResultChunkSize = 64 * 1024
+
+// ToolCallResultChunkEvent carries part of a binary tool result, for results
+// such as generated images that are too large for the string content of a
+// TOOL_CALL_RESULT. Chunks are numbered from zero by Sequence; the final one
+// carries the hex-encoded SHA-256 checksum of the whole result, and the
+// MIME type may be set on any chunk. Data is base64-encoded in JSON, so
+// encodings with native bytes avoid the overhead. BinaryResultAssembler
+// reassembles the result.
+type ToolCallResultChunkEvent struct {
+	*BaseEvent
+	MessageID  string `json:"messageId"`
+	ToolCallID string `json:"toolCallId"`
+	Sequence   int    `json:"sequence"`
+	Data       []byte `json:"data,omitempty"`
+	MimeType   string `json:"mimeType,omitempty"`
+	Final      bool   `json:"final,omitempty"`
+	Checksum   string `json:"checksum,omitempty"`
+}
+
+// NewToolCallResultChunkEvent creates a new tool call result chunk event
+func NewToolCallResultChunkEvent(messageID, toolCallID string, sequence int, data []byte) *ToolCallResultChunkEvent {
+	return &ToolCallResultChunkEvent{
+		BaseEvent:  NewBaseEvent(EventTypeToolCallResultChunk),
+		MessageID:  messageID,
+		ToolCallID: toolCallID,
+		Sequence:   sequence,
+		Data:       data,
+	}
+}
+
+// NewToolCallResultChunks splits a binary tool result into chunk events of
+// at most chunkSize bytes, or DefaultResultChunkSize when chunkSize is not
+// positive. The first chunk carries mimeType when it is not empty and the
+// last one the checksum of data. An empty result is a single, empty chunk.
+func NewToolCallResultChunks(messageID, toolCallID, mimeType string, data []byte, chunkSize int) []*ToolCallResultChunkEvent {
+	if chunkSize <= 0 {
+		chunkSize = DefaultResultChunkSize
+	}
+
+	var chunks []*ToolCallResultChunkEvent
+	for offset := 0; offset == 0 || offset < len(data); offset += chunkSize {
+		end := min(offset+chunkSize, len(data))
+		chunks = append(chunks, NewToolCallResultChunkEvent(messageID, toolCallID, len(chunks), data[offset:end]))
+	}
+	chunks[0].MimeType = mimeType
+	last := chunks[len(chunks)-1]
+	last.Final = true
+	last.Checksum = ResultChecksum(data)
+	return chunks
+}
+
+// ResultChecksum returns the checksum carried by the final chunk of a binary
+// tool result: the hex-encoded SHA-256 of data
+func ResultChecksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// Validate validates the tool call result chunk event
+func (e *ToolCallResultChunkEvent) Validate() error {
+	if err := e.BaseEvent.Validate(); err != nil {
+		return err
+	}
+
+	if e.MessageID == "" {
+		return fmt.Errorf("ToolCallResultChunkEvent validation failed: messageId field is required")
+	}
+
+	if e.ToolCallID == "" {
+		return fmt.Errorf("ToolCallResultChunkEvent validation failed: toolCallId field is required")
+	}
+
+	if e.Sequence < 0 {
+		return fmt.Errorf("ToolCallResultChunkEvent validation failed: sequence must not be negative")
+	}
+
+	if e.Final && e.Checksum == "" {
+		return fmt.Errorf("ToolCallResultChunkEvent validation failed: checksum field is required on the final chunk")
+	}
+
+	if e.Checksum != "" {
+		if decoded, err := hex.DecodeString(e.Checksum); err != nil || len(decoded) != sha256.Size {
+			return fmt.Errorf("ToolCallResultChunkEvent validation failed: checksum must be a hex-encoded SHA-256")
+		}
+	}
+
+	return nil
+}
+
+// ToJSON serializes the event to JSON
+func (e *ToolCallResultChunkEvent) ToJSON() ([]byte, error) {
+	return json.Marshal(e)
+}
+
 // ToolCallChunkEvent represents a chunk of tool call data
 type ToolCallChunkEvent struct {
 	*BaseEvent
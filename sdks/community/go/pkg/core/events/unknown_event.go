@@ -0,0 +1,62 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// UnknownEvent carries an event whose type this SDK does not recognize, for
+// example one added by a newer protocol version. It keeps the original JSON so
+// that callers can inspect it or forward it verbatim. (RawEvent is the
+// protocol's own RAW event type and is unrelated.)
+type UnknownEvent struct {
+	*BaseEvent
+	Data json.RawMessage `json:"-"`
+}
+
+// NewUnknownEvent wraps the JSON of an event with an unrecognized type. The
+// type and timestamp are read from the payload.
+func NewUnknownEvent(data []byte) (*UnknownEvent, error) {
+	var base struct {
+		Type      string `json:"type"`
+		Timestamp *int64 `json:"timestamp"`
+	}
+	if err := json.Unmarshal(data, &base); err != nil {
+		return nil, fmt.Errorf("failed to parse event type: %w", err)
+	}
+	if base.Type == "" {
+		return nil, fmt.Errorf("UnknownEvent validation failed: type field is required")
+	}
+
+	return &UnknownEvent{
+		BaseEvent: &BaseEvent{EventType: EventType(base.Type), TimestampMs: base.Timestamp},
+		Data:      append(json.RawMessage(nil), data...),
+	}, nil
+}
+
+// Validate checks that the event has a type and well-formed JSON. Unknown
+// types are accepted, since that is the purpose of this event.
+func (e *UnknownEvent) Validate() error {
+	if e.BaseEvent == nil || e.EventType == "" {
+		return fmt.Errorf("UnknownEvent validation failed: type field is required")
+	}
+
+	if !json.Valid(e.Data) {
+		return fmt.Errorf("UnknownEvent validation failed: data must be valid JSON")
+	}
+
+	return nil
+}
+
+// MarshalJSON returns the original event JSON
+func (e *UnknownEvent) MarshalJSON() ([]byte, error) {
+	return e.ToJSON()
+}
+
+// ToJSON returns the original event JSON unchanged
+func (e *UnknownEvent) ToJSON() ([]byte, error) {
+	if len(e.Data) == 0 {
+		return nil, fmt.Errorf("UnknownEvent has no data")
+	}
+	return e.Data, nil
+}
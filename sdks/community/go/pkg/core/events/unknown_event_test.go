@@ -0,0 +1,36 @@
+package events
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnknownEvent(t *testing.T) {
+	data := []byte(`{"type":"FUTURE_EVENT","timestamp":1700000000000,"payload":{"n":1}}`)
+
+	_, err := EventFromJSON(data)
+	require.ErrorIs(t, err, ErrUnknownEventType)
+
+	event, err := NewUnknownEvent(data)
+	require.NoError(t, err)
+	assert.Equal(t, EventType("FUTURE_EVENT"), event.Type())
+	require.NotNil(t, event.Timestamp())
+	assert.Equal(t, int64(1700000000000), *event.Timestamp())
+	assert.NoError(t, event.Validate())
+
+	out, err := event.ToJSON()
+	require.NoError(t, err)
+	assert.Equal(t, string(data), string(out))
+
+	wrapped, err := json.Marshal([]Event{event})
+	require.NoError(t, err)
+	assert.JSONEq(t, "["+string(data)+"]", string(wrapped))
+
+	_, err = NewUnknownEvent([]byte(`{"payload":1}`))
+	assert.Error(t, err)
+	_, err = NewUnknownEvent([]byte(`not json`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,73 @@
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	coretypes "github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/types"
+)
+
+// UnknownFieldError is returned (wrapped) by EventFromJSONStrict for a field
+// the event type does not define
+type UnknownFieldError struct {
+	// Field is the name of the field. Fields of a snapshot message are
+	// prefixed with the message's position, as in messages[2].avatar.
+	Field string
+}
+
+// Error implements the error interface
+func (e *UnknownFieldError) Error() string {
+	return fmt.Sprintf("unknown field %q", e.Field)
+}
+
+// strictMessage has the fields of Message without its UnmarshalJSON, which
+// accepts snake_case aliases and ignores fields it does not know
+type strictMessage coretypes.Message
+
+// decodeStrict decodes data into v, reporting fields v does not define as an
+// *UnknownFieldError whose name is prefixed with path
+func decodeStrict(data []byte, v any, path string) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		// encoding/json reports unknown fields only through the message
+		if field, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+			if name, err := strconv.Unquote(field); err == nil {
+				field = name
+			}
+			return &UnknownFieldError{Field: path + field}
+		}
+		return err
+	}
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return fmt.Errorf("unexpected data after the event")
+	}
+	return nil
+}
+
+// checkSnapshotMessageFields rejects unknown fields in the messages of a
+// MESSAGES_SNAPSHOT, which Message's own decoding ignores
+func checkSnapshotMessageFields(data []byte, event Event) error {
+	if _, ok := event.(*MessagesSnapshotEvent); !ok {
+		return nil
+	}
+
+	var raw struct {
+		Messages []json.RawMessage `json:"messages"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	for i, msg := range raw.Messages {
+		var decoded strictMessage
+		if err := decodeStrict(msg, &decoded, fmt.Sprintf("messages[%d].", i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package events
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventFromJSONStrict(t *testing.T) {
+	valid := []byte(`{"type":"MESSAGES_SNAPSHOT","timestamp":1,"messages":[` +
+		`{"id":"m1","role":"user","content":[{"type":"text","text":"hi","extra":true}]},` +
+		`{"id":"m2","role":"assistant","toolCalls":[{"id":"c1","type":"function","function":{"name":"f","arguments":"{}"}}]}]}`)
+	event, err := EventFromJSONStrict(valid)
+	require.NoError(t, err)
+	lenient, err := EventFromJSON(valid)
+	require.NoError(t, err)
+	assert.Equal(t, lenient, event)
+
+	tests := []struct {
+		name  string
+		data  string
+		field string
+	}{
+		{"EventField", `{"type":"TEXT_MESSAGE_CONTENT","messageId":"m","delta":"x","mesageId":"m"}`, "mesageId"},
+		{"MessageField", `{"type":"MESSAGES_SNAPSHOT","messages":[{"id":"m","role":"user","content":"hi"},{"id":"n","role":"user","content":"hi","avatar":"a"}]}`, "messages[1].avatar"},
+		{"SnakeCaseMessageField", `{"type":"MESSAGES_SNAPSHOT","messages":[{"id":"m","role":"tool","content":"ok","tool_call_id":"c"}]}`, "messages[0].tool_call_id"},
+		{"ToolCallField", `{"type":"MESSAGES_SNAPSHOT","messages":[{"id":"m","role":"assistant","toolCalls":[{"id":"c","type":"function","function":{"name":"f","arguments":"","args":""}}]}]}`, "messages[0].args"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := EventFromJSON([]byte(tt.data))
+			require.NoError(t, err)
+
+			_, err = EventFromJSONStrict([]byte(tt.data))
+			var fieldErr *UnknownFieldError
+			require.True(t, errors.As(err, &fieldErr), "got %v", err)
+			assert.Equal(t, tt.field, fieldErr.Field)
+			assert.Contains(t, err.Error(), tt.field)
+		})
+	}
+
+	_, err = EventFromJSONStrict([]byte(`{"type":"STEP_STARTED","stepName":"a"} {}`))
+	assert.Error(t, err)
+	_, err = EventFromJSONStrict([]byte(`{"type":"FUTURE_EVENT"}`))
+	assert.ErrorIs(t, err, ErrUnknownEventType)
+}
@@ -0,0 +1,140 @@
+// Package jsonpointer implements RFC 6901 JSON Pointer evaluation over decoded JSON values.
+package jsonpointer
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidPointer is returned when a pointer string is malformed.
+	ErrInvalidPointer = errors.New("invalid JSON pointer")
+	// ErrNotFound is returned when a pointer does not resolve to a value.
+	ErrNotFound = errors.New("JSON pointer path not found")
+)
+
+// Parse splits a JSON Pointer into its unescaped reference tokens.
+// The empty pointer refers to the whole document and yields no tokens.
+func Parse(pointer string) ([]string, error) {
+	if pointer == "" {
+		return []string{}, nil
+	}
+	if pointer[0] != '/' {
+		return nil, fmt.Errorf("%w: %q must start with '/'", ErrInvalidPointer, pointer)
+	}
+
+	parts := strings.Split(pointer[1:], "/")
+	tokens := make([]string, len(parts))
+	for i, part := range parts {
+		token, err := unescape(part)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q: %v", ErrInvalidPointer, pointer, err)
+		}
+		tokens[i] = token
+	}
+	return tokens, nil
+}
+
+// Format builds a JSON Pointer string from reference tokens, escaping as needed.
+func Format(tokens []string) string {
+	var b strings.Builder
+	for _, token := range tokens {
+		b.WriteByte('/')
+		token = strings.ReplaceAll(token, "~", "~0")
+		token = strings.ReplaceAll(token, "/", "~1")
+		b.WriteString(token)
+	}
+	return b.String()
+}
+
+// Get resolves pointer against a decoded JSON document
+// (map[string]any, []any and scalar values as produced by encoding/json).
+func Get(doc any, pointer string) (any, error) {
+	tokens, err := Parse(pointer)
+	if err != nil {
+		return nil, err
+	}
+	value, err := GetTokens(doc, tokens)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, pointer)
+	}
+	return value, nil
+}
+
+// GetTokens resolves already parsed reference tokens against a decoded JSON document.
+func GetTokens(doc any, tokens []string) (any, error) {
+	current := doc
+	for _, token := range tokens {
+		switch node := current.(type) {
+		case map[string]any:
+			value, ok := node[token]
+			if !ok {
+				return nil, ErrNotFound
+			}
+			current = value
+		case []any:
+			idx, err := ArrayIndex(token, len(node))
+			if err != nil {
+				return nil, err
+			}
+			if idx >= len(node) {
+				return nil, ErrNotFound
+			}
+			current = node[idx]
+		default:
+			return nil, ErrNotFound
+		}
+	}
+	return current, nil
+}
+
+// ArrayIndex parses an array index token. Indexes must be non-negative decimal
+// integers without leading zeros. The returned index may equal length; callers
+// decide whether that is a valid position for their operation.
+func ArrayIndex(token string, length int) (int, error) {
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("%w: invalid array index %q", ErrInvalidPointer, token)
+	}
+	for _, r := range token {
+		if r < '0' || r > '9' {
+			return 0, fmt.Errorf("%w: invalid array index %q", ErrInvalidPointer, token)
+		}
+	}
+	idx, err := strconv.Atoi(token)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid array index %q", ErrInvalidPointer, token)
+	}
+	if idx > length {
+		return 0, fmt.Errorf("%w: array index %d out of bounds", ErrNotFound, idx)
+	}
+	return idx, nil
+}
+
+// unescape decodes the ~0 and ~1 escape sequences of a reference token.
+func unescape(token string) (string, error) {
+	if !strings.Contains(token, "~") {
+		return token, nil
+	}
+	var b strings.Builder
+	for i := 0; i < len(token); i++ {
+		if token[i] != '~' {
+			b.WriteByte(token[i])
+			continue
+		}
+		if i+1 >= len(token) {
+			return "", fmt.Errorf("incomplete escape sequence")
+		}
+		switch token[i+1] {
+		case '0':
+			b.WriteByte('~')
+		case '1':
+			b.WriteByte('/')
+		default:
+			return "", fmt.Errorf("invalid escape sequence ~%c", token[i+1])
+		}
+		i++
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,60 @@
+package jsonpointer
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tokens, err := Parse("")
+	require.NoError(t, err)
+	assert.Empty(t, tokens)
+
+	tokens, err = Parse("/a~1b/m~0n/0")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a/b", "m~n", "0"}, tokens)
+
+	_, err = Parse("a/b")
+	assert.ErrorIs(t, err, ErrInvalidPointer)
+
+	_, err = Parse("/bad~2escape")
+	assert.ErrorIs(t, err, ErrInvalidPointer)
+
+	assert.Equal(t, "/a~1b/m~0n/0", Format([]string{"a/b", "m~n", "0"}))
+}
+
+func TestGet(t *testing.T) {
+	var doc any
+	require.NoError(t, json.Unmarshal([]byte(`{"foo":["bar","baz"],"":0,"a/b":1,"m~n":8,"nested":{"k":null}}`), &doc))
+
+	cases := map[string]any{
+		"/foo/0":    "bar",
+		"/":         float64(0),
+		"/a~1b":     float64(1),
+		"/m~0n":     float64(8),
+		"/nested/k": nil,
+	}
+	for pointer, expected := range cases {
+		value, err := Get(doc, pointer)
+		require.NoError(t, err, pointer)
+		assert.Equal(t, expected, value, pointer)
+	}
+
+	whole, err := Get(doc, "")
+	require.NoError(t, err)
+	assert.Equal(t, doc, whole)
+
+	for _, pointer := range []string{"/missing", "/foo/2", "/foo/-", "/nested/k/deeper"} {
+		_, err := Get(doc, pointer)
+		assert.Error(t, err, pointer)
+	}
+
+	_, err = Get(doc, "/foo/01")
+	assert.ErrorIs(t, err, ErrInvalidPointer)
+
+	_, err = Get(doc, "/missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
@@ -0,0 +1,404 @@
+package schema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/jsonpointer"
+)
+
+// ErrInvalidJSON is returned by PartialValidator when the input cannot be the
+// start of a JSON document
+var ErrInvalidJSON = errors.New("invalid JSON")
+
+// partialState is the position of a PartialValidator within the JSON grammar
+type partialState int
+
+const (
+	stateValue      partialState = iota // expecting a value
+	stateValueOrEnd                     // after '[': a value or ']'
+	stateKeyOrEnd                       // after '{': a key or '}'
+	stateKey                            // after ',' in an object
+	stateColon                          // after a key
+	stateCommaOrEnd                     // after a value inside a container
+	stateString                         // inside a string
+	stateNumber                         // inside a number
+	stateLiteral                        // inside true, false or null
+	stateDone                           // after the top-level value
+)
+
+// PartialValidator checks a JSON document while it is streamed in, such as
+// tool call arguments arriving in fragments. It reports an error as soon as
+// the input seen so far cannot be completed into a document that satisfies
+// the schema.
+//
+// The check is best-effort: malformed JSON, values of a disallowed type,
+// properties forbidden by additionalProperties false and arrays longer than
+// maxItems are reported as soon as they start, and every string, number,
+// literal, object and array is validated in full once it is complete.
+// Keywords that depend on alternatives (anyOf, oneOf, not) only take effect
+// when the value they apply to is complete, so a document that passes
+// may still fail Validate once it ends.
+type PartialValidator struct {
+	schema *Schema
+	buf    []byte
+	pos    int
+	state  partialState
+	stack  []*partialFrame
+	err    error
+
+	// The string, number or literal being scanned
+	start   int
+	isKey   bool
+	escaped bool
+	literal string
+
+	// The schemas and location of the value being scanned
+	valueSchemas []*Schema
+	valuePath    []string
+}
+
+// partialFrame is an object or array that has started but not ended
+type partialFrame struct {
+	array   bool
+	start   int
+	path    []string
+	schemas []*Schema
+	key     string
+	items   int
+}
+
+// NewPartialValidator creates a validator for a document streamed in with Write
+func (s *Schema) NewPartialValidator() *PartialValidator {
+	return &PartialValidator{schema: s}
+}
+
+// Write adds the next fragment of the document. It returns ErrInvalidJSON
+// (wrapped) for malformed input and a *ValidationError once the document can
+// no longer satisfy the schema. After an error every call returns it again.
+func (v *PartialValidator) Write(p []byte) (int, error) {
+	if v.err != nil {
+		return 0, v.err
+	}
+	v.buf = append(v.buf, p...)
+	for v.pos < len(v.buf) {
+		advance, err := v.step(v.buf[v.pos])
+		if err != nil {
+			v.err = err
+			return len(p), err
+		}
+		if advance {
+			v.pos++
+		}
+	}
+	return len(p), nil
+}
+
+// step consumes the byte at v.pos. It reports false when the byte ended a
+// number and must be read again in the new state.
+func (v *PartialValidator) step(c byte) (bool, error) {
+	switch v.state {
+	case stateString:
+		switch {
+		case v.escaped:
+			v.escaped = false
+		case c == '\\':
+			v.escaped = true
+		case c == '"':
+			if v.isKey {
+				return true, v.finishKey()
+			}
+			return true, v.finishValue(v.pos + 1)
+		case c < 0x20:
+			return false, v.syntaxError(c)
+		}
+		return true, nil
+
+	case stateNumber:
+		if strings.IndexByte("0123456789+-.eE", c) >= 0 {
+			return true, nil
+		}
+		return false, v.finishValue(v.pos)
+
+	case stateLiteral:
+		if c != v.literal[0] {
+			return false, v.syntaxError(c)
+		}
+		v.literal = v.literal[1:]
+		if v.literal == "" {
+			return true, v.finishValue(v.pos + 1)
+		}
+		return true, nil
+	}
+
+	if isSpace(c) {
+		return true, nil
+	}
+
+	switch v.state {
+	case stateValue:
+		return true, v.startValue(c)
+
+	case stateValueOrEnd:
+		if c == ']' {
+			return true, v.endContainer()
+		}
+		return true, v.startValue(c)
+
+	case stateKeyOrEnd, stateKey:
+		if c == '}' && v.state == stateKeyOrEnd {
+			return true, v.endContainer()
+		}
+		if c != '"' {
+			return false, v.syntaxError(c)
+		}
+		v.state = stateString
+		v.isKey = true
+		v.start = v.pos
+		return true, nil
+
+	case stateColon:
+		if c != ':' {
+			return false, v.syntaxError(c)
+		}
+		v.state = stateValue
+		return true, nil
+
+	case stateCommaOrEnd:
+		top := v.stack[len(v.stack)-1]
+		switch {
+		case c == ',' && top.array:
+			v.state = stateValue
+		case c == ',':
+			v.state = stateKey
+		case c == ']' && top.array, c == '}' && !top.array:
+			return true, v.endContainer()
+		default:
+			return false, v.syntaxError(c)
+		}
+		return true, nil
+	}
+
+	// stateDone: only whitespace may follow the document
+	return false, v.syntaxError(c)
+}
+
+// startValue begins the value whose first byte is c
+func (v *PartialValidator) startValue(c byte) error {
+	schemas, path, err := v.childContext()
+	if err != nil {
+		return err
+	}
+
+	var kind string
+	switch {
+	case c == '{':
+		kind = "object"
+	case c == '[':
+		kind = "array"
+	case c == '"':
+		kind = "string"
+	case c == 't' || c == 'f':
+		kind = "boolean"
+	case c == 'n':
+		kind = "null"
+	case c == '-' || (c >= '0' && c <= '9'):
+		kind = "number"
+	default:
+		return v.syntaxError(c)
+	}
+	if err := checkKind(expand(schemas), kind, path); err != nil {
+		return err
+	}
+
+	switch kind {
+	case "object", "array":
+		v.stack = append(v.stack, &partialFrame{array: kind == "array", start: v.pos, path: path, schemas: schemas})
+		if kind == "array" {
+			v.state = stateValueOrEnd
+		} else {
+			v.state = stateKeyOrEnd
+		}
+		return nil
+	case "string":
+		v.state = stateString
+		v.isKey = false
+	case "number":
+		v.state = stateNumber
+	default:
+		v.state = stateLiteral
+		v.literal = map[byte]string{'t': "rue", 'f': "alse", 'n': "ull"}[c]
+	}
+	v.start = v.pos
+	v.valueSchemas = schemas
+	v.valuePath = path
+	return nil
+}
+
+// childContext returns the schemas and path of the next value: the document
+// itself, the value of the current object key or the next array item
+func (v *PartialValidator) childContext() ([]*Schema, []string, error) {
+	if len(v.stack) == 0 {
+		if v.schema == nil {
+			return nil, nil, nil
+		}
+		return []*Schema{v.schema}, nil, nil
+	}
+
+	top := v.stack[len(v.stack)-1]
+	var children []*Schema
+	if top.array {
+		path := childPath(top.path, fmt.Sprint(top.items))
+		top.items++
+		for _, s := range expand(top.schemas) {
+			if s.maxItems != nil && top.items > *s.maxItems {
+				return nil, nil, partialError(top.path, "array has more than %d items", *s.maxItems)
+			}
+			if s.items != nil {
+				children = append(children, s.items)
+			}
+		}
+		return children, path, nil
+	}
+
+	for _, s := range expand(top.schemas) {
+		if property, ok := s.properties[top.key]; ok {
+			children = append(children, property)
+		} else if s.additionalProperties != nil {
+			children = append(children, s.additionalProperties)
+		}
+	}
+	return children, childPath(top.path, top.key), nil
+}
+
+// finishKey records the object key that just ended
+func (v *PartialValidator) finishKey() error {
+	var key string
+	if err := json.Unmarshal(v.buf[v.start:v.pos+1], &key); err != nil {
+		return fmt.Errorf("%w at offset %d: %v", ErrInvalidJSON, v.start, err)
+	}
+
+	top := v.stack[len(v.stack)-1]
+	for _, s := range expand(top.schemas) {
+		if _, ok := s.properties[key]; ok {
+			continue
+		}
+		if additional := s.additionalProperties; additional != nil && additional.always != nil && !*additional.always {
+			return partialError(childPath(top.path, key), "property is not allowed")
+		}
+	}
+	top.key = key
+	v.state = stateColon
+	return nil
+}
+
+// finishValue validates the string, number or literal ending before end
+func (v *PartialValidator) finishValue(end int) error {
+	if err := v.check(v.buf[v.start:end], v.valueSchemas, v.valuePath); err != nil {
+		return err
+	}
+	v.afterValue()
+	return nil
+}
+
+// endContainer validates the object or array closed by the byte at v.pos
+func (v *PartialValidator) endContainer() error {
+	top := v.stack[len(v.stack)-1]
+	v.stack = v.stack[:len(v.stack)-1]
+	if err := v.check(v.buf[top.start:v.pos+1], top.schemas, top.path); err != nil {
+		return err
+	}
+	v.afterValue()
+	return nil
+}
+
+// afterValue moves past a complete value
+func (v *PartialValidator) afterValue() {
+	if len(v.stack) == 0 {
+		v.state = stateDone
+		return
+	}
+	v.state = stateCommaOrEnd
+}
+
+// check validates a complete value against every schema that applies to it
+func (v *PartialValidator) check(data []byte, schemas []*Schema, path []string) error {
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("%w at offset %d: %v", ErrInvalidJSON, v.pos, err)
+	}
+
+	var errs []FieldError
+	for _, s := range schemas {
+		s.validate(value, path, &errs)
+	}
+	if len(errs) > 0 {
+		return &ValidationError{Errors: errs}
+	}
+	return nil
+}
+
+// syntaxError reports an unexpected byte at the current offset
+func (v *PartialValidator) syntaxError(c byte) error {
+	return fmt.Errorf("%w: unexpected %q at offset %d", ErrInvalidJSON, c, v.pos)
+}
+
+// expand returns the schemas together with those they include through $ref
+// and allOf, all of which a value must satisfy
+func expand(schemas []*Schema) []*Schema {
+	var expanded []*Schema
+	seen := make(map[*Schema]bool)
+	var visit func(s *Schema)
+	visit = func(s *Schema) {
+		if s == nil || seen[s] {
+			return
+		}
+		seen[s] = true
+		expanded = append(expanded, s)
+		visit(s.resolved)
+		for _, sub := range s.allOf {
+			visit(sub)
+		}
+	}
+	for _, s := range schemas {
+		visit(s)
+	}
+	return expanded
+}
+
+// checkKind reports a value of the given JSON type that the schemas reject
+// regardless of its content. Numbers match both number and integer, since
+// the fraction has not been seen yet.
+func checkKind(schemas []*Schema, kind string, path []string) error {
+	for _, s := range schemas {
+		if s.always != nil && !*s.always {
+			return partialError(path, "no value is allowed")
+		}
+		if len(s.types) == 0 {
+			continue
+		}
+		allowed := false
+		for _, t := range s.types {
+			if t == kind || (kind == "number" && t == "integer") {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return partialError(path, "expected %s, got %s", strings.Join(s.types, " or "), kind)
+		}
+	}
+	return nil
+}
+
+// partialError builds a single-field validation error
+func partialError(path []string, format string, args ...any) error {
+	return &ValidationError{Errors: []FieldError{{Path: jsonpointer.Format(path), Message: fmt.Sprintf(format, args...)}}}
+}
+
+// isSpace reports whether c is JSON whitespace
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
@@ -0,0 +1,93 @@
+package schema
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// feed writes a document to a new partial validator one byte at a time and
+// returns the first error together with the offset at which it occurred
+func feed(s *Schema, document string) (int, error) {
+	v := s.NewPartialValidator()
+	for i := 0; i < len(document); i++ {
+		if _, err := v.Write([]byte{document[i]}); err != nil {
+			return i, err
+		}
+	}
+	return len(document), nil
+}
+
+func TestPartialValidator(t *testing.T) {
+	s := MustCompile([]byte(planSchema))
+
+	valid := []string{
+		`{"title": "Plan", "steps": [{"id": 1, "status": "todo"}]}`,
+		// Prefixes that can still become valid documents
+		`{"title": "Pl`,
+		`{"steps": [{"id": 2}, {"id"`,
+		`{"priority": "lo`,
+		` {"title":"a","steps":[{"id":1}],"priority":"high"}  `,
+	}
+	for _, document := range valid {
+		_, err := feed(s, document)
+		assert.NoError(t, err, document)
+	}
+
+	invalid := []struct {
+		document string
+		failAt   string
+		path     string
+	}{
+		{`{"title": 42`, `{"title": 4`, "/title"},
+		{`{"title": "Plan", "owner": "me"}`, `{"title": "Plan", "owner"`, "/owner"},
+		{`{"steps": {"id": 1}}`, `{"steps": {`, "/steps"},
+		{`{"steps": [{"id": "one"}]}`, `{"steps": [{"id": "`, "/steps/0/id"},
+		{`{"steps": [{"id": 1.5}]}`, `{"steps": [{"id": 1.5}`, "/steps/0/id"},
+		{`{"steps": [{"id": 1, "status": "doing"}]}`, `{"steps": [{"id": 1, "status": "doing"`, "/steps/0/status"},
+		{`{"priority": "urgent", "title": "x"}`, `{"priority": "urgent"`, "/priority"},
+		{`{"steps": [{"status": "done"}]}`, `{"steps": [{"status": "done"}`, "/steps/0"},
+		{`[1, 2]`, `[`, ""},
+	}
+	for _, tt := range invalid {
+		offset, err := feed(s, tt.document)
+		var validationErr *ValidationError
+		require.True(t, errors.As(err, &validationErr), tt.document)
+		assert.Equal(t, len(tt.failAt)-1, offset, tt.document)
+		assert.Equal(t, tt.path, validationErr.Errors[0].Path, tt.document)
+	}
+}
+
+func TestPartialValidatorMaxItems(t *testing.T) {
+	s := MustCompile([]byte(`{"type": "array", "maxItems": 2, "items": {"type": "integer"}}`))
+
+	offset, err := feed(s, `[1, 2, 3, 4]`)
+	require.Error(t, err)
+	assert.Equal(t, len(`[1, 2, 3`)-1, offset)
+	assert.Contains(t, err.Error(), "more than 2 items")
+}
+
+func TestPartialValidatorSyntax(t *testing.T) {
+	s := MustCompile([]byte(`{"type": "object"}`))
+
+	for _, document := range []string{`{"a" 1}`, `{"a": tru3}`, `{"a": 1,,}`, `{"a": 1} x`, `{"a": [1}`, `{'a': 1}`, `{"a": 1e}`} {
+		_, err := feed(s, document)
+		assert.ErrorIs(t, err, ErrInvalidJSON, document)
+	}
+
+	// Errors stick, and larger fragments are checked the same way
+	v := s.NewPartialValidator()
+	_, err := v.Write([]byte(`{"a": [1, {"b": "é\"}"}]`))
+	require.NoError(t, err)
+	_, err = v.Write([]byte(`}}`))
+	require.ErrorIs(t, err, ErrInvalidJSON)
+	_, again := v.Write([]byte(` `))
+	assert.Equal(t, err, again)
+
+	// A nil schema only checks the syntax
+	var none *Schema
+	_, err = feed(none, `{"anything": [true, false, null, -1.5e3, "x"]}`)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,643 @@
+// Package schema compiles and evaluates a subset of JSON Schema used to check
+// structured AG-UI payloads such as activity content.
+//
+// Supported keywords: type, enum, const, properties, required,
+// additionalProperties, items, minItems, maxItems, uniqueItems, minLength,
+// maxLength, pattern, minimum, maximum, exclusiveMinimum, exclusiveMaximum,
+// allOf, anyOf, oneOf, not, and local $ref into $defs or definitions.
+// Other keywords, such as title and description, are ignored. A $ref may
+// recurse only through a keyword that descends into the value, such as
+// properties or items.
+package schema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/jsonpointer"
+)
+
+// ErrInvalidSchema is returned when a schema document cannot be compiled
+var ErrInvalidSchema = errors.New("invalid JSON schema")
+
+// Schema is a compiled JSON Schema. It is immutable and safe for concurrent use.
+type Schema struct {
+	// always is set for boolean schemas: true accepts everything, false nothing
+	always *bool
+
+	types []string
+	enum  []any
+	// constValue is only meaningful when hasConst is set, since null is a valid const
+	constValue any
+	hasConst   bool
+
+	properties           map[string]*Schema
+	required             []string
+	additionalProperties *Schema
+
+	items       *Schema
+	minItems    *int
+	maxItems    *int
+	uniqueItems bool
+
+	minLength *int
+	maxLength *int
+	pattern   *regexp.Regexp
+
+	minimum          *float64
+	maximum          *float64
+	exclusiveMinimum *float64
+	exclusiveMaximum *float64
+
+	allOf []*Schema
+	anyOf []*Schema
+	oneOf []*Schema
+	not   *Schema
+
+	ref      string
+	resolved *Schema
+}
+
+// FieldError describes a single schema violation
+type FieldError struct {
+	// Path is the JSON Pointer of the offending value; empty for the root
+	Path    string
+	Message string
+}
+
+// Error implements the error interface
+func (e FieldError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// ValidationError lists every violation found while validating a value
+type ValidationError struct {
+	Errors []FieldError
+}
+
+// Error implements the error interface
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, fieldErr := range e.Errors {
+		messages[i] = fieldErr.Error()
+	}
+	return fmt.Sprintf("schema validation failed: %s", strings.Join(messages, "; "))
+}
+
+// Compile parses and compiles a schema document
+func Compile(data []byte) (*Schema, error) {
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSchema, err)
+	}
+
+	c := &compiler{root: doc, refs: map[string]*Schema{}}
+	compiled, err := c.compile(doc, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.resolve(); err != nil {
+		return nil, err
+	}
+	if err := c.checkCycles(); err != nil {
+		return nil, err
+	}
+	return compiled, nil
+}
+
+// MustCompile is like Compile but panics on error. It is intended for
+// schemas that are constants in the program.
+func MustCompile(data []byte) *Schema {
+	compiled, err := Compile(data)
+	if err != nil {
+		panic(err)
+	}
+	return compiled
+}
+
+// Validate checks value against the schema. Values of arbitrary Go types are
+// first converted to their JSON representation. The returned error is a
+// *ValidationError listing every violation.
+func (s *Schema) Validate(value any) error {
+	normalized, err := normalize(value)
+	if err != nil {
+		return fmt.Errorf("schema validation failed: value is not JSON encodable: %w", err)
+	}
+
+	var errs []FieldError
+	s.validate(normalized, nil, &errs)
+	if len(errs) > 0 {
+		return &ValidationError{Errors: errs}
+	}
+	return nil
+}
+
+// normalize converts a value into the generic form produced by encoding/json
+func normalize(value any) (any, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var normalized any
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
+
+// compiler holds state shared while compiling a single document
+type compiler struct {
+	root    any
+	refs    map[string]*Schema
+	pending []*Schema
+}
+
+// compile compiles the schema found at path within the document
+func (c *compiler) compile(node any, path []string) (*Schema, error) {
+	pointer := jsonpointer.Format(path)
+	if compiled, ok := c.refs[pointer]; ok {
+		return compiled, nil
+	}
+
+	s := &Schema{}
+	c.refs[pointer] = s
+
+	if always, ok := node.(bool); ok {
+		s.always = &always
+		return s, nil
+	}
+
+	obj, ok := node.(map[string]any)
+	if !ok {
+		return nil, c.errorf(path, "schema must be an object or boolean")
+	}
+
+	if err := c.compileTypes(s, obj, path); err != nil {
+		return nil, err
+	}
+	if enum, ok := obj["enum"]; ok {
+		values, ok := enum.([]any)
+		if !ok {
+			return nil, c.errorf(append(path, "enum"), "must be an array")
+		}
+		s.enum = values
+	}
+	if constValue, ok := obj["const"]; ok {
+		s.constValue = constValue
+		s.hasConst = true
+	}
+
+	var err error
+	if properties, ok := obj["properties"]; ok {
+		propertiesObj, ok := properties.(map[string]any)
+		if !ok {
+			return nil, c.errorf(append(path, "properties"), "must be an object")
+		}
+		s.properties = make(map[string]*Schema, len(propertiesObj))
+		for name, propertySchema := range propertiesObj {
+			if s.properties[name], err = c.compile(propertySchema, childPath(path, "properties", name)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if required, ok := obj["required"]; ok {
+		if s.required, err = stringList(required); err != nil {
+			return nil, c.errorf(append(path, "required"), "%v", err)
+		}
+	}
+	if additional, ok := obj["additionalProperties"]; ok {
+		if s.additionalProperties, err = c.compile(additional, childPath(path, "additionalProperties")); err != nil {
+			return nil, err
+		}
+	}
+	if items, ok := obj["items"]; ok {
+		if s.items, err = c.compile(items, childPath(path, "items")); err != nil {
+			return nil, err
+		}
+	}
+	if unique, ok := obj["uniqueItems"].(bool); ok {
+		s.uniqueItems = unique
+	}
+
+	for keyword, target := range map[string]**int{
+		"minItems":  &s.minItems,
+		"maxItems":  &s.maxItems,
+		"minLength": &s.minLength,
+		"maxLength": &s.maxLength,
+	} {
+		if raw, ok := obj[keyword]; ok {
+			n, ok := raw.(float64)
+			if !ok || n < 0 || n != math.Trunc(n) {
+				return nil, c.errorf(append(path, keyword), "must be a non-negative integer")
+			}
+			limit := int(n)
+			*target = &limit
+		}
+	}
+	for keyword, target := range map[string]**float64{
+		"minimum":          &s.minimum,
+		"maximum":          &s.maximum,
+		"exclusiveMinimum": &s.exclusiveMinimum,
+		"exclusiveMaximum": &s.exclusiveMaximum,
+	} {
+		if raw, ok := obj[keyword]; ok {
+			n, ok := raw.(float64)
+			if !ok {
+				return nil, c.errorf(append(path, keyword), "must be a number")
+			}
+			*target = &n
+		}
+	}
+
+	if pattern, ok := obj["pattern"]; ok {
+		patternString, ok := pattern.(string)
+		if !ok {
+			return nil, c.errorf(append(path, "pattern"), "must be a string")
+		}
+		if s.pattern, err = regexp.Compile(patternString); err != nil {
+			return nil, c.errorf(append(path, "pattern"), "%v", err)
+		}
+	}
+
+	for keyword, target := range map[string]*[]*Schema{
+		"allOf": &s.allOf,
+		"anyOf": &s.anyOf,
+		"oneOf": &s.oneOf,
+	} {
+		raw, ok := obj[keyword]
+		if !ok {
+			continue
+		}
+		branches, ok := raw.([]any)
+		if !ok || len(branches) == 0 {
+			return nil, c.errorf(append(path, keyword), "must be a non-empty array")
+		}
+		for i, branch := range branches {
+			compiled, err := c.compile(branch, childPath(path, keyword, fmt.Sprint(i)))
+			if err != nil {
+				return nil, err
+			}
+			*target = append(*target, compiled)
+		}
+	}
+	if not, ok := obj["not"]; ok {
+		if s.not, err = c.compile(not, childPath(path, "not")); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, keyword := range []string{"$defs", "definitions"} {
+		defs, ok := obj[keyword].(map[string]any)
+		if !ok {
+			continue
+		}
+		for name, def := range defs {
+			if _, err := c.compile(def, childPath(path, keyword, name)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if ref, ok := obj["$ref"]; ok {
+		refString, ok := ref.(string)
+		if !ok || !strings.HasPrefix(refString, "#") {
+			return nil, c.errorf(append(path, "$ref"), "only local references are supported")
+		}
+		s.ref = refString
+		c.pending = append(c.pending, s)
+	}
+
+	return s, nil
+}
+
+// compileTypes reads the type keyword, which may be a string or a list
+func (c *compiler) compileTypes(s *Schema, obj map[string]any, path []string) error {
+	raw, ok := obj["type"]
+	if !ok {
+		return nil
+	}
+	if single, ok := raw.(string); ok {
+		raw = []any{single}
+	}
+	types, err := stringList(raw)
+	if err != nil {
+		return c.errorf(append(path, "type"), "%v", err)
+	}
+	for _, t := range types {
+		switch t {
+		case "null", "boolean", "object", "array", "number", "integer", "string":
+		default:
+			return c.errorf(append(path, "type"), "unknown type %q", t)
+		}
+	}
+	s.types = types
+	return nil
+}
+
+// resolve links every $ref to its target, compiling targets outside the
+// definitions sections on demand
+func (c *compiler) resolve() error {
+	for len(c.pending) > 0 {
+		s := c.pending[0]
+		c.pending = c.pending[1:]
+
+		tokens, err := jsonpointer.Parse(strings.TrimPrefix(s.ref, "#"))
+		if err != nil {
+			return fmt.Errorf("%w: $ref %s: %v", ErrInvalidSchema, s.ref, err)
+		}
+		target, err := jsonpointer.GetTokens(c.root, tokens)
+		if err != nil {
+			return fmt.Errorf("%w: $ref %s does not resolve", ErrInvalidSchema, s.ref)
+		}
+		if s.resolved, err = c.compile(target, tokens); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkCycles rejects schemas that lead back to themselves through $ref,
+// allOf, anyOf, oneOf and not alone. None of these consume any part of the
+// value, so validating against such a schema would recurse forever.
+func (c *compiler) checkCycles() error {
+	pointers := make([]string, 0, len(c.refs))
+	for pointer := range c.refs {
+		pointers = append(pointers, pointer)
+	}
+	sort.Strings(pointers)
+
+	const (
+		visiting = iota + 1
+		visited
+	)
+	state := make(map[*Schema]int, len(c.refs))
+	var visit func(s *Schema) bool
+	visit = func(s *Schema) bool {
+		switch state[s] {
+		case visiting:
+			return true
+		case visited:
+			return false
+		}
+		state[s] = visiting
+		next := []*Schema{s.resolved, s.not}
+		next = append(next, s.allOf...)
+		next = append(next, s.anyOf...)
+		next = append(next, s.oneOf...)
+		for _, sub := range next {
+			if sub != nil && visit(sub) {
+				return true
+			}
+		}
+		state[s] = visited
+		return false
+	}
+
+	for _, pointer := range pointers {
+		if visit(c.refs[pointer]) {
+			location := pointer
+			if location == "" {
+				location = "/"
+			}
+			return fmt.Errorf("%w at %s: $ref cycle does not consume any part of the value", ErrInvalidSchema, location)
+		}
+	}
+	return nil
+}
+
+// errorf reports a compile error at a location in the schema document
+func (c *compiler) errorf(path []string, format string, args ...any) error {
+	location := jsonpointer.Format(path)
+	if location == "" {
+		location = "/"
+	}
+	return fmt.Errorf("%w at %s: %s", ErrInvalidSchema, location, fmt.Sprintf(format, args...))
+}
+
+// childPath returns a copy of path extended with tokens
+func childPath(path []string, tokens ...string) []string {
+	child := make([]string, 0, len(path)+len(tokens))
+	child = append(child, path...)
+	return append(child, tokens...)
+}
+
+// stringList converts a JSON array of strings
+func stringList(raw any) ([]string, error) {
+	values, ok := raw.([]any)
+	if !ok {
+		return nil, fmt.Errorf("must be an array of strings")
+	}
+	result := make([]string, len(values))
+	for i, value := range values {
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("must be an array of strings")
+		}
+		result[i] = s
+	}
+	return result, nil
+}
+
+// validate appends violations of value found at path to errs
+func (s *Schema) validate(value any, path []string, errs *[]FieldError) {
+	fail := func(format string, args ...any) {
+		*errs = append(*errs, FieldError{Path: jsonpointer.Format(path), Message: fmt.Sprintf(format, args...)})
+	}
+
+	if s.always != nil {
+		if !*s.always {
+			fail("no value is allowed")
+		}
+		return
+	}
+	if s.resolved != nil {
+		s.resolved.validate(value, path, errs)
+	}
+
+	if len(s.types) > 0 && !matchesAnyType(value, s.types) {
+		fail("expected %s, got %s", strings.Join(s.types, " or "), typeName(value))
+		return
+	}
+	if s.enum != nil && !containsValue(s.enum, value) {
+		fail("value %s is not one of the allowed values", describe(value))
+	}
+	if s.hasConst && !reflect.DeepEqual(s.constValue, value) {
+		fail("value must be %s", describe(s.constValue))
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		s.validateObject(v, path, errs, fail)
+	case []any:
+		s.validateArray(v, path, errs, fail)
+	case string:
+		length := utf8.RuneCountInString(v)
+		if s.minLength != nil && length < *s.minLength {
+			fail("length %d is shorter than %d", length, *s.minLength)
+		}
+		if s.maxLength != nil && length > *s.maxLength {
+			fail("length %d is longer than %d", length, *s.maxLength)
+		}
+		if s.pattern != nil && !s.pattern.MatchString(v) {
+			fail("value does not match pattern %s", s.pattern)
+		}
+	case float64:
+		if s.minimum != nil && v < *s.minimum {
+			fail("value %v is less than %v", v, *s.minimum)
+		}
+		if s.maximum != nil && v > *s.maximum {
+			fail("value %v is greater than %v", v, *s.maximum)
+		}
+		if s.exclusiveMinimum != nil && v <= *s.exclusiveMinimum {
+			fail("value %v must be greater than %v", v, *s.exclusiveMinimum)
+		}
+		if s.exclusiveMaximum != nil && v >= *s.exclusiveMaximum {
+			fail("value %v must be less than %v", v, *s.exclusiveMaximum)
+		}
+	}
+
+	for _, branch := range s.allOf {
+		branch.validate(value, path, errs)
+	}
+	if len(s.anyOf) > 0 && countMatches(s.anyOf, value, path) == 0 {
+		fail("value does not match any of the allowed schemas")
+	}
+	if len(s.oneOf) > 0 {
+		if matches := countMatches(s.oneOf, value, path); matches != 1 {
+			fail("value matches %d schemas, expected exactly one", matches)
+		}
+	}
+	if s.not != nil && countMatches([]*Schema{s.not}, value, path) == 1 {
+		fail("value must not match the excluded schema")
+	}
+}
+
+// validateObject checks object keywords
+func (s *Schema) validateObject(obj map[string]any, path []string, errs *[]FieldError, fail func(string, ...any)) {
+	for _, name := range s.required {
+		if _, ok := obj[name]; !ok {
+			fail("missing required property %q", name)
+		}
+	}
+
+	names := make([]string, 0, len(obj))
+	for name := range obj {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if property, ok := s.properties[name]; ok {
+			property.validate(obj[name], childPath(path, name), errs)
+			continue
+		}
+		if s.additionalProperties != nil {
+			if s.additionalProperties.always != nil && !*s.additionalProperties.always {
+				*errs = append(*errs, FieldError{Path: jsonpointer.Format(childPath(path, name)), Message: "property is not allowed"})
+				continue
+			}
+			s.additionalProperties.validate(obj[name], childPath(path, name), errs)
+		}
+	}
+}
+
+// validateArray checks array keywords
+func (s *Schema) validateArray(items []any, path []string, errs *[]FieldError, fail func(string, ...any)) {
+	if s.minItems != nil && len(items) < *s.minItems {
+		fail("array has %d items, fewer than %d", len(items), *s.minItems)
+	}
+	if s.maxItems != nil && len(items) > *s.maxItems {
+		fail("array has %d items, more than %d", len(items), *s.maxItems)
+	}
+	if s.uniqueItems {
+		for i := 1; i < len(items); i++ {
+			if containsValue(items[:i], items[i]) {
+				fail("array items must be unique, item %d is a duplicate", i)
+				break
+			}
+		}
+	}
+	if s.items != nil {
+		for i, item := range items {
+			s.items.validate(item, childPath(path, fmt.Sprint(i)), errs)
+		}
+	}
+}
+
+// countMatches returns how many schemas accept value
+func countMatches(schemas []*Schema, value any, path []string) int {
+	matches := 0
+	for _, branch := range schemas {
+		var branchErrs []FieldError
+		branch.validate(value, path, &branchErrs)
+		if len(branchErrs) == 0 {
+			matches++
+		}
+	}
+	return matches
+}
+
+// matchesAnyType reports whether value is one of the named JSON types
+func matchesAnyType(value any, types []string) bool {
+	actual := typeName(value)
+	for _, t := range types {
+		if t == actual {
+			return true
+		}
+		if t == "number" && actual == "integer" {
+			return true
+		}
+	}
+	return false
+}
+
+// typeName returns the JSON type of a decoded value; whole numbers are integers
+func typeName(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	case float64:
+		if v == math.Trunc(v) && !math.IsInf(v, 0) {
+			return "integer"
+		}
+		return "number"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// containsValue reports whether values contains value
+func containsValue(values []any, value any) bool {
+	for _, candidate := range values {
+		if reflect.DeepEqual(candidate, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// describe renders a value as compact JSON for error messages
+func describe(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
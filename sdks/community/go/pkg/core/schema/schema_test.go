@@ -0,0 +1,149 @@
+package schema
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const planSchema = `{
+	"type": "object",
+	"required": ["title", "steps"],
+	"additionalProperties": false,
+	"properties": {
+		"title": {"type": "string", "minLength": 1},
+		"priority": {"enum": ["low", "high"]},
+		"steps": {
+			"type": "array",
+			"minItems": 1,
+			"items": {"$ref": "#/$defs/step"}
+		}
+	},
+	"$defs": {
+		"step": {
+			"type": "object",
+			"required": ["id"],
+			"properties": {
+				"id": {"type": "integer", "minimum": 1},
+				"status": {"type": "string", "pattern": "^(todo|done)$"}
+			}
+		}
+	}
+}`
+
+func TestValidate_Valid(t *testing.T) {
+	s, err := Compile([]byte(planSchema))
+	require.NoError(t, err)
+
+	assert.NoError(t, s.Validate(map[string]any{
+		"title":    "ship it",
+		"priority": "high",
+		"steps":    []any{map[string]any{"id": 1, "status": "done"}, map[string]any{"id": 2}},
+	}))
+
+	// Go structs are validated through their JSON form
+	type step struct {
+		ID int `json:"id"`
+	}
+	assert.NoError(t, s.Validate(map[string]any{"title": "t", "steps": []step{{ID: 3}}}))
+}
+
+func TestValidate_ListsEveryFailure(t *testing.T) {
+	s, err := Compile([]byte(planSchema))
+	require.NoError(t, err)
+
+	err = s.Validate(map[string]any{
+		"priority": "urgent",
+		"extra":    true,
+		"steps":    []any{map[string]any{"id": 0.5, "status": "blocked"}, map[string]any{}},
+	})
+	require.Error(t, err)
+
+	var validationErr *ValidationError
+	require.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, []FieldError{
+		{Path: "", Message: `missing required property "title"`},
+		{Path: "/extra", Message: "property is not allowed"},
+		{Path: "/priority", Message: `value "urgent" is not one of the allowed values`},
+		{Path: "/steps/0/id", Message: "expected integer, got number"},
+		{Path: "/steps/0/status", Message: "value does not match pattern ^(todo|done)$"},
+		{Path: "/steps/1", Message: `missing required property "id"`},
+	}, validationErr.Errors)
+	assert.Contains(t, err.Error(), `schema validation failed: missing required property "title"; /extra: property is not allowed`)
+}
+
+func TestValidate_Keywords(t *testing.T) {
+	cases := []struct {
+		name   string
+		schema string
+		valid  []any
+		reject []any
+	}{
+		{"Boolean", `false`, nil, []any{1, "a"}},
+		{"TypeList", `{"type":["string","null"]}`, []any{"a", nil}, []any{1.0}},
+		{"Number", `{"type":"number","exclusiveMaximum":10,"maximum":20}`, []any{1, 9.5}, []any{10, "1"}},
+		{"Const", `{"const":null}`, []any{nil}, []any{false}},
+		{"Length", `{"maxLength":2}`, []any{"é", "ab", 12345}, []any{"abc"}},
+		{"Unique", `{"uniqueItems":true,"maxItems":3}`, []any{[]any{1, 2}}, []any{[]any{1, 1}, []any{1, 2, 3, 4}}},
+		{"AnyOf", `{"anyOf":[{"type":"string"},{"minimum":5}]}`, []any{"a", 6}, []any{1}},
+		{"OneOf", `{"oneOf":[{"type":"integer"},{"minimum":0}]}`, []any{-1, 0.5}, []any{3}},
+		{"AllOfNot", `{"allOf":[{"type":"integer"}],"not":{"const":0}}`, []any{1}, []any{0, 1.5}},
+		{"Recursive", `{"type":"object","properties":{"child":{"$ref":"#"}}}`,
+			[]any{map[string]any{"child": map[string]any{"child": map[string]any{}}}},
+			[]any{map[string]any{"child": map[string]any{"child": 1}}}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := Compile([]byte(tc.schema))
+			require.NoError(t, err)
+			for _, value := range tc.valid {
+				assert.NoError(t, s.Validate(value), "%v", value)
+			}
+			for _, value := range tc.reject {
+				assert.Error(t, s.Validate(value), "%v", value)
+			}
+		})
+	}
+}
+
+func TestCompile_Errors(t *testing.T) {
+	for _, schema := range []string{
+		`{`,
+		`"object"`,
+		`{"type":"map"}`,
+		`{"required":"id"}`,
+		`{"pattern":"("}`,
+		`{"minItems":-1}`,
+		`{"anyOf":[]}`,
+		`{"$ref":"#/$defs/missing"}`,
+		`{"$ref":"https://example.com/schema.json"}`,
+		`{"properties":{"a":{"type":1}}}`,
+	} {
+		_, err := Compile([]byte(schema))
+		require.Error(t, err, schema)
+		assert.True(t, errors.Is(err, ErrInvalidSchema), schema)
+	}
+
+	assert.Panics(t, func() { MustCompile([]byte(`{`)) })
+}
+
+func TestCompile_RefCycles(t *testing.T) {
+	for _, schema := range []string{
+		`{"$ref":"#"}`,
+		`{"$defs":{"a":{"$ref":"#/$defs/b"},"b":{"$ref":"#/$defs/a"}},"$ref":"#/$defs/a"}`,
+		`{"$defs":{"a":{"allOf":[{"$ref":"#/$defs/a"}]}},"type":"object"}`,
+		`{"anyOf":[{"type":"string"},{"not":{"$ref":"#"}}]}`,
+	} {
+		_, err := Compile([]byte(schema))
+		require.Error(t, err, schema)
+		assert.True(t, errors.Is(err, ErrInvalidSchema), schema)
+	}
+
+	// Recursion through a keyword that descends into the value is fine
+	s, err := Compile([]byte(`{"$defs":{"node":{"type":"array","items":{"$ref":"#/$defs/node"}}},"$ref":"#/$defs/node"}`))
+	require.NoError(t, err)
+	assert.NoError(t, s.Validate([]any{[]any{}, []any{[]any{}}}))
+	assert.Error(t, s.Validate([]any{1}))
+}
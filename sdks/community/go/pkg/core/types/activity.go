@@ -0,0 +1,153 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// ErrActivityStructNotRegistered is returned by ActivityValue for activity
+// types without a registered struct
+var ErrActivityStructNotRegistered = errors.New("no struct registered for activity type")
+
+var (
+	activityStructsMu sync.RWMutex
+	activityStructs   = map[string]reflect.Type{}
+)
+
+// RegisterActivityStruct binds an activity type to a Go struct so that
+// ActivityValue decodes its content into that struct and ActivityInto checks
+// that callers decode into it. proto is a value or pointer of the struct
+// type, for example PlanActivity{} or (*PlanActivity)(nil). Registering an
+// existing type replaces its struct. It panics if proto is not a struct.
+func RegisterActivityStruct(activityType string, proto any) {
+	t := reflect.TypeOf(proto)
+	if t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("RegisterActivityStruct: %T is not a struct", proto))
+	}
+
+	activityStructsMu.Lock()
+	defer activityStructsMu.Unlock()
+	activityStructs[activityType] = t
+}
+
+// UnregisterActivityStruct removes the struct registered for an activity type
+func UnregisterActivityStruct(activityType string) {
+	activityStructsMu.Lock()
+	defer activityStructsMu.Unlock()
+	delete(activityStructs, activityType)
+}
+
+// lookupActivityStruct returns the struct registered for an activity type
+func lookupActivityStruct(activityType string) (reflect.Type, bool) {
+	activityStructsMu.RLock()
+	defer activityStructsMu.RUnlock()
+	t, ok := activityStructs[activityType]
+	return t, ok
+}
+
+// ActivityInto decodes the content of an activity message into v, which must
+// be a pointer to a struct. When a struct is registered for the message's
+// activity type, v must point to that struct. Fields whose json tag lacks
+// omitempty are required and must be present in the content.
+func (m Message) ActivityInto(v any) error {
+	if m.Role != RoleActivity {
+		return fmt.Errorf("message %s is a %s message, not an activity", m.ID, m.Role)
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("activity target must be a non-nil pointer to a struct, got %T", v)
+	}
+	target := rv.Elem().Type()
+	if registered, ok := lookupActivityStruct(m.ActivityType); ok && registered != target {
+		return fmt.Errorf("activity type %s is registered as %s, not %s", m.ActivityType, registered, target)
+	}
+
+	data, err := json.Marshal(m.Content)
+	if err != nil {
+		return fmt.Errorf("failed to encode content of activity %s: %w", m.ID, err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return fmt.Errorf("content of activity %s is not an object", m.ID)
+	}
+	if missing := missingRequiredFields(target, fields); len(missing) > 0 {
+		return fmt.Errorf("content of activity %s is missing required fields: %s", m.ID, strings.Join(missing, ", "))
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to decode content of activity %s: %w", m.ID, err)
+	}
+	return nil
+}
+
+// ActivityValue decodes the content of an activity message into a new value of
+// the struct registered for its activity type and returns a pointer to it.
+// It returns an error wrapping ErrActivityStructNotRegistered when no struct
+// is registered.
+func (m Message) ActivityValue() (any, error) {
+	t, ok := lookupActivityStruct(m.ActivityType)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrActivityStructNotRegistered, m.ActivityType)
+	}
+	v := reflect.New(t).Interface()
+	if err := m.ActivityInto(v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// missingRequiredFields lists the JSON names of the fields of struct type t
+// without omitempty that are absent from fields. Fields of embedded structs
+// are checked as if they belonged to t, as in encoding/json.
+func missingRequiredFields(t reflect.Type, fields map[string]json.RawMessage) []string {
+	var missing []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				missing = append(missing, missingRequiredFields(embedded, fields)...)
+				continue
+			}
+		}
+		if !field.IsExported() || hasTagOption(options, "omitempty") {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if _, ok := fields[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
+// hasTagOption reports whether a comma separated list of struct tag options
+// contains option
+func hasTagOption(options, option string) bool {
+	for options != "" {
+		var current string
+		current, options, _ = strings.Cut(options, ",")
+		if current == option {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type planStep struct {
+	Title string `json:"title"`
+	Done  bool   `json:"done,omitempty"`
+}
+
+type planActivity struct {
+	Steps []planStep `json:"steps"`
+	Note  string     `json:"note,omitempty"`
+}
+
+type thinkingActivity struct {
+	Text string `json:"text"`
+}
+
+func TestActivityInto(t *testing.T) {
+	RegisterActivityStruct("PLAN", (*planActivity)(nil))
+	t.Cleanup(func() { UnregisterActivityStruct("PLAN") })
+
+	msg := Message{
+		ID:           "act-1",
+		Role:         RoleActivity,
+		ActivityType: "PLAN",
+		Content: map[string]any{
+			"steps": []any{map[string]any{"title": "search", "done": true}},
+		},
+	}
+
+	var plan planActivity
+	require.NoError(t, msg.ActivityInto(&plan))
+	assert.Equal(t, planActivity{Steps: []planStep{{Title: "search", Done: true}}}, plan)
+
+	value, err := msg.ActivityValue()
+	require.NoError(t, err)
+	assert.Equal(t, &plan, value)
+
+	// Content decoded from JSON arrives as raw JSON
+	msg.Content = json.RawMessage(`{"steps":[],"note":"empty"}`)
+	require.NoError(t, msg.ActivityInto(&plan))
+	assert.Equal(t, "empty", plan.Note)
+
+	msg.Content = map[string]any{"note": "no steps"}
+	err = msg.ActivityInto(&plan)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "missing required fields: steps")
+
+	var thinking thinkingActivity
+	err = msg.ActivityInto(&thinking)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "registered as types.planActivity")
+
+	// Unregistered types decode into the provided struct
+	thought := Message{ID: "act-2", Role: RoleActivity, ActivityType: "THINKING", Content: map[string]any{"text": "hmm"}}
+	require.NoError(t, thought.ActivityInto(&thinking))
+	assert.Equal(t, "hmm", thinking.Text)
+	_, err = thought.ActivityValue()
+	assert.ErrorIs(t, err, ErrActivityStructNotRegistered)
+
+	assert.Error(t, thought.ActivityInto(thinking), "target must be a pointer")
+	assert.Error(t, Message{ID: "msg-1", Role: RoleUser, Content: "hi"}.ActivityInto(&thinking))
+	assert.Panics(t, func() { RegisterActivityStruct("BAD", "not a struct") })
+}
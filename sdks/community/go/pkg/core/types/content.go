@@ -0,0 +1,225 @@
+package types
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ErrMimeTypeMismatch is returned by ResolveData when the resolved bytes are
+// of a different media type than the content declares
+var ErrMimeTypeMismatch = errors.New("content does not match declared mime type")
+
+// ErrInvalidDataURI is returned for malformed data: URIs
+var ErrInvalidDataURI = errors.New("invalid data URI")
+
+// defaultDataURIMimeType is the media type of data URIs that do not name one (RFC 2397)
+const defaultDataURIMimeType = "text/plain;charset=US-ASCII"
+
+// IsDataURI reports whether uri uses the data: scheme
+func IsDataURI(uri string) bool {
+	return len(uri) >= 5 && strings.EqualFold(uri[:5], "data:")
+}
+
+// ParseDataURI decodes a data: URI (RFC 2397) such as
+// data:image/png;base64,iVBORw0KGgo= and returns its bytes and mime type.
+// Base64 payloads may use the standard or URL-safe alphabet; other payloads
+// are percent-decoded.
+func ParseDataURI(uri string) ([]byte, string, error) {
+	if !IsDataURI(uri) {
+		return nil, "", fmt.Errorf("%w: missing data: scheme", ErrInvalidDataURI)
+	}
+
+	header, payload, ok := strings.Cut(uri[5:], ",")
+	if !ok {
+		return nil, "", fmt.Errorf("%w: missing comma before the data", ErrInvalidDataURI)
+	}
+
+	isBase64 := false
+	if strings.HasSuffix(strings.ToLower(header), ";base64") {
+		isBase64 = true
+		header = header[:len(header)-len(";base64")]
+	}
+
+	mimeType := defaultDataURIMimeType
+	if header != "" {
+		if strings.HasPrefix(header, ";") {
+			header = "text/plain" + header
+		}
+		if _, _, err := mime.ParseMediaType(header); err != nil {
+			return nil, "", fmt.Errorf("%w: bad media type %q: %v", ErrInvalidDataURI, header, err)
+		}
+		mimeType = header
+	}
+
+	if isBase64 {
+		data, err := decodeBase64(payload)
+		if err != nil {
+			return nil, "", fmt.Errorf("%w: bad base64 data: %v", ErrInvalidDataURI, err)
+		}
+		return data, mimeType, nil
+	}
+
+	data, err := url.PathUnescape(payload)
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: bad percent-encoding: %v", ErrInvalidDataURI, err)
+	}
+	return []byte(data), mimeType, nil
+}
+
+// ValidateAudio checks the audio fields of a fragment. SampleRate, Channels
+// and Encoding are only valid when the fragment's mime type (or that of its
+// source) starts with "audio/", and SampleRate and Channels must be positive.
+func (c InputContent) ValidateAudio() error {
+	if c.SampleRate == 0 && c.Channels == 0 && c.Encoding == "" {
+		return nil
+	}
+
+	mimeType := c.MimeType
+	if mimeType == "" && c.Source != nil {
+		mimeType = c.Source.MimeType
+	}
+	if !strings.HasPrefix(strings.ToLower(mimeType), "audio/") {
+		return fmt.Errorf("sampleRate, channels and encoding require an audio/ mime type, got %q", mimeType)
+	}
+	if c.SampleRate < 0 {
+		return fmt.Errorf("sampleRate must be positive, got %d", c.SampleRate)
+	}
+	if c.Channels < 0 {
+		return fmt.Errorf("channels must be positive, got %d", c.Channels)
+	}
+	return nil
+}
+
+// ResolveData returns the bytes and mime type of a binary or typed multimodal
+// fragment. Inline base64 data and data: URLs are decoded without a fetch;
+// any other URL is passed to fetch. A data: URL supplies the mime type when
+// the fragment does not declare one. When the fragment declares a mime type,
+// the bytes are sniffed and ErrMimeTypeMismatch is returned if they are
+// clearly of another media type; without a declared type the sniffed type is
+// returned.
+func (c InputContent) ResolveData(ctx context.Context, fetch func(url string) ([]byte, error)) ([]byte, string, error) {
+	if c.Type == InputContentTypeText {
+		return nil, "", fmt.Errorf("text content has no binary data")
+	}
+
+	mimeType, url, data := c.MimeType, c.URL, c.Data
+	if c.Source != nil {
+		if c.Source.MimeType != "" {
+			mimeType = c.Source.MimeType
+		}
+		switch c.Source.Type {
+		case InputContentSourceTypeURL:
+			url = c.Source.Value
+		case InputContentSourceTypeData:
+			data = c.Source.Value
+		}
+	}
+
+	var payload []byte
+	switch {
+	case data == "" && IsDataURI(url):
+		decoded, uriMimeType, err := ParseDataURI(url)
+		if err != nil {
+			return nil, "", err
+		}
+		if mimeType == "" && uriMimeType != defaultDataURIMimeType {
+			mimeType = uriMimeType
+		}
+		payload = decoded
+	case data != "":
+		decoded, err := decodeBase64(data)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid base64 data for %s content: %w", c.Type, err)
+		}
+		payload = decoded
+	case url != "":
+		if fetch == nil {
+			return nil, "", fmt.Errorf("cannot fetch %s content from %s: no fetch function", c.Type, url)
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, "", err
+		}
+		fetched, err := fetch(url)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to fetch %s content from %s: %w", c.Type, url, err)
+		}
+		payload = fetched
+	case c.ID != "":
+		return nil, "", fmt.Errorf("%s content %s is only referenced by id and cannot be resolved", c.Type, c.ID)
+	default:
+		return nil, "", fmt.Errorf("%s content has no data, url or id", c.Type)
+	}
+
+	sniffed := http.DetectContentType(payload)
+	if mimeType == "" {
+		return payload, sniffed, nil
+	}
+	if err := checkMimeType(mimeType, sniffed); err != nil {
+		return nil, "", err
+	}
+	return payload, mimeType, nil
+}
+
+// decodeBase64 decodes standard or URL-safe base64, with or without padding
+func decodeBase64(data string) ([]byte, error) {
+	data = strings.TrimRight(strings.TrimSpace(data), "=")
+	if strings.ContainsAny(data, "-_") {
+		return base64.RawURLEncoding.DecodeString(data)
+	}
+	return base64.RawStdEncoding.DecodeString(data)
+}
+
+// unambiguousMimeTypes are the sniffed types whose signature identifies the
+// media type exactly. Container formats such as WebM, Ogg, MP4 and ZIP carry
+// many media types, so sniffing reports them as video/webm, application/ogg,
+// video/mp4 or application/zip whatever they hold.
+var unambiguousMimeTypes = map[string]bool{
+	"image/png":       true,
+	"image/jpeg":      true,
+	"image/gif":       true,
+	"image/webp":      true,
+	"application/pdf": true,
+}
+
+// checkMimeType compares a declared mime type with the sniffed one. Only a
+// sniffed type with an unambiguous signature can count as a mismatch, so
+// audio/webm is not rejected for sniffing as video/webm.
+func checkMimeType(declared, sniffed string) error {
+	sniffedType, _, err := mime.ParseMediaType(sniffed)
+	if err != nil || !unambiguousMimeTypes[sniffedType] {
+		return nil
+	}
+
+	declaredType, _, err := mime.ParseMediaType(declared)
+	if err != nil {
+		return fmt.Errorf("invalid mime type %q: %w", declared, err)
+	}
+	if normalizeMimeType(declaredType) != normalizeMimeType(sniffedType) {
+		return fmt.Errorf("%w: declared %s, detected %s", ErrMimeTypeMismatch, declaredType, sniffedType)
+	}
+	return nil
+}
+
+// mimeAliases maps non-standard mime type spellings to their canonical form
+var mimeAliases = map[string]string{
+	"image/jpg":      "image/jpeg",
+	"image/pjpeg":    "image/jpeg",
+	"audio/mp3":      "audio/mpeg",
+	"audio/x-wav":    "audio/wave",
+	"audio/wav":      "audio/wave",
+	"audio/vnd.wave": "audio/wave",
+}
+
+// normalizeMimeType returns the canonical spelling of a mime type
+func normalizeMimeType(mimeType string) string {
+	if alias, ok := mimeAliases[mimeType]; ok {
+		return alias
+	}
+	return mimeType
+}
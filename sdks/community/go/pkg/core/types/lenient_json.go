@@ -0,0 +1,164 @@
+package types
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// relaxJSON rewrites the relaxed JSON models commonly produce into strict
+// JSON: it removes Markdown code fences, comments and trailing commas,
+// converts single-quoted strings and unquoted object keys to double-quoted
+// strings, escapes raw control characters in strings and maps the Python
+// literals True, False and None to their JSON equivalents. Input that is not
+// recognized is copied unchanged, so the result may still be invalid.
+func relaxJSON(src string) string {
+	src = stripCodeFence(src)
+
+	var out strings.Builder
+	out.Grow(len(src))
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == '"' || c == '\'':
+			i = relaxString(src, i, &out)
+
+		case c == '/' && i+1 < len(src) && (src[i+1] == '/' || src[i+1] == '*'):
+			i = skipComment(src, i)
+
+		case c == ',':
+			if next := skipInsignificant(src, i+1); next < len(src) && (src[next] == '}' || src[next] == ']') {
+				i = next
+				continue
+			}
+			out.WriteByte(c)
+			i++
+
+		case isIdentStart(c):
+			end := i + 1
+			for end < len(src) && isIdentPart(src[end]) {
+				end++
+			}
+			ident := src[i:end]
+			next := skipInsignificant(src, end)
+			switch {
+			case next < len(src) && src[next] == ':':
+				out.WriteByte('"')
+				out.WriteString(ident)
+				out.WriteByte('"')
+			case ident == "True":
+				out.WriteString("true")
+			case ident == "False":
+				out.WriteString("false")
+			case ident == "None":
+				out.WriteString("null")
+			default:
+				out.WriteString(ident)
+			}
+			i = end
+
+		default:
+			out.WriteByte(c)
+			i++
+		}
+	}
+	return out.String()
+}
+
+// relaxString writes the string starting at src[start] as a double-quoted
+// JSON string and returns the offset after it. An unterminated string is
+// copied unchanged.
+func relaxString(src string, start int, out *strings.Builder) int {
+	quote := src[start]
+	var body strings.Builder
+	for i := start + 1; i < len(src); {
+		c := src[i]
+		switch {
+		case c == quote:
+			out.WriteByte('"')
+			out.WriteString(body.String())
+			out.WriteByte('"')
+			return i + 1
+		case c == '\\' && i+1 < len(src):
+			if src[i+1] == '\'' {
+				body.WriteByte('\'')
+			} else {
+				body.WriteString(src[i : i+2])
+			}
+			i += 2
+			continue
+		case c == '"':
+			body.WriteString(`\"`)
+		case c == '\n':
+			body.WriteString(`\n`)
+		case c == '\r':
+			body.WriteString(`\r`)
+		case c == '\t':
+			body.WriteString(`\t`)
+		case c < 0x20:
+			body.WriteString(`\u00`)
+			body.WriteByte("0123456789abcdef"[c>>4])
+			body.WriteByte("0123456789abcdef"[c&0xf])
+		default:
+			_, size := utf8.DecodeRuneInString(src[i:])
+			body.WriteString(src[i : i+size])
+			i += size
+			continue
+		}
+		i++
+	}
+	out.WriteString(src[start:])
+	return len(src)
+}
+
+// skipComment returns the offset after the // or /* comment at src[start]
+func skipComment(src string, start int) int {
+	if src[start+1] == '/' {
+		if end := strings.IndexByte(src[start:], '\n'); end >= 0 {
+			return start + end
+		}
+		return len(src)
+	}
+	if end := strings.Index(src[start+2:], "*/"); end >= 0 {
+		return start + 2 + end + 2
+	}
+	return len(src)
+}
+
+// skipInsignificant returns the offset of the first byte at or after i that
+// is neither whitespace nor part of a comment
+func skipInsignificant(src string, i int) int {
+	for i < len(src) {
+		switch {
+		case src[i] == ' ' || src[i] == '\t' || src[i] == '\n' || src[i] == '\r':
+			i++
+		case src[i] == '/' && i+1 < len(src) && (src[i+1] == '/' || src[i+1] == '*'):
+			i = skipComment(src, i)
+		default:
+			return i
+		}
+	}
+	return i
+}
+
+// stripCodeFence removes a Markdown code fence around the document
+func stripCodeFence(src string) string {
+	trimmed := strings.TrimSpace(src)
+	if !strings.HasPrefix(trimmed, "```") {
+		return src
+	}
+	newline := strings.IndexByte(trimmed, '\n')
+	if newline < 0 {
+		return src
+	}
+	return strings.TrimSuffix(strings.TrimSpace(trimmed[newline+1:]), "```")
+}
+
+// isIdentStart reports whether c can start an unquoted key
+func isIdentStart(c byte) bool {
+	return c == '_' || c == '$' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// isIdentPart reports whether c can continue an unquoted key
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || (c >= '0' && c <= '9')
+}
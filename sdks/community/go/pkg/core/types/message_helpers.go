@@ -1,8 +1,16 @@
 package types
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
 
-// ContentString returns the content as a string when the underlying value is string-like.
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/internal/deepcopy"
+)
+
+// ContentString returns the content as a string when the underlying value is
+// string-like. The content parts of an assistant message are flattened by
+// concatenating their text.
 func (m Message) ContentString() (string, bool) {
 	if m.Role == RoleActivity {
 		return "", false
@@ -27,7 +35,33 @@ func (m Message) ContentString() (string, bool) {
 		}
 		return text, true
 	default:
-		return "", false
+		parts, ok := m.ContentAssistantContents()
+		if !ok {
+			return "", false
+		}
+		var b strings.Builder
+		for _, part := range parts {
+			b.WriteString(part.Text)
+		}
+		return b.String(), true
+	}
+}
+
+// ContentAssistantContents returns the content as []AssistantContent for
+// assistant messages when the underlying value is a non-empty content part
+// array.
+func (m Message) ContentAssistantContents() ([]AssistantContent, bool) {
+	if m.Role != RoleAssistant {
+		return nil, false
+	}
+
+	switch value := m.Content.(type) {
+	case []AssistantContent:
+		return value, len(value) > 0
+	case []any:
+		return decodeAssistantContents(value)
+	default:
+		return nil, false
 	}
 }
 
@@ -52,6 +86,34 @@ func (m Message) ContentInputContents() ([]InputContent, bool) {
 	}
 }
 
+// ContentParts returns the multimodal content parts of a message. It is a shorthand
+// for ContentInputContents and reports false when the content is not a parts array.
+func (m Message) ContentParts() ([]InputContent, bool) {
+	return m.ContentInputContents()
+}
+
+// Text returns a displayable string for the message content. Plain string content
+// is returned as is; for multimodal content the text parts are concatenated and
+// binary parts are ignored. Other content yields an empty string.
+func (m Message) Text() string {
+	if text, ok := m.ContentString(); ok {
+		return text
+	}
+
+	parts, ok := m.ContentParts()
+	if !ok {
+		return ""
+	}
+
+	var b strings.Builder
+	for _, part := range parts {
+		if part.Type == InputContentTypeText {
+			b.WriteString(part.Text)
+		}
+	}
+	return b.String()
+}
+
 // ContentActivity returns the content as map[string]any for activity messages when the underlying value is an object.
 func (m Message) ContentActivity() (map[string]any, bool) {
 	if m.Role != RoleActivity {
@@ -74,6 +136,50 @@ func (m Message) ContentActivity() (map[string]any, bool) {
 	}
 }
 
+// argumentsConfig holds the settings applied by ArgumentsOption values
+type argumentsConfig struct {
+	lenient bool
+}
+
+// ArgumentsOption defines options for decoding tool call arguments
+type ArgumentsOption func(*argumentsConfig)
+
+// LenientArguments retries arguments that are not valid JSON after repairing
+// common model mistakes: Markdown code fences, comments, trailing commas,
+// single-quoted strings, unquoted keys, raw newlines in strings and the
+// Python literals True, False and None. If the repaired arguments still do
+// not decode, the error from the strict attempt is returned.
+func LenientArguments() ArgumentsOption {
+	return func(c *argumentsConfig) {
+		c.lenient = true
+	}
+}
+
+// ArgumentsMap decodes the JSON-encoded arguments into a map. Empty arguments
+// yield an empty map. Decoding is strict unless LenientArguments is given.
+func (f FunctionCall) ArgumentsMap(options ...ArgumentsOption) (map[string]any, error) {
+	config := argumentsConfig{}
+	for _, opt := range options {
+		opt(&config)
+	}
+
+	args := map[string]any{}
+	if f.Arguments == "" {
+		return args, nil
+	}
+	err := json.Unmarshal([]byte(f.Arguments), &args)
+	if err != nil && config.lenient {
+		relaxed := map[string]any{}
+		if json.Unmarshal([]byte(relaxJSON(f.Arguments)), &relaxed) == nil {
+			return relaxed, nil
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode arguments for function %s: %w", f.Name, err)
+	}
+	return args, nil
+}
+
 // decodeInputContents converts a JSON-decoded array into []InputContent.
 func decodeInputContents(value []any) ([]InputContent, bool) {
 	if value == nil {
@@ -92,6 +198,24 @@ func decodeInputContents(value []any) ([]InputContent, bool) {
 	return parts, true
 }
 
+// decodeAssistantContents converts a JSON-decoded array into []AssistantContent.
+func decodeAssistantContents(value []any) ([]AssistantContent, bool) {
+	if len(value) == 0 {
+		return nil, false
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, false
+	}
+
+	var parts []AssistantContent
+	if err := json.Unmarshal(data, &parts); err != nil {
+		return nil, false
+	}
+	return parts, true
+}
+
 // inputContentsHaveBinaryPayload reports whether every binary fragment satisfies required constraints.
 func inputContentsHaveBinaryPayload(parts []InputContent) bool {
 	for _, part := range parts {
@@ -103,3 +227,10 @@ func inputContentsHaveBinaryPayload(parts []InputContent) bool {
 	}
 	return true
 }
+
+// Clone returns a deep copy of the message. Content, tool calls and any maps
+// or slices they hold are copied, so the clone can be modified without
+// affecting the original.
+func (m Message) Clone() Message {
+	return deepcopy.Copy(m)
+}
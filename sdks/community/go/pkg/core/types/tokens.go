@@ -0,0 +1,183 @@
+package types
+
+import (
+	"encoding/json"
+	"strings"
+	"unicode/utf8"
+)
+
+// TokenCounter estimates how many model tokens a piece of text uses. Hosts
+// plug in the tokenizer of their model; DefaultTokenCounter is a heuristic.
+type TokenCounter interface {
+	Count(text string) int
+}
+
+// TokenCounterFunc adapts a function to the TokenCounter interface
+type TokenCounterFunc func(text string) int
+
+// Count implements TokenCounter
+func (f TokenCounterFunc) Count(text string) int {
+	return f(text)
+}
+
+// charsPerToken is the average number of characters per token assumed by
+// DefaultTokenCounter, a common rule of thumb for English text
+const charsPerToken = 4
+
+// DefaultTokenCounter estimates one token per four characters, rounding up
+var DefaultTokenCounter TokenCounter = TokenCounterFunc(func(text string) int {
+	return (utf8.RuneCountInString(text) + charsPerToken - 1) / charsPerToken
+})
+
+// ByteLen returns the size in bytes of the message content: the length of
+// string content, the sum of the text, inline data and URLs of multimodal
+// parts, or the length of the JSON encoding of structured content such as
+// activity content.
+func (m Message) ByteLen() int {
+	if text, ok := m.ContentString(); ok {
+		return len(text)
+	}
+
+	if parts, ok := contentParts(m.Content); ok {
+		size := 0
+		for _, part := range parts {
+			size += len(part.Text) + len(part.Data) + len(part.URL)
+			if part.Source != nil {
+				size += len(part.Source.Value)
+			}
+		}
+		return size
+	}
+
+	if m.Content == nil {
+		return 0
+	}
+	data, err := json.Marshal(m.Content)
+	if err != nil {
+		return 0
+	}
+	return len(data)
+}
+
+// EstimateTokens estimates the tokens used by a conversation, counting the
+// text of each message's content, its reasoning and the names and arguments
+// of its tool calls. Binary content parts are not counted, since their cost
+// depends on the model. A nil counter uses DefaultTokenCounter.
+func EstimateTokens(msgs []Message, tc TokenCounter) int {
+	if tc == nil {
+		tc = DefaultTokenCounter
+	}
+
+	total := 0
+	for _, msg := range msgs {
+		total += tc.Count(msg.tokenText())
+		if msg.Reasoning != "" {
+			total += tc.Count(msg.Reasoning)
+		}
+		for _, call := range msg.ToolCalls {
+			total += tc.Count(call.Function.Name)
+			total += tc.Count(call.Function.Arguments)
+		}
+	}
+	return total
+}
+
+// tokenText returns the content text a model reads: string content, the text
+// of multimodal parts, or the JSON encoding of structured content
+func (m Message) tokenText() string {
+	if text, ok := m.ContentString(); ok {
+		return text
+	}
+	if parts, ok := contentParts(m.Content); ok {
+		var b strings.Builder
+		for _, part := range parts {
+			b.WriteString(part.Text)
+		}
+		return b.String()
+	}
+	if m.Content == nil {
+		return ""
+	}
+	data, err := json.Marshal(m.Content)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// contentParts returns content holding multimodal parts, whatever the role
+func contentParts(content any) ([]InputContent, bool) {
+	switch value := content.(type) {
+	case []InputContent:
+		return value, true
+	case []any:
+		return decodeInputContents(value)
+	default:
+		return nil, false
+	}
+}
+
+// TrimMessages drops the oldest messages of a conversation until its
+// estimated size fits maxTokens. System messages are always kept, even when
+// they alone exceed the budget. An assistant message with tool calls and the
+// tool messages answering them are kept or dropped together, so a tool result
+// never outlives its call. Messages are only dropped from the start of the
+// conversation: once a message is kept, every later one is too. The kept
+// messages are returned in their original order in a new slice; msgs is not
+// modified. A nil counter uses DefaultTokenCounter.
+func TrimMessages(msgs []Message, maxTokens int, tc TokenCounter) []Message {
+	if tc == nil {
+		tc = DefaultTokenCounter
+	}
+
+	// first holds, for each message, the earliest message that must be kept
+	// with it: the assistant message that made the call a tool message answers
+	budget := maxTokens
+	first := make([]int, len(msgs))
+	calls := make(map[string]int)
+	for i, msg := range msgs {
+		first[i] = i
+		if msg.Role == RoleSystem {
+			budget -= EstimateTokens(msgs[i:i+1], tc)
+			continue
+		}
+		if index, ok := calls[msg.ToolCallID]; ok && msg.Role == RoleTool {
+			first[i] = index
+		}
+		for _, call := range msg.ToolCalls {
+			calls[call.ID] = i
+		}
+	}
+
+	// Grow the kept suffix msgs[start:] one message at a time, pulling in the
+	// calls of the tool results it contains, while it fits the budget
+	start := len(msgs)
+	for start > 0 {
+		next := start - 1
+		for j := start - 1; j >= next; j-- {
+			if first[j] < next {
+				next = first[j]
+			}
+		}
+
+		cost := 0
+		for _, msg := range msgs[next:start] {
+			if msg.Role != RoleSystem {
+				cost += EstimateTokens([]Message{msg}, tc)
+			}
+		}
+		if cost > budget {
+			break
+		}
+		budget -= cost
+		start = next
+	}
+
+	kept := make([]Message, 0, len(msgs))
+	for i, msg := range msgs {
+		if i >= start || msg.Role == RoleSystem {
+			kept = append(kept, msg)
+		}
+	}
+	return kept
+}
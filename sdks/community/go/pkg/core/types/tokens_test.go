@@ -0,0 +1,85 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessageByteLen(t *testing.T) {
+	assert.Equal(t, 0, Message{ID: "msg-1", Role: RoleAssistant}.ByteLen())
+	assert.Equal(t, 5, Message{ID: "msg-1", Role: RoleUser, Content: "hello"}.ByteLen())
+
+	multimodal := Message{ID: "msg-2", Role: RoleUser, Content: []InputContent{
+		{Type: InputContentTypeText, Text: "describe"},
+		{Type: InputContentTypeBinary, MimeType: "image/png", Data: "aGVsbG8="},
+		{Type: InputContentTypeImage, Source: &InputContentSource{Type: InputContentSourceTypeURL, Value: "https://x.test/a.png"}},
+	}}
+	assert.Equal(t, len("describe")+len("aGVsbG8=")+len("https://x.test/a.png"), multimodal.ByteLen())
+
+	// Parts decoded from JSON count the same way
+	var decoded Message
+	data, err := json.Marshal(multimodal)
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, multimodal.ByteLen(), decoded.ByteLen())
+
+	activity := Message{ID: "act-1", Role: RoleActivity, ActivityType: "PLAN", Content: map[string]any{"steps": []any{}}}
+	assert.Equal(t, len(`{"steps":[]}`), activity.ByteLen())
+}
+
+func TestEstimateTokens(t *testing.T) {
+	msgs := []Message{
+		{ID: "msg-1", Role: RoleUser, Content: "12345678"},
+		{ID: "msg-2", Role: RoleAssistant, Content: "123", Reasoning: "12345",
+			ToolCalls: []ToolCall{{ID: "call-1", Type: "function", Function: FunctionCall{Name: "abcd", Arguments: `{}`}}}},
+		{ID: "msg-3", Role: RoleUser, Content: []InputContent{
+			{Type: InputContentTypeText, Text: "1234"},
+			{Type: InputContentTypeBinary, MimeType: "image/png", Data: "aGVsbG8gd29ybGQ="},
+		}},
+	}
+
+	// 2 + (1 + 2 + 1 + 1) + 1
+	assert.Equal(t, 8, EstimateTokens(msgs, nil))
+	assert.Equal(t, 2, DefaultTokenCounter.Count("ééééé"))
+
+	perByte := TokenCounterFunc(func(text string) int { return len(text) })
+	assert.Equal(t, 8+3+5+4+2+4, EstimateTokens(msgs, perByte))
+	assert.Equal(t, 0, EstimateTokens(nil, nil))
+}
+
+func TestTrimMessages(t *testing.T) {
+	// Each message costs one token per byte of content
+	perByte := TokenCounterFunc(func(text string) int { return len(text) })
+	call := ToolCall{ID: "call-1", Type: "function", Function: FunctionCall{Name: "", Arguments: ""}}
+
+	msgs := []Message{
+		{ID: "sys", Role: RoleSystem, Content: "ss"},
+		{ID: "u1", Role: RoleUser, Content: "1111"},
+		{ID: "a1", Role: RoleAssistant, Content: "22", ToolCalls: []ToolCall{call}},
+		{ID: "u2", Role: RoleUser, Content: "3"},
+		{ID: "t1", Role: RoleTool, Content: "44", ToolCallID: "call-1"},
+		{ID: "a2", Role: RoleAssistant, Content: "55"},
+	}
+	original := append([]Message(nil), msgs...)
+
+	ids := func(kept []Message) []string {
+		var out []string
+		for _, msg := range kept {
+			out = append(out, msg.ID)
+		}
+		return out
+	}
+
+	assert.Equal(t, []string{"sys", "u1", "a1", "u2", "t1", "a2"}, ids(TrimMessages(msgs, 100, perByte)))
+	assert.Equal(t, []string{"sys", "a1", "u2", "t1", "a2"}, ids(TrimMessages(msgs, 9, perByte)))
+	// The tool call pair does not fit, so it is dropped together with the
+	// user message between its halves
+	assert.Equal(t, []string{"sys", "a2"}, ids(TrimMessages(msgs, 8, perByte)))
+	assert.Equal(t, []string{"sys"}, ids(TrimMessages(msgs, 3, perByte)))
+	assert.Equal(t, []string{"sys"}, ids(TrimMessages(msgs, 0, perByte)))
+	assert.Equal(t, original, msgs, "input is not modified")
+
+	assert.Empty(t, TrimMessages(nil, 10, nil))
+}
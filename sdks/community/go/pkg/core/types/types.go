@@ -120,6 +120,12 @@ type InputContent struct {
 	Source *InputContentSource `json:"source,omitempty"`
 	// Metadata is optional metadata for typed multimodal fragments.
 	Metadata any `json:"metadata,omitempty"`
+	// SampleRate is the optional sample rate in Hz of an audio fragment.
+	SampleRate int `json:"sampleRate,omitempty"`
+	// Channels is the optional channel count of an audio fragment.
+	Channels int `json:"channels,omitempty"`
+	// Encoding is the optional sample encoding of an audio fragment, such as "pcm_s16le".
+	Encoding string `json:"encoding,omitempty"`
 }
 
 // UnmarshalJSON implements json.Unmarshaler and supports snake_case compatibility.
@@ -156,6 +162,15 @@ func (c *InputContent) UnmarshalJSON(data []byte) error {
 	if err := unmarshalField(raw, &c.Metadata, "metadata"); err != nil {
 		return err
 	}
+	if err := unmarshalField(raw, &c.SampleRate, "sampleRate", "sample_rate"); err != nil {
+		return err
+	}
+	if err := unmarshalField(raw, &c.Channels, "channels"); err != nil {
+		return err
+	}
+	if err := unmarshalField(raw, &c.Encoding, "encoding"); err != nil {
+		return err
+	}
 
 	if c.Type == InputContentTypeBinary {
 		if err := validateBinaryInputContent(*c); err != nil {
@@ -166,13 +181,41 @@ func (c *InputContent) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+const (
+	// AssistantContentTypeText is the assistant content type for text parts.
+	AssistantContentTypeText = "text"
+	// AssistantContentTypeCitation is the assistant content type for citations of a source.
+	AssistantContentTypeCitation = "citation"
+	// AssistantContentTypeCode is the assistant content type for code blocks.
+	AssistantContentTypeCode = "code"
+)
+
+// AssistantContent represents a part of an assistant message whose content is
+// an ordered list of parts rather than a string, so that segments such as
+// citations stay addressable.
+type AssistantContent struct {
+	// Type is the discriminator for the content part.
+	Type string `json:"type"`
+	// Text is the text of the part: the prose of a text part, the source of a
+	// code part, or the marker or quoted span of a citation.
+	Text string `json:"text,omitempty"`
+	// ID is an optional identifier for referring to the part.
+	ID string `json:"id,omitempty"`
+	// Language is the optional programming language of a code part.
+	Language string `json:"language,omitempty"`
+	// URL is the location of the source of a citation.
+	URL string `json:"url,omitempty"`
+	// Title is the optional title of the source of a citation.
+	Title string `json:"title,omitempty"`
+}
+
 // Message represents an AG-UI message.
 type Message struct {
 	// ID is the message identifier.
 	ID string `json:"id"`
 	// Role is the message role discriminator.
 	Role Role `json:"role"`
-	// Content is the message content (string, []InputContent, or structured object depending on role).
+	// Content is the message content (string, []InputContent, []AssistantContent, or structured object depending on role).
 	Content any `json:"content,omitempty"`
 	// Name is an optional sender name.
 	Name string `json:"name,omitempty"`
@@ -188,6 +231,12 @@ type Message struct {
 	Error string `json:"error,omitempty"`
 	// ActivityType is an optional activity discriminator for activity messages.
 	ActivityType string `json:"activityType,omitempty"`
+	// Reasoning is optional hidden reasoning that produced an assistant message,
+	// kept apart from Content so that UIs can show or hide it.
+	Reasoning string `json:"reasoning,omitempty"`
+	// Metadata is optional application data, such as UI hints, carried with
+	// the message. It is preserved as is and never validated.
+	Metadata map[string]any `json:"metadata,omitempty"`
 }
 
 // UnmarshalJSON implements json.Unmarshaler and supports snake_case compatibility.
@@ -227,6 +276,12 @@ func (m *Message) UnmarshalJSON(data []byte) error {
 	if err := unmarshalField(raw, &m.ActivityType, "activityType", "activity_type"); err != nil {
 		return err
 	}
+	if err := unmarshalField(raw, &m.Reasoning, "reasoning"); err != nil {
+		return err
+	}
+	if err := unmarshalField(raw, &m.Metadata, "metadata"); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -239,6 +294,10 @@ type Context struct {
 	Value string `json:"value"`
 }
 
+// ContextItem is an alternative name for Context, the key/value grounding
+// entries sent in RunAgentInput.Context.
+type ContextItem = Context
+
 // Tool represents a tool definition available to the agent.
 type Tool struct {
 	// Name is the tool name.
@@ -1,9 +1,13 @@
 package types
 
 import (
+	"context"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"testing"
 
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/schema"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -206,6 +210,24 @@ func TestInputContentUnmarshalBinaryRequiresMimeType(t *testing.T) {
 	assert.Error(t, err)
 }
 
+// TestMessageMetadataRoundTrip verifies Metadata survives JSON and is omitted when empty.
+func TestMessageMetadataRoundTrip(t *testing.T) {
+	payload := []byte(`{"id":"m1","role":"assistant","content":"hi","metadata":{"avatar":"bot","ui":{"pinned":true}}}`)
+
+	var msg Message
+	require.NoError(t, json.Unmarshal(payload, &msg))
+	assert.Equal(t, "bot", msg.Metadata["avatar"])
+	assert.Equal(t, map[string]any{"pinned": true}, msg.Metadata["ui"])
+
+	data, err := json.Marshal(msg)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(payload), string(data))
+
+	data, err = json.Marshal(Message{ID: "m2", Role: RoleUser, Content: "hi", Metadata: map[string]any{}})
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "metadata")
+}
+
 // TestMessageContentString verifies ContentString extracts text content.
 func TestMessageContentString(t *testing.T) {
 	msg := Message{Role: RoleAssistant, Content: "hello"}
@@ -280,6 +302,78 @@ func TestMessageContentInputContents(t *testing.T) {
 	assert.Equal(t, "https://example.com/test.png", parts[0].URL)
 }
 
+// TestMessageContentAssistantContents verifies assistant content parts are extracted and flattened.
+func TestMessageContentAssistantContents(t *testing.T) {
+	payload := []byte(`{
+		"id": "msg-1",
+		"role": "assistant",
+		"content": [
+			{"type": "text", "text": "Go was announced in 2009"},
+			{"type": "citation", "id": "cite-1", "text": "[1]", "url": "https://go.dev/blog", "title": "The Go Blog"},
+			{"type": "text", "text": ". Example:\n"},
+			{"type": "code", "language": "go", "text": "fmt.Println(1)"}
+		]
+	}`)
+
+	var msg Message
+	require.NoError(t, json.Unmarshal(payload, &msg))
+	parts, ok := msg.ContentAssistantContents()
+	require.True(t, ok)
+	require.Len(t, parts, 4)
+	assert.Equal(t, AssistantContent{Type: AssistantContentTypeCitation, ID: "cite-1", Text: "[1]", URL: "https://go.dev/blog", Title: "The Go Blog"}, parts[1])
+	assert.Equal(t, "go", parts[3].Language)
+	for _, part := range parts {
+		assert.NoError(t, part.Validate())
+	}
+
+	text, ok := msg.ContentString()
+	assert.True(t, ok)
+	assert.Equal(t, "Go was announced in 2009[1]. Example:\nfmt.Println(1)", text)
+	assert.Equal(t, text, msg.Text())
+
+	msg = Message{Role: RoleAssistant, Content: []AssistantContent{{Type: AssistantContentTypeText, Text: "hi"}}}
+	text, ok = msg.ContentString()
+	assert.True(t, ok)
+	assert.Equal(t, "hi", text)
+
+	msg = Message{Role: RoleAssistant, Content: "plain"}
+	_, ok = msg.ContentAssistantContents()
+	assert.False(t, ok)
+
+	msg = Message{Role: RoleUser, Content: []any{map[string]any{"type": "text", "text": "hi"}}}
+	_, ok = msg.ContentAssistantContents()
+	assert.False(t, ok)
+
+	assert.Error(t, AssistantContent{}.Validate())
+	assert.Error(t, AssistantContent{Type: AssistantContentTypeText}.Validate())
+	assert.Error(t, AssistantContent{Type: AssistantContentTypeCitation, Text: "[1]"}.Validate())
+	assert.Error(t, AssistantContent{Type: "table", Text: "x"}.Validate())
+}
+
+// TestMessageContentPartsAndText verifies ContentParts and Text across content shapes.
+func TestMessageContentPartsAndText(t *testing.T) {
+	msg := Message{Role: RoleAssistant, Content: "hello"}
+	_, ok := msg.ContentParts()
+	assert.False(t, ok)
+	assert.Equal(t, "hello", msg.Text())
+
+	msg = Message{
+		Role: RoleUser,
+		Content: []InputContent{
+			{Type: InputContentTypeText, Text: "Describe "},
+			{Type: InputContentTypeBinary, MimeType: "image/png", URL: "https://example.com/test.png"},
+			{Type: InputContentTypeText, Text: "this image"},
+		},
+	}
+	parts, ok := msg.ContentParts()
+	require.True(t, ok)
+	assert.Len(t, parts, 3)
+	assert.Equal(t, "Describe this image", msg.Text())
+
+	msg = Message{Role: RoleActivity, Content: map[string]any{"step": 1}}
+	assert.Equal(t, "", msg.Text())
+}
+
 // TestInputContentUnmarshalImageWithSource verifies decoding an image InputContent with a source object.
 func TestInputContentUnmarshalImageWithSource(t *testing.T) {
 	payload := []byte(`{
@@ -329,6 +423,39 @@ func TestInputContentUnmarshalAudioWithDataSource(t *testing.T) {
 	assert.Nil(t, content.Metadata)
 }
 
+// TestInputContentAudioFields verifies decoding and validating audio stream fields.
+func TestInputContentAudioFields(t *testing.T) {
+	payload := []byte(`{
+		"type": "binary",
+		"mimeType": "audio/pcm",
+		"data": "AAAA",
+		"sample_rate": 16000,
+		"channels": 2,
+		"encoding": "pcm_s16le"
+	}`)
+
+	var content InputContent
+	require.NoError(t, json.Unmarshal(payload, &content))
+	assert.Equal(t, 16000, content.SampleRate)
+	assert.Equal(t, 2, content.Channels)
+	assert.Equal(t, "pcm_s16le", content.Encoding)
+	assert.NoError(t, content.ValidateAudio())
+
+	data, err := json.Marshal(content)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"sampleRate":16000`)
+
+	assert.NoError(t, InputContent{Type: InputContentTypeBinary, MimeType: "image/png"}.ValidateAudio())
+	assert.Error(t, InputContent{Type: InputContentTypeBinary, MimeType: "image/png", Encoding: "opus"}.ValidateAudio())
+	assert.Error(t, InputContent{Type: InputContentTypeBinary, MimeType: "audio/pcm", SampleRate: -8000}.ValidateAudio())
+	assert.Error(t, InputContent{Type: InputContentTypeBinary, MimeType: "audio/pcm", Channels: -1}.ValidateAudio())
+	assert.NoError(t, InputContent{
+		Type:       InputContentTypeAudio,
+		Source:     &InputContentSource{Type: InputContentSourceTypeURL, Value: "https://example.com/a.ogg", MimeType: "audio/ogg"},
+		SampleRate: 48000,
+	}.ValidateAudio())
+}
+
 // TestInputContentUnmarshalDocumentWithSnakeCaseSource verifies snake_case compatibility in source fields.
 func TestInputContentUnmarshalDocumentWithSnakeCaseSource(t *testing.T) {
 	payload := []byte(`{
@@ -553,3 +680,345 @@ func TestMessageContentActivity(t *testing.T) {
 	_, ok = msg.ContentActivity()
 	assert.False(t, ok)
 }
+
+// TestFunctionCallArgumentsMap verifies ArgumentsMap decodes JSON arguments.
+func TestFunctionCallArgumentsMap(t *testing.T) {
+	args, err := FunctionCall{Name: "search", Arguments: `{"query":"weather","limit":3}`}.ArgumentsMap()
+	require.NoError(t, err)
+	assert.Equal(t, "weather", args["query"])
+	assert.Equal(t, float64(3), args["limit"])
+
+	args, err = FunctionCall{Name: "noop"}.ArgumentsMap()
+	require.NoError(t, err)
+	assert.Empty(t, args)
+
+	_, err = FunctionCall{Name: "broken", Arguments: `{"query"`}.ArgumentsMap()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "broken")
+}
+
+// TestFunctionCallArgumentsMapLenient verifies relaxed JSON is repaired on request.
+func TestFunctionCallArgumentsMapLenient(t *testing.T) {
+	cases := []struct {
+		name     string
+		args     string
+		expected map[string]any
+	}{
+		{"TrailingCommas", `{"tags":["a","b",],"limit":3,}`, map[string]any{"tags": []any{"a", "b"}, "limit": float64(3)}},
+		{"SingleQuotes", `{'query':'it\'s "here"'}`, map[string]any{"query": `it's "here"`}},
+		{"UnquotedKeys", `{query: "weather", max_results: 2}`, map[string]any{"query": "weather", "max_results": float64(2)}},
+		{"Comments", "{\n  // search term\n  \"query\": \"x\" /* inline */\n}", map[string]any{"query": "x"}},
+		{"PythonLiterals", `{"exact": True, "fuzzy": False, "filter": None}`, map[string]any{"exact": true, "fuzzy": false, "filter": nil}},
+		{"CodeFence", "```json\n{\"query\": \"x\"}\n```", map[string]any{"query": "x"}},
+		{"RawNewline", "{\"text\": \"line one\nline two\"}", map[string]any{"text": "line one\nline two"}},
+		{"Unicode", `{'city':'Zürich','note':"https://example.com/a,b"}`, map[string]any{"city": "Zürich", "note": "https://example.com/a,b"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			call := FunctionCall{Name: "search", Arguments: tc.args}
+			_, err := call.ArgumentsMap()
+			require.Error(t, err, "strict decoding is the default")
+
+			args, err := call.ArgumentsMap(LenientArguments())
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, args)
+		})
+	}
+
+	// Valid JSON is unchanged, including sequences that look like repairs
+	args, err := FunctionCall{Arguments: `{"s":"a, }","t":"// not a comment","True":1}`}.ArgumentsMap(LenientArguments())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"s": "a, }", "t": "// not a comment", "True": float64(1)}, args)
+
+	_, err = FunctionCall{Name: "broken", Arguments: `{"query"`}.ArgumentsMap(LenientArguments())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "broken")
+}
+
+// TestRunAgentInputValidate verifies structural validation of run input.
+func TestRunAgentInputValidate(t *testing.T) {
+	valid := func() RunAgentInput {
+		return RunAgentInput{
+			ThreadID: "thread-1",
+			RunID:    "run-1",
+			Messages: []Message{
+				{ID: "msg-1", Role: RoleUser, Content: "hi"},
+				{ID: "msg-2", Role: RoleAssistant, Content: "hello"},
+			},
+			Tools:   []Tool{{Name: "search", Parameters: map[string]any{"type": "object"}}},
+			Context: []ContextItem{{Description: "locale", Value: "en-GB"}},
+			Resume:  []ResumeEntry{{InterruptID: "int-1", Status: ResumeStatusResolved}},
+		}
+	}
+
+	input := valid()
+	require.NoError(t, input.Validate())
+
+	cases := map[string]struct {
+		mutate func(*RunAgentInput)
+		want   string
+	}{
+		"MissingThreadID":  {func(r *RunAgentInput) { r.ThreadID = "" }, "threadId field is required"},
+		"MissingRunID":     {func(r *RunAgentInput) { r.RunID = "" }, "runId field is required"},
+		"SelfParent":       {func(r *RunAgentInput) { r.ParentRunID = &r.RunID }, "parentRunId must differ"},
+		"MissingMessageID": {func(r *RunAgentInput) { r.Messages[0].ID = "" }, "messages[0] id field is required"},
+		"UnknownRole":      {func(r *RunAgentInput) { r.Messages[1].Role = "robot" }, `unsupported role "robot"`},
+		"DuplicateMessage": {func(r *RunAgentInput) { r.Messages[1].ID = "msg-1" }, "duplicate message id msg-1"},
+		"MissingToolName":  {func(r *RunAgentInput) { r.Tools[0].Name = "" }, "tools[0] name field is required"},
+		"DuplicateTool":    {func(r *RunAgentInput) { r.Tools = append(r.Tools, r.Tools[0]) }, "duplicate tool name search"},
+		"ResumeStatus":     {func(r *RunAgentInput) { r.Resume[0].Status = "done" }, `unsupported status "done"`},
+		"BlankContext":     {func(r *RunAgentInput) { r.Context[0].Description = " " }, "context[0] description field is required"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			input := valid()
+			tc.mutate(&input)
+			err := input.Validate()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.want)
+		})
+	}
+}
+
+// TestToolValidateArguments verifies tool schemas are compiled and enforced.
+func TestRunAgentInputContextMap(t *testing.T) {
+	input := RunAgentInput{Context: []ContextItem{
+		{Description: "locale", Value: "en-GB"},
+		{Description: "user", Value: "Ada"},
+		{Description: "locale", Value: "fr-FR"},
+	}}
+	assert.Equal(t, map[string]string{"locale": "fr-FR", "user": "Ada"}, input.ContextMap())
+	assert.Empty(t, RunAgentInput{}.ContextMap())
+}
+
+func TestToolValidateArguments(t *testing.T) {
+	tool := Tool{
+		Name:        "weather",
+		Description: "Look up the weather",
+		Parameters: map[string]any{
+			"type":     "object",
+			"required": []string{"city"},
+			"properties": map[string]any{
+				"city": map[string]any{"type": "string"},
+				"days": map[string]any{"type": "integer", "minimum": 1},
+			},
+		},
+	}
+	require.NoError(t, tool.Validate())
+
+	assert.NoError(t, tool.ValidateArguments(json.RawMessage(`{"city":"Paris","days":2}`)))
+
+	err := tool.ValidateArguments(json.RawMessage(`{"days":0}`))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `missing required property "city"`)
+	assert.Contains(t, err.Error(), "/days")
+
+	err = tool.ValidateArguments(nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "city")
+
+	err = tool.ValidateArguments(json.RawMessage(`{"city":`))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "valid JSON")
+
+	// Tools without parameters accept any JSON arguments
+	assert.NoError(t, Tool{Name: "ping"}.ValidateArguments(json.RawMessage(`{"x":1}`)))
+
+	broken := Tool{Name: "broken", Parameters: json.RawMessage(`{"type":"map"}`)}
+	assert.Error(t, broken.Validate())
+	assert.Error(t, broken.ValidateArguments(json.RawMessage(`{}`)))
+	assert.Error(t, Tool{}.Validate())
+
+	input := RunAgentInput{ThreadID: "thread-1", RunID: "run-1", Tools: []Tool{broken}}
+	err = input.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "tools[0]")
+
+	// A self-referencing schema must fail instead of recursing forever
+	cyclic := Tool{Name: "cyclic", Parameters: json.RawMessage(`{"$ref":"#"}`)}
+	err = cyclic.Validate()
+	require.Error(t, err)
+	assert.ErrorIs(t, err, schema.ErrInvalidSchema)
+	assert.ErrorIs(t, cyclic.ValidateArguments(json.RawMessage(`{}`)), schema.ErrInvalidSchema)
+	input = RunAgentInput{ThreadID: "thread-1", RunID: "run-1", Tools: []Tool{cyclic}}
+	assert.Error(t, input.Validate())
+}
+
+func TestInputContentResolveData(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	encoded := base64.StdEncoding.EncodeToString(png)
+	ctx := context.Background()
+
+	t.Run("InlineData", func(t *testing.T) {
+		content := InputContent{Type: InputContentTypeBinary, MimeType: "image/png", Data: encoded}
+		data, mimeType, err := content.ResolveData(ctx, nil)
+		require.NoError(t, err)
+		assert.Equal(t, png, data)
+		assert.Equal(t, "image/png", mimeType)
+
+		content = InputContent{Type: InputContentTypeImage, Source: &InputContentSource{
+			Type: InputContentSourceTypeData, Value: base64.RawURLEncoding.EncodeToString(png), MimeType: "image/png",
+		}}
+		data, _, err = content.ResolveData(ctx, nil)
+		require.NoError(t, err)
+		assert.Equal(t, png, data)
+	})
+
+	t.Run("FetchesURL", func(t *testing.T) {
+		var fetched []string
+		fetch := func(url string) ([]byte, error) {
+			fetched = append(fetched, url)
+			return png, nil
+		}
+
+		content := InputContent{Type: InputContentTypeImage, Source: &InputContentSource{
+			Type: InputContentSourceTypeURL, Value: "https://example.com/a.png",
+		}}
+		data, mimeType, err := content.ResolveData(ctx, fetch)
+		require.NoError(t, err)
+		assert.Equal(t, png, data)
+		assert.Equal(t, "image/png", mimeType, "sniffed when not declared")
+		assert.Equal(t, []string{"https://example.com/a.png"}, fetched)
+
+		_, _, err = content.ResolveData(ctx, nil)
+		assert.Error(t, err)
+
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		_, _, err = content.ResolveData(cancelled, fetch)
+		assert.ErrorIs(t, err, context.Canceled)
+
+		_, _, err = content.ResolveData(ctx, func(string) ([]byte, error) { return nil, errors.New("404") })
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "404")
+	})
+
+	t.Run("ChecksMimeType", func(t *testing.T) {
+		content := InputContent{Type: InputContentTypeBinary, MimeType: "audio/wav", Data: encoded}
+		_, _, err := content.ResolveData(ctx, nil)
+		assert.ErrorIs(t, err, ErrMimeTypeMismatch)
+
+		// Formats the sniffer does not recognize are accepted as declared
+		content = InputContent{Type: InputContentTypeBinary, MimeType: "application/json", Data: base64.StdEncoding.EncodeToString([]byte(`{"a":1}`))}
+		_, mimeType, err := content.ResolveData(ctx, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "application/json", mimeType)
+
+		content = InputContent{Type: InputContentTypeImage, Source: &InputContentSource{
+			Type: InputContentSourceTypeData, Value: base64.StdEncoding.EncodeToString([]byte("\xff\xd8\xff\xe0")), MimeType: "image/jpg",
+		}}
+		_, _, err = content.ResolveData(ctx, nil)
+		assert.NoError(t, err)
+
+		// Containers sniff as one media type but may hold another
+		containers := []struct {
+			mimeType string
+			data     string
+		}{
+			{"audio/webm", "\x1a\x45\xdf\xa3\x9f\x42\x86\x81\x01\x42\xf7\x81\x01\x42\x82\x84webm"},
+			{"audio/ogg", "OggS\x00\x02\x00\x00\x00\x00\x00\x00\x00\x00"},
+			{"audio/mp4", "\x00\x00\x00\x18ftypM4A \x00\x00\x00\x00mp42isom"},
+			{"application/vnd.openxmlformats-officedocument.wordprocessingml.document", "PK\x03\x04\x14\x00\x06\x00"},
+		}
+		for _, tc := range containers {
+			content = InputContent{Type: InputContentTypeBinary, MimeType: tc.mimeType, Data: base64.StdEncoding.EncodeToString([]byte(tc.data))}
+			_, mimeType, err := content.ResolveData(ctx, nil)
+			require.NoError(t, err, tc.mimeType)
+			assert.Equal(t, tc.mimeType, mimeType)
+		}
+	})
+
+	t.Run("Unresolvable", func(t *testing.T) {
+		for _, content := range []InputContent{
+			{Type: InputContentTypeText, Text: "hi"},
+			{Type: InputContentTypeBinary, MimeType: "image/png", ID: "file-1"},
+			{Type: InputContentTypeBinary, MimeType: "image/png", Data: "not base64!"},
+			{Type: InputContentTypeImage},
+		} {
+			_, _, err := content.ResolveData(ctx, nil)
+			assert.Error(t, err, content.Type)
+		}
+	})
+}
+
+func TestParseDataURI(t *testing.T) {
+	cases := []struct {
+		uri      string
+		data     string
+		mimeType string
+	}{
+		{"data:image/png;base64,iVBORw0KGgo=", "\x89PNG\r\n\x1a\n", "image/png"},
+		{"data:image/png;base64,iVBORw0KGgo", "\x89PNG\r\n\x1a\n", "image/png"},
+		{"DATA:text/plain;charset=utf-8,hello%20world", "hello world", "text/plain;charset=utf-8"},
+		{"data:,A%20brief%20note", "A brief note", "text/plain;charset=US-ASCII"},
+		{"data:;charset=utf-8,x", "x", "text/plain;charset=utf-8"},
+	}
+	for _, tc := range cases {
+		data, mimeType, err := ParseDataURI(tc.uri)
+		require.NoError(t, err, tc.uri)
+		assert.Equal(t, tc.data, string(data), tc.uri)
+		assert.Equal(t, tc.mimeType, mimeType, tc.uri)
+	}
+
+	for _, uri := range []string{
+		"https://example.com/a.png",
+		"data:image/png;base64",
+		"data:image/png;base64,***",
+		"data:not a type,x",
+		"data:text/plain,%zz",
+	} {
+		_, _, err := ParseDataURI(uri)
+		assert.ErrorIs(t, err, ErrInvalidDataURI, uri)
+	}
+
+	t.Run("ResolveData", func(t *testing.T) {
+		content := InputContent{Type: InputContentTypeImage, Source: &InputContentSource{
+			Type: InputContentSourceTypeURL, Value: "data:image/png;base64,iVBORw0KGgo=",
+		}}
+		data, mimeType, err := content.ResolveData(context.Background(), func(string) ([]byte, error) {
+			t.Fatal("data URIs must not be fetched")
+			return nil, nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "\x89PNG\r\n\x1a\n", string(data))
+		assert.Equal(t, "image/png", mimeType)
+
+		content.Source.MimeType = "audio/wav"
+		_, _, err = content.ResolveData(context.Background(), nil)
+		assert.ErrorIs(t, err, ErrMimeTypeMismatch)
+
+		content = InputContent{Type: InputContentTypeBinary, MimeType: "image/png", URL: "data:image/png;base64,%%%"}
+		_, _, err = content.ResolveData(context.Background(), nil)
+		assert.ErrorIs(t, err, ErrInvalidDataURI)
+	})
+}
+
+// TestMessageClone verifies that clones share no mutable state with the original.
+func TestMessageClone(t *testing.T) {
+	original := Message{
+		ID:   "msg-1",
+		Role: RoleAssistant,
+		Content: map[string]any{
+			"steps": []any{map[string]any{"title": "search"}},
+		},
+		ToolCalls: []ToolCall{{ID: "call-1", Type: "function", Function: FunctionCall{Name: "search", Arguments: "{}"}}},
+	}
+
+	clone := original.Clone()
+	require.Equal(t, original, clone)
+
+	clone.Content.(map[string]any)["steps"].([]any)[0].(map[string]any)["title"] = "changed"
+	clone.Content.(map[string]any)["extra"] = true
+	clone.ToolCalls[0].Function.Name = "changed"
+
+	assert.Equal(t, "search", original.Content.(map[string]any)["steps"].([]any)[0].(map[string]any)["title"])
+	assert.NotContains(t, original.Content.(map[string]any), "extra")
+	assert.Equal(t, "search", original.ToolCalls[0].Function.Name)
+
+	parts := Message{ID: "msg-2", Role: RoleUser, Content: []InputContent{
+		{Type: InputContentTypeImage, Source: &InputContentSource{Type: InputContentSourceTypeURL, Value: "https://x.test/a.png"}},
+	}}
+	partsClone := parts.Clone()
+	partsClone.Content.([]InputContent)[0].Source.Value = "changed"
+	assert.Equal(t, "https://x.test/a.png", parts.Content.([]InputContent)[0].Source.Value)
+}
@@ -0,0 +1,179 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/schema"
+)
+
+// Validate checks the structure of the run input: the thread and run IDs are
+// required, message IDs and tool names must be present and unique, context
+// entries need a description, and resume entries must reference an interrupt
+// with a known status. Role-specific message content rules are enforced by
+// the events package.
+func (r *RunAgentInput) Validate() error {
+	if r.ThreadID == "" {
+		return fmt.Errorf("RunAgentInput validation failed: threadId field is required")
+	}
+
+	if r.RunID == "" {
+		return fmt.Errorf("RunAgentInput validation failed: runId field is required")
+	}
+
+	if r.ParentRunID != nil && *r.ParentRunID == r.RunID {
+		return fmt.Errorf("RunAgentInput validation failed: parentRunId must differ from runId")
+	}
+
+	messageIDs := make(map[string]bool, len(r.Messages))
+	for i, msg := range r.Messages {
+		if msg.ID == "" {
+			return fmt.Errorf("RunAgentInput validation failed: messages[%d] id field is required", i)
+		}
+		if !msg.Role.IsValid() {
+			return fmt.Errorf("RunAgentInput validation failed: messages[%d] has unsupported role %q", i, msg.Role)
+		}
+		if messageIDs[msg.ID] {
+			return fmt.Errorf("RunAgentInput validation failed: duplicate message id %s", msg.ID)
+		}
+		messageIDs[msg.ID] = true
+	}
+
+	toolNames := make(map[string]bool, len(r.Tools))
+	for i, tool := range r.Tools {
+		if tool.Name == "" {
+			return fmt.Errorf("RunAgentInput validation failed: tools[%d] name field is required", i)
+		}
+		if toolNames[tool.Name] {
+			return fmt.Errorf("RunAgentInput validation failed: duplicate tool name %s", tool.Name)
+		}
+		toolNames[tool.Name] = true
+		if err := tool.Validate(); err != nil {
+			return fmt.Errorf("RunAgentInput validation failed: tools[%d]: %w", i, err)
+		}
+	}
+
+	for i, item := range r.Context {
+		if strings.TrimSpace(item.Description) == "" {
+			return fmt.Errorf("RunAgentInput validation failed: context[%d] description field is required", i)
+		}
+	}
+
+	for i, entry := range r.Resume {
+		if entry.InterruptID == "" {
+			return fmt.Errorf("RunAgentInput validation failed: resume[%d] interruptId field is required", i)
+		}
+		switch entry.Status {
+		case ResumeStatusResolved, ResumeStatusCancelled:
+		default:
+			return fmt.Errorf("RunAgentInput validation failed: resume[%d] has unsupported status %q", i, entry.Status)
+		}
+	}
+
+	return nil
+}
+
+// ContextMap returns the context entries keyed by description, for use in
+// prompt templates. When descriptions repeat, the last entry wins.
+func (r RunAgentInput) ContextMap() map[string]string {
+	values := make(map[string]string, len(r.Context))
+	for _, item := range r.Context {
+		values[item.Description] = item.Value
+	}
+	return values
+}
+
+// IsValid reports whether the role is one of the protocol message roles
+func (r Role) IsValid() bool {
+	switch r {
+	case RoleDeveloper, RoleSystem, RoleAssistant, RoleUser, RoleTool, RoleActivity, RoleReasoning:
+		return true
+	}
+	return false
+}
+
+// Validate checks that the tool has a name and that its parameters, when
+// present, are a JSON Schema that compiles
+func (t Tool) Validate() error {
+	if t.Name == "" {
+		return fmt.Errorf("tool name field is required")
+	}
+
+	if _, err := t.parametersSchema(); err != nil {
+		return fmt.Errorf("tool %s parameters: %w", t.Name, err)
+	}
+
+	return nil
+}
+
+// ValidateArguments checks the JSON arguments of a call to this tool against
+// its parameters schema. Empty arguments are treated as an empty object. Schema
+// violations are reported as a wrapped *schema.ValidationError.
+func (t Tool) ValidateArguments(args json.RawMessage) error {
+	compiled, err := t.parametersSchema()
+	if err != nil {
+		return fmt.Errorf("tool %s parameters: %w", t.Name, err)
+	}
+
+	if len(args) == 0 {
+		args = json.RawMessage("{}")
+	}
+	var value any
+	if err := json.Unmarshal(args, &value); err != nil {
+		return fmt.Errorf("tool %s arguments must be valid JSON: %w", t.Name, err)
+	}
+
+	if compiled == nil {
+		return nil
+	}
+	if err := compiled.Validate(value); err != nil {
+		return fmt.Errorf("tool %s arguments: %w", t.Name, err)
+	}
+	return nil
+}
+
+// parametersSchema compiles the parameters schema, returning nil when the tool
+// declares no parameters
+func (t Tool) parametersSchema() (*schema.Schema, error) {
+	var data []byte
+	switch parameters := t.Parameters.(type) {
+	case nil:
+		return nil, nil
+	case json.RawMessage:
+		data = parameters
+	case []byte:
+		data = parameters
+	default:
+		encoded, err := json.Marshal(parameters)
+		if err != nil {
+			return nil, err
+		}
+		data = encoded
+	}
+
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+	return schema.Compile(data)
+}
+
+// Validate checks that the content part has a known type and the fields it
+// requires: text for text and code parts, and a URL for citations
+func (c AssistantContent) Validate() error {
+	switch c.Type {
+	case AssistantContentTypeText, AssistantContentTypeCode:
+		if c.Text == "" {
+			return fmt.Errorf("text field is required for %s content", c.Type)
+		}
+	case AssistantContentTypeCitation:
+		if c.URL == "" {
+			return fmt.Errorf("url field is required for citation content")
+		}
+	case "":
+		return fmt.Errorf("content type field is required")
+	default:
+		return fmt.Errorf("unsupported assistant content type %q", c.Type)
+	}
+	return nil
+}
@@ -92,6 +92,9 @@ func GetOptimalBufferSizeForEvent(event events.Event) int {
 		// without serializing first, so stick with the base size
 		return baseSize
 	case *events.StateDeltaEvent:
+		if len(e.MergePatch) > 0 {
+			return max(baseSize, len(e.MergePatch)*2)
+		}
 		// Estimate based on number of operations
 		if len(e.Delta) > 0 {
 			// Rough estimate: 100 bytes per operation
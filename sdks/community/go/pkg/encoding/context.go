@@ -0,0 +1,33 @@
+package encoding
+
+import (
+	"context"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+)
+
+// EncodeContext writes event to w, propagating ctx when w implements
+// ContextEventWriter. Other writers cannot be interrupted, so ctx is only
+// checked before the write.
+func EncodeContext(ctx context.Context, w EventWriter, event events.Event) error {
+	if cw, ok := w.(ContextEventWriter); ok {
+		return cw.EncodeContext(ctx, event)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return w.Encode(event)
+}
+
+// NextContext reads the next event from r, propagating ctx when r implements
+// ContextEventReader. Other readers cannot be interrupted while they wait for
+// the stream, so ctx is only checked before the read.
+func NextContext(ctx context.Context, r EventReader) (events.Event, error) {
+	if cr, ok := r.(ContextEventReader); ok {
+		return cr.NextContext(ctx)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return r.Next()
+}
@@ -30,12 +30,8 @@ func NewEventEncoder() *EventEncoder {
 
 // EncodeEvent encodes a single event using the specified content type
 func (e *EventEncoder) EncodeEvent(ctx context.Context, event events.Event, contentType string) ([]byte, error) {
-	if event == nil {
-		return nil, fmt.Errorf("event cannot be nil")
-	}
-
 	// Validate the event before encoding
-	if err := event.Validate(); err != nil {
+	if err := events.ValidateEvent(event); err != nil {
 		return nil, fmt.Errorf("event validation failed: %w", err)
 	}
 
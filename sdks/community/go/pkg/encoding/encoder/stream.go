@@ -0,0 +1,123 @@
+package encoder
+
+import (
+	stdjson "encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/encoding"
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/encoding/negotiation"
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/encoding/proto"
+)
+
+// Content types understood by NewEncoder and NewDecoder
+const (
+	ContentTypeJSON     = "application/json"
+	ContentTypeProtobuf = proto.ContentType
+	// ContentTypeProtobufAlias is the shorter protobuf media type accepted for compatibility
+	ContentTypeProtobufAlias = "application/vnd.ag-ui+proto"
+)
+
+// ErrUnsupportedContentType is returned when no stream format matches a content type
+var ErrUnsupportedContentType = errors.New("unsupported content type")
+
+// NewEncoder returns a stream encoder for the wire format selected by contentType.
+// contentType may be a Content-Type value or an Accept header; for Accept headers
+// the supported type with the highest quality wins. An empty value or wildcard
+// selects JSON. JSON streams are newline-delimited and protobuf streams are
+// length-prefixed.
+func NewEncoder(contentType string, w io.Writer) (encoding.EventWriter, error) {
+	resolved, err := ResolveContentType(contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resolved {
+	case ContentTypeProtobuf:
+		return proto.NewEncoder(w), nil
+	default:
+		return &jsonStreamEncoder{w: w}, nil
+	}
+}
+
+// NewDecoder returns a stream decoder for the wire format selected by contentType.
+// It accepts the same values as NewEncoder.
+func NewDecoder(contentType string, r io.Reader) (encoding.EventReader, error) {
+	resolved, err := ResolveContentType(contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resolved {
+	case ContentTypeProtobuf:
+		return proto.NewDecoder(r), nil
+	default:
+		return &jsonStreamDecoder{decoder: stdjson.NewDecoder(r)}, nil
+	}
+}
+
+// ResolveContentType maps a Content-Type or Accept header value to one of
+// ContentTypeJSON or ContentTypeProtobuf.
+func ResolveContentType(contentType string) (string, error) {
+	if contentType == "" {
+		return ContentTypeJSON, nil
+	}
+
+	acceptTypes, err := negotiation.ParseAcceptHeader(contentType)
+	if err != nil {
+		return "", fmt.Errorf("%w %q: %v", ErrUnsupportedContentType, contentType, err)
+	}
+
+	for _, acceptType := range acceptTypes {
+		if acceptType.Quality <= 0 {
+			continue
+		}
+		switch acceptType.Type {
+		case ContentTypeJSON, "*/*", "application/*":
+			return ContentTypeJSON, nil
+		case ContentTypeProtobuf, ContentTypeProtobufAlias:
+			return ContentTypeProtobuf, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w %q, supported: %s, %s", ErrUnsupportedContentType, contentType,
+		ContentTypeJSON, ContentTypeProtobuf)
+}
+
+// jsonStreamEncoder writes events as newline-delimited JSON
+type jsonStreamEncoder struct {
+	w io.Writer
+}
+
+// Encode writes a single event followed by a newline
+func (e *jsonStreamEncoder) Encode(event events.Event) error {
+	if event == nil {
+		return fmt.Errorf("event cannot be nil")
+	}
+
+	data, err := event.ToJSON()
+	if err != nil {
+		return fmt.Errorf("event encoding failed: %w", err)
+	}
+
+	if _, err := e.w.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("JSON write failed: %w", err)
+	}
+	return nil
+}
+
+// jsonStreamDecoder reads a stream of concatenated or newline-delimited JSON events
+type jsonStreamDecoder struct {
+	decoder *stdjson.Decoder
+}
+
+// Next returns the next event, or io.EOF when the stream ends
+func (d *jsonStreamDecoder) Next() (events.Event, error) {
+	var raw stdjson.RawMessage
+	if err := d.decoder.Decode(&raw); err != nil {
+		return nil, err
+	}
+	return events.EventFromJSON(raw)
+}
@@ -0,0 +1,65 @@
+package encoder
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamRoundTrip(t *testing.T) {
+	for _, contentType := range []string{ContentTypeJSON, ContentTypeProtobuf, ContentTypeProtobufAlias} {
+		t.Run(contentType, func(t *testing.T) {
+			var buf bytes.Buffer
+			enc, err := NewEncoder(contentType, &buf)
+			require.NoError(t, err)
+			require.NoError(t, enc.Encode(events.NewRunStartedEvent("thread-1", "run-1")))
+			require.NoError(t, enc.Encode(events.NewTextMessageContentEvent("msg-1", "Hello")))
+
+			dec, err := NewDecoder(contentType, &buf)
+			require.NoError(t, err)
+
+			event, err := dec.Next()
+			require.NoError(t, err)
+			assert.Equal(t, events.EventTypeRunStarted, event.Type())
+			assert.Equal(t, "run-1", event.RunID())
+
+			event, err = dec.Next()
+			require.NoError(t, err)
+			content, ok := event.(*events.TextMessageContentEvent)
+			require.True(t, ok)
+			assert.Equal(t, "Hello", content.Delta)
+
+			_, err = dec.Next()
+			assert.True(t, errors.Is(err, io.EOF))
+		})
+	}
+}
+
+func TestResolveContentType(t *testing.T) {
+	cases := map[string]string{
+		"":                                ContentTypeJSON,
+		"*/*":                             ContentTypeJSON,
+		"application/json; charset=utf-8": ContentTypeJSON,
+		"application/vnd.ag-ui+proto":     ContentTypeProtobuf,
+		"text/html, application/vnd.ag-ui.event+proto;q=0.9, application/json;q=0.5": ContentTypeProtobuf,
+		"application/json;q=0.2, application/vnd.ag-ui+proto":                        ContentTypeProtobuf,
+	}
+	for header, expected := range cases {
+		resolved, err := ResolveContentType(header)
+		require.NoError(t, err, header)
+		assert.Equal(t, expected, resolved, header)
+	}
+
+	_, err := NewEncoder("application/xml", io.Discard)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrUnsupportedContentType))
+	assert.Contains(t, err.Error(), "application/xml")
+
+	_, err = NewDecoder("text/event-stream", bytes.NewReader(nil))
+	assert.True(t, errors.Is(err, ErrUnsupportedContentType))
+}
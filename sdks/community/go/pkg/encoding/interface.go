@@ -84,6 +84,34 @@ type StreamDecoder interface {
 	ContentType() string
 }
 
+// EventWriter writes events to an underlying stream in a specific wire format
+type EventWriter interface {
+	// Encode writes a single event to the stream
+	Encode(event events.Event) error
+}
+
+// EventReader reads events from an underlying stream in a specific wire format
+type EventReader interface {
+	// Next returns the next event, or io.EOF when the stream ends
+	Next() (events.Event, error)
+}
+
+// ContextEventWriter is an EventWriter that also honors a context while
+// writing; see EncodeContext
+type ContextEventWriter interface {
+	EventWriter
+	// EncodeContext writes a single event, failing once ctx is done
+	EncodeContext(ctx context.Context, event events.Event) error
+}
+
+// ContextEventReader is an EventReader that also honors a context while
+// waiting for the stream; see NextContext
+type ContextEventReader interface {
+	EventReader
+	// NextContext returns the next event, or the context's error once ctx is done
+	NextContext(ctx context.Context) (events.Event, error)
+}
+
 // StreamSessionManager manages streaming sessions
 type StreamSessionManager interface {
 	// StartEncodingSession initializes a streaming encoding session
@@ -122,7 +122,7 @@ func (d *JSONDecoder) Decode(ctx context.Context, data []byte) (events.Event, er
 
 	// Validate the event if requested
 	if d.options.ValidateEvents {
-		if err := event.Validate(); err != nil {
+		if err := events.ValidateEvent(event); err != nil {
 			return nil, &encoding.DecodingError{
 				Format:  "json",
 				Data:    data,
@@ -246,6 +246,13 @@ func (d *JSONDecoder) createEvent(eventType events.EventType, data []byte) (even
 			event = &e
 		}
 
+	case events.EventTypeMessageUpdate:
+		var e events.MessageUpdateEvent
+		err = decoder.Decode(&e)
+		if err == nil {
+			event = &e
+		}
+
 	case events.EventTypeTextMessageContent:
 		var e events.TextMessageContentEvent
 		err = decoder.Decode(&e)
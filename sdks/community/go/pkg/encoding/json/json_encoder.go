@@ -89,7 +89,7 @@ func (e *JSONEncoder) Encode(ctx context.Context, event events.Event) ([]byte, e
 
 	// Validate the event before encoding if requested
 	if e.options.ValidateOutput {
-		if err := event.Validate(); err != nil {
+		if err := events.ValidateEvent(event); err != nil {
 			return nil, &encoding.EncodingError{
 				Format:  "json",
 				Event:   event,
@@ -0,0 +1,140 @@
+// Package ndjson decodes AG-UI events from newline-delimited JSON streams, an
+// alternative to Server-Sent Events used by some upstreams.
+package ndjson
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+)
+
+// ErrPartialLine is returned when the stream ends in the middle of a line
+// that does not hold a complete event
+var ErrPartialLine = errors.New("NDJSON stream ended with a partial line")
+
+// ErrLineTooLarge is returned when a line exceeds the configured maximum size
+var ErrLineTooLarge = errors.New("NDJSON line exceeds maximum size")
+
+// Decoder reads AG-UI events from a newline-delimited JSON stream, one event
+// per line
+type Decoder struct {
+	reader *bufio.Reader
+	line   int
+
+	allowUnknown bool
+	maxLineBytes int
+}
+
+// DecoderOption defines options for creating decoders
+type DecoderOption func(*Decoder)
+
+// AllowUnknownEvents makes Next return events with unrecognized types as
+// *events.UnknownEvent instead of failing with events.ErrUnknownEventType
+func AllowUnknownEvents(allow bool) DecoderOption {
+	return func(d *Decoder) {
+		d.allowUnknown = allow
+	}
+}
+
+// WithMaxLineBytes limits the size of a single line, so that an upstream that
+// never sends a newline cannot exhaust memory. Longer lines are discarded
+// without being buffered and Next returns ErrLineTooLarge. Zero means no
+// limit.
+func WithMaxLineBytes(n int) DecoderOption {
+	return func(d *Decoder) {
+		d.maxLineBytes = n
+	}
+}
+
+// NewNDJSONDecoder creates a new NDJSON decoder reading from r
+func NewNDJSONDecoder(r io.Reader, options ...DecoderOption) *Decoder {
+	d := &Decoder{reader: bufio.NewReader(r)}
+	for _, opt := range options {
+		opt(d)
+	}
+	return d
+}
+
+// Next reads and decodes the next event from the stream, skipping blank
+// lines. It returns io.EOF once the stream ends. A final line without a
+// terminating newline is decoded if it holds a complete event; if its JSON is
+// cut off, Next returns an error wrapping ErrPartialLine. Other decoding
+// errors name the line and only affect that line, so callers may keep calling
+// Next.
+func (d *Decoder) Next() (events.Event, error) {
+	for {
+		line, oversized, err := d.readLine()
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("NDJSON read failed: %w", err)
+		}
+		atEOF := err != nil
+
+		if atEOF && len(line) == 0 && !oversized {
+			return nil, io.EOF
+		}
+		d.line++
+		if oversized {
+			return nil, fmt.Errorf("%w of %d bytes at line %d", ErrLineTooLarge, d.maxLineBytes, d.line)
+		}
+
+		data := bytes.TrimSpace(line)
+		if len(data) == 0 {
+			if atEOF {
+				return nil, io.EOF
+			}
+			continue
+		}
+
+		event, decodeErr := events.EventFromJSON(data)
+		if decodeErr != nil {
+			if d.allowUnknown && errors.Is(decodeErr, events.ErrUnknownEventType) {
+				unknown, err := events.NewUnknownEvent(data)
+				if err != nil {
+					return nil, err
+				}
+				return unknown, nil
+			}
+			if atEOF && isTruncated(decodeErr, data) {
+				return nil, fmt.Errorf("%w at line %d: %v", ErrPartialLine, d.line, decodeErr)
+			}
+			return nil, fmt.Errorf("failed to decode NDJSON event at line %d: %w", d.line, decodeErr)
+		}
+		return event, nil
+	}
+}
+
+// readLine reads a line including its terminator. With a line size limit
+// configured, the rest of a line that exceeds it is discarded as it is read
+// and oversized is set.
+func (d *Decoder) readLine() (line []byte, oversized bool, err error) {
+	for {
+		chunk, err := d.reader.ReadSlice('\n')
+		if !oversized {
+			size := len(line) + len(bytes.TrimRight(chunk, "\r\n"))
+			if d.maxLineBytes > 0 && size > d.maxLineBytes {
+				oversized = true
+				line = nil
+			} else {
+				line = append(line, chunk...)
+			}
+		}
+		if !errors.Is(err, bufio.ErrBufferFull) {
+			return line, oversized, err
+		}
+	}
+}
+
+// isTruncated reports whether a decoding error shows that the JSON in data
+// ended before it was complete
+func isTruncated(err error, data []byte) bool {
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return syntaxErr.Offset >= int64(len(data))
+	}
+	return errors.Is(err, io.ErrUnexpectedEOF)
+}
@@ -0,0 +1,119 @@
+package ndjson
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecoder(t *testing.T) {
+	input := strings.Join([]string{
+		`{"type":"RUN_STARTED","threadId":"thread-1","runId":"run-1"}`,
+		``,
+		`{"type":"TEXT_MESSAGE_CONTENT","messageId":"msg-1","delta":"hi"}` + "\r",
+		`   `,
+		`{"type":"RUN_FINISHED","threadId":"thread-1","runId":"run-1"}`,
+	}, "\n")
+
+	dec := NewNDJSONDecoder(strings.NewReader(input))
+	var types []events.EventType
+	for {
+		event, err := dec.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		types = append(types, event.Type())
+	}
+	assert.Equal(t, []events.EventType{
+		events.EventTypeRunStarted,
+		events.EventTypeTextMessageContent,
+		events.EventTypeRunFinished,
+	}, types)
+
+	_, err := dec.Next()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestDecoderErrors(t *testing.T) {
+	t.Run("BadLine", func(t *testing.T) {
+		input := "{\"type\":\"RUN_STARTED\",\"threadId\":\"t\",\"runId\":\"r\"}\n{not json}\n{\"type\":\"STEP_STARTED\",\"stepName\":\"plan\"}\n"
+		dec := NewNDJSONDecoder(strings.NewReader(input))
+
+		_, err := dec.Next()
+		require.NoError(t, err)
+
+		_, err = dec.Next()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "line 2")
+		assert.False(t, errors.Is(err, ErrPartialLine))
+
+		event, err := dec.Next()
+		require.NoError(t, err, "decoding continues after a bad line")
+		assert.Equal(t, events.EventTypeStepStarted, event.Type())
+	})
+
+	t.Run("PartialLine", func(t *testing.T) {
+		input := "{\"type\":\"STEP_STARTED\",\"stepName\":\"plan\"}\n{\"type\":\"STEP_FINI"
+		dec := NewNDJSONDecoder(strings.NewReader(input))
+
+		_, err := dec.Next()
+		require.NoError(t, err)
+
+		_, err = dec.Next()
+		assert.ErrorIs(t, err, ErrPartialLine)
+		assert.Contains(t, err.Error(), "line 2")
+
+		_, err = dec.Next()
+		assert.Equal(t, io.EOF, err)
+	})
+
+	t.Run("InvalidFinalLine", func(t *testing.T) {
+		// A complete but invalid last line is a bad line, not a partial one
+		for _, input := range []string{"{not json}", `{"type":"STEP_STARTED","stepName":5}`} {
+			_, err := NewNDJSONDecoder(strings.NewReader(input)).Next()
+			require.Error(t, err, input)
+			assert.False(t, errors.Is(err, ErrPartialLine), input)
+			assert.Contains(t, err.Error(), "line 1")
+		}
+	})
+
+	t.Run("MaxLineBytes", func(t *testing.T) {
+		long := `{"type":"TEXT_MESSAGE_CONTENT","messageId":"msg-1","delta":"` + strings.Repeat("x", 8192) + `"}`
+		input := long + "\n" + `{"type":"STEP_STARTED","stepName":"plan"}` + "\n" + long
+		dec := NewNDJSONDecoder(strings.NewReader(input), WithMaxLineBytes(1024))
+
+		_, err := dec.Next()
+		assert.ErrorIs(t, err, ErrLineTooLarge)
+		assert.Contains(t, err.Error(), "line 1")
+
+		event, err := dec.Next()
+		require.NoError(t, err, "decoding continues after an oversized line")
+		assert.Equal(t, events.EventTypeStepStarted, event.Type())
+
+		_, err = dec.Next()
+		assert.ErrorIs(t, err, ErrLineTooLarge)
+		_, err = dec.Next()
+		assert.Equal(t, io.EOF, err)
+
+		event, err = NewNDJSONDecoder(strings.NewReader(long)).Next()
+		require.NoError(t, err, "lines are unbounded by default")
+		assert.Equal(t, events.EventTypeTextMessageContent, event.Type())
+	})
+
+	t.Run("UnknownEvents", func(t *testing.T) {
+		input := `{"type":"FUTURE_EVENT","value":1}` + "\n"
+
+		_, err := NewNDJSONDecoder(strings.NewReader(input)).Next()
+		assert.ErrorIs(t, err, events.ErrUnknownEventType)
+
+		event, err := NewNDJSONDecoder(strings.NewReader(input), AllowUnknownEvents(true)).Next()
+		require.NoError(t, err)
+		assert.Equal(t, events.EventType("FUTURE_EVENT"), event.Type())
+	})
+}
@@ -0,0 +1,218 @@
+package proto
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/types"
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/encoding/proto/pb"
+)
+
+// toProtoBase converts the common event fields. Event types missing from the
+// schema's EventType enum (the chunk events) are left at the zero value, which
+// matches the output of the TypeScript SDK.
+func toProtoBase(event events.Event) (*pb.BaseEvent, error) {
+	base := &pb.BaseEvent{}
+	if value, ok := pb.EventType_value[string(event.Type())]; ok {
+		base.Type = pb.EventType(value)
+	}
+	if ts := event.Timestamp(); ts != nil {
+		timestamp := *ts
+		base.Timestamp = &timestamp
+	}
+	if raw := event.RawJSON(); raw != nil {
+		value := &structpb.Value{}
+		if err := protojson.Unmarshal(raw, value); err != nil {
+			return nil, fmt.Errorf("rawEvent: %w", err)
+		}
+		base.RawEvent = value
+	}
+	return base, nil
+}
+
+// fromProtoBase converts the common event fields
+func fromProtoBase(eventType events.EventType, base *pb.BaseEvent) *events.BaseEvent {
+	result := &events.BaseEvent{EventType: eventType}
+	if base != nil && base.Timestamp != nil {
+		timestamp := base.GetTimestamp()
+		result.TimestampMs = &timestamp
+	}
+	if base.GetRawEvent() != nil {
+		result.RawEvent = base.GetRawEvent().AsInterface()
+	}
+	return result
+}
+
+// toValue converts an arbitrary JSON-compatible value into a protobuf Value.
+// A nil value yields nil so that optional fields are omitted.
+func toValue(v any) (*structpb.Value, error) {
+	if v == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	value := &structpb.Value{}
+	if err := protojson.Unmarshal(data, value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// fromValue converts a protobuf Value into its plain Go representation
+func fromValue(v *structpb.Value) any {
+	if v == nil {
+		return nil
+	}
+	return v.AsInterface()
+}
+
+// fromValueMap converts a protobuf Value holding an object into a map
+func fromValueMap(v *structpb.Value) map[string]any {
+	if m, ok := fromValue(v).(map[string]any); ok {
+		return m
+	}
+	return nil
+}
+
+// optionalString returns a pointer to s, or nil when s is empty
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// toProtoPatch converts JSON Patch operations, mapping op names to the schema enum
+func toProtoPatch(ops []events.JSONPatchOperation) ([]*pb.JsonPatchOperation, error) {
+	result := make([]*pb.JsonPatchOperation, 0, len(ops))
+	for i, op := range ops {
+		opType, ok := pb.JsonPatchOperationType_value[strings.ToUpper(op.Op)]
+		if !ok {
+			return nil, fmt.Errorf("delta[%d]: unknown operation %q", i, op.Op)
+		}
+		value, err := toValue(op.Value)
+		if err != nil {
+			return nil, fmt.Errorf("delta[%d]: %w", i, err)
+		}
+		result = append(result, &pb.JsonPatchOperation{
+			Op:    pb.JsonPatchOperationType(opType),
+			Path:  op.Path,
+			From:  optionalString(op.From),
+			Value: value,
+		})
+	}
+	return result, nil
+}
+
+// fromProtoPatch converts JSON Patch operations, mapping the schema enum back to op names
+func fromProtoPatch(ops []*pb.JsonPatchOperation) ([]events.JSONPatchOperation, error) {
+	result := make([]events.JSONPatchOperation, 0, len(ops))
+	for i, op := range ops {
+		name, ok := pb.JsonPatchOperationType_name[int32(op.GetOp())]
+		if !ok {
+			return nil, fmt.Errorf("delta[%d]: unknown operation %d", i, op.GetOp())
+		}
+		result = append(result, events.JSONPatchOperation{
+			Op:    strings.ToLower(name),
+			Path:  op.GetPath(),
+			From:  op.GetFrom(),
+			Value: fromValue(op.GetValue()),
+		})
+	}
+	return result, nil
+}
+
+// toProtoRunFinished flattens the outcome into the schema's outcome and interrupts fields
+func toProtoRunFinished(e *events.RunFinishedEvent) (*pb.RunFinishedEvent, error) {
+	result, err := toValue(e.Result)
+	if err != nil {
+		return nil, fmt.Errorf("result: %w", err)
+	}
+	msg := &pb.RunFinishedEvent{
+		ThreadId: e.ThreadIDValue,
+		RunId:    e.RunIDValue,
+		Result:   result,
+	}
+	if e.Outcome != nil {
+		msg.Outcome = string(e.Outcome.Type)
+		for i, interrupt := range e.Outcome.Interrupts {
+			converted, err := toProtoInterrupt(interrupt)
+			if err != nil {
+				return nil, fmt.Errorf("interrupts[%d]: %w", i, err)
+			}
+			msg.Interrupts = append(msg.Interrupts, converted)
+		}
+	}
+	return msg, nil
+}
+
+// fromProtoRunFinished rebuilds the outcome from the schema's flat fields.
+// An empty outcome decodes to no outcome, as emitted by older SDKs.
+func fromProtoRunFinished(v *pb.RunFinishedEvent) *events.RunFinishedEvent {
+	event := &events.RunFinishedEvent{
+		BaseEvent:     fromProtoBase(events.EventTypeRunFinished, v.GetBaseEvent()),
+		ThreadIDValue: v.GetThreadId(),
+		RunIDValue:    v.GetRunId(),
+		Result:        fromValue(v.GetResult()),
+	}
+	switch events.RunFinishedOutcomeType(v.GetOutcome()) {
+	case events.RunFinishedOutcomeTypeSuccess:
+		event.Outcome = &events.RunFinishedOutcome{Type: events.RunFinishedOutcomeTypeSuccess}
+	case events.RunFinishedOutcomeTypeInterrupt:
+		outcome := &events.RunFinishedOutcome{Type: events.RunFinishedOutcomeTypeInterrupt}
+		for _, interrupt := range v.GetInterrupts() {
+			outcome.Interrupts = append(outcome.Interrupts, fromProtoInterrupt(interrupt))
+		}
+		event.Outcome = outcome
+	}
+	return event
+}
+
+// toProtoInterrupt converts an interrupt
+func toProtoInterrupt(interrupt types.Interrupt) (*pb.Interrupt, error) {
+	schema, err := toValue(nilIfEmptyMap(interrupt.ResponseSchema))
+	if err != nil {
+		return nil, fmt.Errorf("responseSchema: %w", err)
+	}
+	metadata, err := toValue(nilIfEmptyMap(interrupt.Metadata))
+	if err != nil {
+		return nil, fmt.Errorf("metadata: %w", err)
+	}
+	return &pb.Interrupt{
+		Id:             interrupt.ID,
+		Reason:         interrupt.Reason,
+		Message:        optionalString(interrupt.Message),
+		ToolCallId:     optionalString(interrupt.ToolCallID),
+		ResponseSchema: schema,
+		ExpiresAt:      optionalString(interrupt.ExpiresAt),
+		Metadata:       metadata,
+	}, nil
+}
+
+// fromProtoInterrupt converts an interrupt
+func fromProtoInterrupt(interrupt *pb.Interrupt) types.Interrupt {
+	return types.Interrupt{
+		ID:             interrupt.GetId(),
+		Reason:         interrupt.GetReason(),
+		Message:        interrupt.GetMessage(),
+		ToolCallID:     interrupt.GetToolCallId(),
+		ResponseSchema: fromValueMap(interrupt.GetResponseSchema()),
+		ExpiresAt:      interrupt.GetExpiresAt(),
+		Metadata:       fromValueMap(interrupt.GetMetadata()),
+	}
+}
+
+// nilIfEmptyMap returns nil for empty maps so that they are omitted
+func nilIfEmptyMap(m map[string]any) any {
+	if len(m) == 0 {
+		return nil
+	}
+	return m
+}
@@ -0,0 +1,215 @@
+package proto
+
+import (
+	"fmt"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/types"
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/encoding/proto/pb"
+)
+
+// toProtoMessages converts snapshot messages. String content maps to the
+// content field and multimodal user content maps to content parts.
+func toProtoMessages(messages []events.Message) ([]*pb.Message, error) {
+	result := make([]*pb.Message, 0, len(messages))
+	for i, msg := range messages {
+		converted := &pb.Message{
+			Id:         msg.ID,
+			Role:       string(msg.Role),
+			Name:       optionalString(msg.Name),
+			ToolCallId: optionalString(msg.ToolCallID),
+			Error:      optionalString(msg.Error),
+		}
+
+		if _, ok := msg.ContentAssistantContents(); ok {
+			return nil, fmt.Errorf("messages[%d]: assistant content parts cannot be represented in protobuf", i)
+		} else if text, ok := msg.ContentString(); ok {
+			converted.Content = &text
+		} else if parts, ok := msg.ContentInputContents(); ok {
+			for j, part := range parts {
+				protoPart, err := toProtoInputContent(part)
+				if err != nil {
+					return nil, fmt.Errorf("messages[%d].content[%d]: %w", i, j, err)
+				}
+				converted.ContentParts = append(converted.ContentParts, protoPart)
+			}
+		} else if msg.Content != nil {
+			return nil, fmt.Errorf("messages[%d]: %s message content cannot be represented in protobuf", i, msg.Role)
+		}
+
+		for _, toolCall := range msg.ToolCalls {
+			converted.ToolCalls = append(converted.ToolCalls, &pb.ToolCall{
+				Id:   toolCall.ID,
+				Type: toolCall.Type,
+				Function: &pb.ToolCall_Function{
+					Name:      toolCall.Function.Name,
+					Arguments: toolCall.Function.Arguments,
+				},
+			})
+		}
+
+		result = append(result, converted)
+	}
+	return result, nil
+}
+
+// fromProtoMessages converts snapshot messages
+func fromProtoMessages(messages []*pb.Message) []events.Message {
+	result := make([]events.Message, 0, len(messages))
+	for _, msg := range messages {
+		converted := events.Message{
+			ID:         msg.GetId(),
+			Role:       types.Role(msg.GetRole()),
+			Name:       msg.GetName(),
+			ToolCallID: msg.GetToolCallId(),
+			Error:      msg.GetError(),
+		}
+
+		if converted.Role == types.RoleUser && len(msg.GetContentParts()) > 0 {
+			parts := make([]types.InputContent, 0, len(msg.GetContentParts()))
+			for _, part := range msg.GetContentParts() {
+				if inputPart, ok := fromProtoInputContent(part); ok {
+					parts = append(parts, inputPart)
+				}
+			}
+			converted.Content = parts
+		} else if msg.Content != nil {
+			converted.Content = msg.GetContent()
+		}
+
+		for _, toolCall := range msg.GetToolCalls() {
+			converted.ToolCalls = append(converted.ToolCalls, events.ToolCall{
+				ID:   toolCall.GetId(),
+				Type: toolCall.GetType(),
+				Function: events.Function{
+					Name:      toolCall.GetFunction().GetName(),
+					Arguments: toolCall.GetFunction().GetArguments(),
+				},
+			})
+		}
+
+		result = append(result, converted)
+	}
+	return result
+}
+
+// toProtoInputContent converts a multimodal content part. Legacy binary parts
+// are sent as documents flagged with legacyBinary metadata, as the TypeScript SDK does.
+func toProtoInputContent(part types.InputContent) (*pb.InputContent, error) {
+	switch part.Type {
+	case types.InputContentTypeText:
+		return &pb.InputContent{Part: &pb.InputContent_Text{Text: &pb.TextInputPart{Text: part.Text}}}, nil
+
+	case types.InputContentTypeImage, types.InputContentTypeAudio, types.InputContentTypeVideo, types.InputContentTypeDocument:
+		metadata, err := toValue(part.Metadata)
+		if err != nil {
+			return nil, fmt.Errorf("metadata: %w", err)
+		}
+		source := toProtoSource(part.Source)
+		switch part.Type {
+		case types.InputContentTypeImage:
+			return &pb.InputContent{Part: &pb.InputContent_Image{Image: &pb.ImageInputPart{Source: source, Metadata: metadata}}}, nil
+		case types.InputContentTypeAudio:
+			return &pb.InputContent{Part: &pb.InputContent_Audio{Audio: &pb.AudioInputPart{Source: source, Metadata: metadata}}}, nil
+		case types.InputContentTypeVideo:
+			return &pb.InputContent{Part: &pb.InputContent_Video{Video: &pb.VideoInputPart{Source: source, Metadata: metadata}}}, nil
+		default:
+			return &pb.InputContent{Part: &pb.InputContent_Document{Document: &pb.DocumentInputPart{Source: source, Metadata: metadata}}}, nil
+		}
+
+	case types.InputContentTypeBinary:
+		var source *types.InputContentSource
+		switch {
+		case part.Data != "":
+			source = &types.InputContentSource{Type: types.InputContentSourceTypeData, Value: part.Data, MimeType: part.MimeType}
+		case part.URL != "":
+			source = &types.InputContentSource{Type: types.InputContentSourceTypeURL, Value: part.URL, MimeType: part.MimeType}
+		case part.ID != "":
+			source = &types.InputContentSource{Type: types.InputContentSourceTypeURL, Value: part.ID, MimeType: part.MimeType}
+		default:
+			return nil, fmt.Errorf("binary content requires data, url or id")
+		}
+		legacy := map[string]any{"legacyBinary": true}
+		if part.Filename != "" {
+			legacy["filename"] = part.Filename
+		}
+		if part.ID != "" {
+			legacy["id"] = part.ID
+		}
+		metadata, err := toValue(legacy)
+		if err != nil {
+			return nil, err
+		}
+		return &pb.InputContent{Part: &pb.InputContent_Document{Document: &pb.DocumentInputPart{
+			Source:   toProtoSource(source),
+			Metadata: metadata,
+		}}}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported content type %q", part.Type)
+}
+
+// fromProtoInputContent converts a multimodal content part
+func fromProtoInputContent(part *pb.InputContent) (types.InputContent, bool) {
+	switch p := part.GetPart().(type) {
+	case *pb.InputContent_Text:
+		return types.InputContent{Type: types.InputContentTypeText, Text: p.Text.GetText()}, true
+	case *pb.InputContent_Image:
+		return fromProtoMediaPart(types.InputContentTypeImage, p.Image.GetSource(), fromValue(p.Image.GetMetadata())), true
+	case *pb.InputContent_Audio:
+		return fromProtoMediaPart(types.InputContentTypeAudio, p.Audio.GetSource(), fromValue(p.Audio.GetMetadata())), true
+	case *pb.InputContent_Video:
+		return fromProtoMediaPart(types.InputContentTypeVideo, p.Video.GetSource(), fromValue(p.Video.GetMetadata())), true
+	case *pb.InputContent_Document:
+		return fromProtoMediaPart(types.InputContentTypeDocument, p.Document.GetSource(), fromValue(p.Document.GetMetadata())), true
+	}
+	return types.InputContent{}, false
+}
+
+// fromProtoMediaPart builds a typed multimodal content part
+func fromProtoMediaPart(contentType string, source *pb.InputContentSource, metadata any) types.InputContent {
+	return types.InputContent{
+		Type:     contentType,
+		Source:   fromProtoSource(source),
+		Metadata: metadata,
+	}
+}
+
+// toProtoSource converts a content source
+func toProtoSource(source *types.InputContentSource) *pb.InputContentSource {
+	if source == nil {
+		return nil
+	}
+	switch source.Type {
+	case types.InputContentSourceTypeData:
+		return &pb.InputContentSource{Source: &pb.InputContentSource_Data{Data: &pb.InputContentDataSource{
+			Value:    source.Value,
+			MimeType: source.MimeType,
+		}}}
+	case types.InputContentSourceTypeURL:
+		return &pb.InputContentSource{Source: &pb.InputContentSource_Url{Url: &pb.InputContentUrlSource{
+			Value:    source.Value,
+			MimeType: optionalString(source.MimeType),
+		}}}
+	}
+	return nil
+}
+
+// fromProtoSource converts a content source
+func fromProtoSource(source *pb.InputContentSource) *types.InputContentSource {
+	switch s := source.GetSource().(type) {
+	case *pb.InputContentSource_Data:
+		return &types.InputContentSource{
+			Type:     types.InputContentSourceTypeData,
+			Value:    s.Data.GetValue(),
+			MimeType: s.Data.GetMimeType(),
+		}
+	case *pb.InputContentSource_Url:
+		return &types.InputContentSource{
+			Type:     types.InputContentSourceTypeURL,
+			Value:    s.Url.GetValue(),
+			MimeType: s.Url.GetMimeType(),
+		}
+	}
+	return nil
+}
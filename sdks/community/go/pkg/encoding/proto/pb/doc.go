@@ -0,0 +1,12 @@
+// Package pb contains the Go types generated from the shared AG-UI protobuf
+// schema in sdks/typescript/packages/proto/src/proto.
+//
+// Regenerate with:
+//
+//	protoc -I ../../../../../../typescript/packages/proto/src/proto \
+//		--go_out=. --go_opt=paths=source_relative \
+//		--go_opt=Mevents.proto=github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/encoding/proto/pb \
+//		--go_opt=Mpatch.proto=github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/encoding/proto/pb \
+//		--go_opt=Mtypes.proto=github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/encoding/proto/pb \
+//		events.proto patch.proto types.proto
+package pb
@@ -0,0 +1,225 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: patch.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type JsonPatchOperationType int32
+
+const (
+	JsonPatchOperationType_ADD     JsonPatchOperationType = 0
+	JsonPatchOperationType_REMOVE  JsonPatchOperationType = 1
+	JsonPatchOperationType_REPLACE JsonPatchOperationType = 2
+	JsonPatchOperationType_MOVE    JsonPatchOperationType = 3
+	JsonPatchOperationType_COPY    JsonPatchOperationType = 4
+	JsonPatchOperationType_TEST    JsonPatchOperationType = 5
+)
+
+// Enum value maps for JsonPatchOperationType.
+var (
+	JsonPatchOperationType_name = map[int32]string{
+		0: "ADD",
+		1: "REMOVE",
+		2: "REPLACE",
+		3: "MOVE",
+		4: "COPY",
+		5: "TEST",
+	}
+	JsonPatchOperationType_value = map[string]int32{
+		"ADD":     0,
+		"REMOVE":  1,
+		"REPLACE": 2,
+		"MOVE":    3,
+		"COPY":    4,
+		"TEST":    5,
+	}
+)
+
+func (x JsonPatchOperationType) Enum() *JsonPatchOperationType {
+	p := new(JsonPatchOperationType)
+	*p = x
+	return p
+}
+
+func (x JsonPatchOperationType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (JsonPatchOperationType) Descriptor() protoreflect.EnumDescriptor {
+	return file_patch_proto_enumTypes[0].Descriptor()
+}
+
+func (JsonPatchOperationType) Type() protoreflect.EnumType {
+	return &file_patch_proto_enumTypes[0]
+}
+
+func (x JsonPatchOperationType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use JsonPatchOperationType.Descriptor instead.
+func (JsonPatchOperationType) EnumDescriptor() ([]byte, []int) {
+	return file_patch_proto_rawDescGZIP(), []int{0}
+}
+
+type JsonPatchOperation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Op            JsonPatchOperationType `protobuf:"varint,1,opt,name=op,proto3,enum=ag_ui.JsonPatchOperationType" json:"op,omitempty"`
+	Path          string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	From          *string                `protobuf:"bytes,3,opt,name=from,proto3,oneof" json:"from,omitempty"`
+	Value         *structpb.Value        `protobuf:"bytes,4,opt,name=value,proto3,oneof" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JsonPatchOperation) Reset() {
+	*x = JsonPatchOperation{}
+	mi := &file_patch_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JsonPatchOperation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JsonPatchOperation) ProtoMessage() {}
+
+func (x *JsonPatchOperation) ProtoReflect() protoreflect.Message {
+	mi := &file_patch_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JsonPatchOperation.ProtoReflect.Descriptor instead.
+func (*JsonPatchOperation) Descriptor() ([]byte, []int) {
+	return file_patch_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *JsonPatchOperation) GetOp() JsonPatchOperationType {
+	if x != nil {
+		return x.Op
+	}
+	return JsonPatchOperationType_ADD
+}
+
+func (x *JsonPatchOperation) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *JsonPatchOperation) GetFrom() string {
+	if x != nil && x.From != nil {
+		return *x.From
+	}
+	return ""
+}
+
+func (x *JsonPatchOperation) GetValue() *structpb.Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+var File_patch_proto protoreflect.FileDescriptor
+
+const file_patch_proto_rawDesc = "" +
+	"\n" +
+	"\vpatch.proto\x12\x05ag_ui\x1a\x1cgoogle/protobuf/struct.proto\"\xb6\x01\n" +
+	"\x12JsonPatchOperation\x12-\n" +
+	"\x02op\x18\x01 \x01(\x0e2\x1d.ag_ui.JsonPatchOperationTypeR\x02op\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12\x17\n" +
+	"\x04from\x18\x03 \x01(\tH\x00R\x04from\x88\x01\x01\x121\n" +
+	"\x05value\x18\x04 \x01(\v2\x16.google.protobuf.ValueH\x01R\x05value\x88\x01\x01B\a\n" +
+	"\x05_fromB\b\n" +
+	"\x06_value*X\n" +
+	"\x16JsonPatchOperationType\x12\a\n" +
+	"\x03ADD\x10\x00\x12\n" +
+	"\n" +
+	"\x06REMOVE\x10\x01\x12\v\n" +
+	"\aREPLACE\x10\x02\x12\b\n" +
+	"\x04MOVE\x10\x03\x12\b\n" +
+	"\x04COPY\x10\x04\x12\b\n" +
+	"\x04TEST\x10\x05B\x17\xaa\x02\x14AGUI.ProtocolBuffersb\x06proto3"
+
+var (
+	file_patch_proto_rawDescOnce sync.Once
+	file_patch_proto_rawDescData []byte
+)
+
+func file_patch_proto_rawDescGZIP() []byte {
+	file_patch_proto_rawDescOnce.Do(func() {
+		file_patch_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_patch_proto_rawDesc), len(file_patch_proto_rawDesc)))
+	})
+	return file_patch_proto_rawDescData
+}
+
+var file_patch_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_patch_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_patch_proto_goTypes = []any{
+	(JsonPatchOperationType)(0), // 0: ag_ui.JsonPatchOperationType
+	(*JsonPatchOperation)(nil),  // 1: ag_ui.JsonPatchOperation
+	(*structpb.Value)(nil),      // 2: google.protobuf.Value
+}
+var file_patch_proto_depIdxs = []int32{
+	0, // 0: ag_ui.JsonPatchOperation.op:type_name -> ag_ui.JsonPatchOperationType
+	2, // 1: ag_ui.JsonPatchOperation.value:type_name -> google.protobuf.Value
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_patch_proto_init() }
+func file_patch_proto_init() {
+	if File_patch_proto != nil {
+		return
+	}
+	file_patch_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_patch_proto_rawDesc), len(file_patch_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_patch_proto_goTypes,
+		DependencyIndexes: file_patch_proto_depIdxs,
+		EnumInfos:         file_patch_proto_enumTypes,
+		MessageInfos:      file_patch_proto_msgTypes,
+	}.Build()
+	File_patch_proto = out.File
+	file_patch_proto_goTypes = nil
+	file_patch_proto_depIdxs = nil
+}
@@ -0,0 +1,1076 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: types.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ToolCall struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Function      *ToolCall_Function     `protobuf:"bytes,3,opt,name=function,proto3" json:"function,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ToolCall) Reset() {
+	*x = ToolCall{}
+	mi := &file_types_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ToolCall) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ToolCall) ProtoMessage() {}
+
+func (x *ToolCall) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ToolCall.ProtoReflect.Descriptor instead.
+func (*ToolCall) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ToolCall) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ToolCall) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ToolCall) GetFunction() *ToolCall_Function {
+	if x != nil {
+		return x.Function
+	}
+	return nil
+}
+
+type InputContentDataSource struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	MimeType      string                 `protobuf:"bytes,2,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InputContentDataSource) Reset() {
+	*x = InputContentDataSource{}
+	mi := &file_types_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InputContentDataSource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InputContentDataSource) ProtoMessage() {}
+
+func (x *InputContentDataSource) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InputContentDataSource.ProtoReflect.Descriptor instead.
+func (*InputContentDataSource) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *InputContentDataSource) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *InputContentDataSource) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
+type InputContentUrlSource struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	MimeType      *string                `protobuf:"bytes,2,opt,name=mime_type,json=mimeType,proto3,oneof" json:"mime_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InputContentUrlSource) Reset() {
+	*x = InputContentUrlSource{}
+	mi := &file_types_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InputContentUrlSource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InputContentUrlSource) ProtoMessage() {}
+
+func (x *InputContentUrlSource) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InputContentUrlSource.ProtoReflect.Descriptor instead.
+func (*InputContentUrlSource) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *InputContentUrlSource) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *InputContentUrlSource) GetMimeType() string {
+	if x != nil && x.MimeType != nil {
+		return *x.MimeType
+	}
+	return ""
+}
+
+type InputContentSource struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Source:
+	//
+	//	*InputContentSource_Data
+	//	*InputContentSource_Url
+	Source        isInputContentSource_Source `protobuf_oneof:"source"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InputContentSource) Reset() {
+	*x = InputContentSource{}
+	mi := &file_types_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InputContentSource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InputContentSource) ProtoMessage() {}
+
+func (x *InputContentSource) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InputContentSource.ProtoReflect.Descriptor instead.
+func (*InputContentSource) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *InputContentSource) GetSource() isInputContentSource_Source {
+	if x != nil {
+		return x.Source
+	}
+	return nil
+}
+
+func (x *InputContentSource) GetData() *InputContentDataSource {
+	if x != nil {
+		if x, ok := x.Source.(*InputContentSource_Data); ok {
+			return x.Data
+		}
+	}
+	return nil
+}
+
+func (x *InputContentSource) GetUrl() *InputContentUrlSource {
+	if x != nil {
+		if x, ok := x.Source.(*InputContentSource_Url); ok {
+			return x.Url
+		}
+	}
+	return nil
+}
+
+type isInputContentSource_Source interface {
+	isInputContentSource_Source()
+}
+
+type InputContentSource_Data struct {
+	Data *InputContentDataSource `protobuf:"bytes,1,opt,name=data,proto3,oneof"`
+}
+
+type InputContentSource_Url struct {
+	Url *InputContentUrlSource `protobuf:"bytes,2,opt,name=url,proto3,oneof"`
+}
+
+func (*InputContentSource_Data) isInputContentSource_Source() {}
+
+func (*InputContentSource_Url) isInputContentSource_Source() {}
+
+type TextInputPart struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TextInputPart) Reset() {
+	*x = TextInputPart{}
+	mi := &file_types_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TextInputPart) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TextInputPart) ProtoMessage() {}
+
+func (x *TextInputPart) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TextInputPart.ProtoReflect.Descriptor instead.
+func (*TextInputPart) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *TextInputPart) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+type ImageInputPart struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Source        *InputContentSource    `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	Metadata      *structpb.Value        `protobuf:"bytes,2,opt,name=metadata,proto3,oneof" json:"metadata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImageInputPart) Reset() {
+	*x = ImageInputPart{}
+	mi := &file_types_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImageInputPart) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImageInputPart) ProtoMessage() {}
+
+func (x *ImageInputPart) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImageInputPart.ProtoReflect.Descriptor instead.
+func (*ImageInputPart) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ImageInputPart) GetSource() *InputContentSource {
+	if x != nil {
+		return x.Source
+	}
+	return nil
+}
+
+func (x *ImageInputPart) GetMetadata() *structpb.Value {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type AudioInputPart struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Source        *InputContentSource    `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	Metadata      *structpb.Value        `protobuf:"bytes,2,opt,name=metadata,proto3,oneof" json:"metadata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AudioInputPart) Reset() {
+	*x = AudioInputPart{}
+	mi := &file_types_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AudioInputPart) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AudioInputPart) ProtoMessage() {}
+
+func (x *AudioInputPart) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AudioInputPart.ProtoReflect.Descriptor instead.
+func (*AudioInputPart) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *AudioInputPart) GetSource() *InputContentSource {
+	if x != nil {
+		return x.Source
+	}
+	return nil
+}
+
+func (x *AudioInputPart) GetMetadata() *structpb.Value {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type VideoInputPart struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Source        *InputContentSource    `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	Metadata      *structpb.Value        `protobuf:"bytes,2,opt,name=metadata,proto3,oneof" json:"metadata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VideoInputPart) Reset() {
+	*x = VideoInputPart{}
+	mi := &file_types_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VideoInputPart) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VideoInputPart) ProtoMessage() {}
+
+func (x *VideoInputPart) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VideoInputPart.ProtoReflect.Descriptor instead.
+func (*VideoInputPart) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *VideoInputPart) GetSource() *InputContentSource {
+	if x != nil {
+		return x.Source
+	}
+	return nil
+}
+
+func (x *VideoInputPart) GetMetadata() *structpb.Value {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type DocumentInputPart struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Source        *InputContentSource    `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	Metadata      *structpb.Value        `protobuf:"bytes,2,opt,name=metadata,proto3,oneof" json:"metadata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DocumentInputPart) Reset() {
+	*x = DocumentInputPart{}
+	mi := &file_types_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DocumentInputPart) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DocumentInputPart) ProtoMessage() {}
+
+func (x *DocumentInputPart) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DocumentInputPart.ProtoReflect.Descriptor instead.
+func (*DocumentInputPart) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DocumentInputPart) GetSource() *InputContentSource {
+	if x != nil {
+		return x.Source
+	}
+	return nil
+}
+
+func (x *DocumentInputPart) GetMetadata() *structpb.Value {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type InputContent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Part:
+	//
+	//	*InputContent_Text
+	//	*InputContent_Image
+	//	*InputContent_Audio
+	//	*InputContent_Video
+	//	*InputContent_Document
+	Part          isInputContent_Part `protobuf_oneof:"part"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InputContent) Reset() {
+	*x = InputContent{}
+	mi := &file_types_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InputContent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InputContent) ProtoMessage() {}
+
+func (x *InputContent) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InputContent.ProtoReflect.Descriptor instead.
+func (*InputContent) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *InputContent) GetPart() isInputContent_Part {
+	if x != nil {
+		return x.Part
+	}
+	return nil
+}
+
+func (x *InputContent) GetText() *TextInputPart {
+	if x != nil {
+		if x, ok := x.Part.(*InputContent_Text); ok {
+			return x.Text
+		}
+	}
+	return nil
+}
+
+func (x *InputContent) GetImage() *ImageInputPart {
+	if x != nil {
+		if x, ok := x.Part.(*InputContent_Image); ok {
+			return x.Image
+		}
+	}
+	return nil
+}
+
+func (x *InputContent) GetAudio() *AudioInputPart {
+	if x != nil {
+		if x, ok := x.Part.(*InputContent_Audio); ok {
+			return x.Audio
+		}
+	}
+	return nil
+}
+
+func (x *InputContent) GetVideo() *VideoInputPart {
+	if x != nil {
+		if x, ok := x.Part.(*InputContent_Video); ok {
+			return x.Video
+		}
+	}
+	return nil
+}
+
+func (x *InputContent) GetDocument() *DocumentInputPart {
+	if x != nil {
+		if x, ok := x.Part.(*InputContent_Document); ok {
+			return x.Document
+		}
+	}
+	return nil
+}
+
+type isInputContent_Part interface {
+	isInputContent_Part()
+}
+
+type InputContent_Text struct {
+	Text *TextInputPart `protobuf:"bytes,1,opt,name=text,proto3,oneof"`
+}
+
+type InputContent_Image struct {
+	Image *ImageInputPart `protobuf:"bytes,2,opt,name=image,proto3,oneof"`
+}
+
+type InputContent_Audio struct {
+	Audio *AudioInputPart `protobuf:"bytes,3,opt,name=audio,proto3,oneof"`
+}
+
+type InputContent_Video struct {
+	Video *VideoInputPart `protobuf:"bytes,4,opt,name=video,proto3,oneof"`
+}
+
+type InputContent_Document struct {
+	Document *DocumentInputPart `protobuf:"bytes,5,opt,name=document,proto3,oneof"`
+}
+
+func (*InputContent_Text) isInputContent_Part() {}
+
+func (*InputContent_Image) isInputContent_Part() {}
+
+func (*InputContent_Audio) isInputContent_Part() {}
+
+func (*InputContent_Video) isInputContent_Part() {}
+
+func (*InputContent_Document) isInputContent_Part() {}
+
+type Message struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Role          string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	Content       *string                `protobuf:"bytes,3,opt,name=content,proto3,oneof" json:"content,omitempty"`
+	Name          *string                `protobuf:"bytes,4,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	ToolCalls     []*ToolCall            `protobuf:"bytes,5,rep,name=tool_calls,json=toolCalls,proto3" json:"tool_calls,omitempty"`
+	ToolCallId    *string                `protobuf:"bytes,6,opt,name=tool_call_id,json=toolCallId,proto3,oneof" json:"tool_call_id,omitempty"`
+	Error         *string                `protobuf:"bytes,7,opt,name=error,proto3,oneof" json:"error,omitempty"`
+	ContentParts  []*InputContent        `protobuf:"bytes,8,rep,name=content_parts,json=contentParts,proto3" json:"content_parts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Message) Reset() {
+	*x = Message{}
+	mi := &file_types_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Message) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Message) ProtoMessage() {}
+
+func (x *Message) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Message.ProtoReflect.Descriptor instead.
+func (*Message) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Message) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Message) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *Message) GetContent() string {
+	if x != nil && x.Content != nil {
+		return *x.Content
+	}
+	return ""
+}
+
+func (x *Message) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *Message) GetToolCalls() []*ToolCall {
+	if x != nil {
+		return x.ToolCalls
+	}
+	return nil
+}
+
+func (x *Message) GetToolCallId() string {
+	if x != nil && x.ToolCallId != nil {
+		return *x.ToolCallId
+	}
+	return ""
+}
+
+func (x *Message) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+func (x *Message) GetContentParts() []*InputContent {
+	if x != nil {
+		return x.ContentParts
+	}
+	return nil
+}
+
+type Interrupt struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Reason         string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	Message        *string                `protobuf:"bytes,3,opt,name=message,proto3,oneof" json:"message,omitempty"`
+	ToolCallId     *string                `protobuf:"bytes,4,opt,name=tool_call_id,json=toolCallId,proto3,oneof" json:"tool_call_id,omitempty"`
+	ResponseSchema *structpb.Value        `protobuf:"bytes,5,opt,name=response_schema,json=responseSchema,proto3,oneof" json:"response_schema,omitempty"`
+	ExpiresAt      *string                `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3,oneof" json:"expires_at,omitempty"`
+	Metadata       *structpb.Value        `protobuf:"bytes,7,opt,name=metadata,proto3,oneof" json:"metadata,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Interrupt) Reset() {
+	*x = Interrupt{}
+	mi := &file_types_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Interrupt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Interrupt) ProtoMessage() {}
+
+func (x *Interrupt) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Interrupt.ProtoReflect.Descriptor instead.
+func (*Interrupt) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Interrupt) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Interrupt) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Interrupt) GetMessage() string {
+	if x != nil && x.Message != nil {
+		return *x.Message
+	}
+	return ""
+}
+
+func (x *Interrupt) GetToolCallId() string {
+	if x != nil && x.ToolCallId != nil {
+		return *x.ToolCallId
+	}
+	return ""
+}
+
+func (x *Interrupt) GetResponseSchema() *structpb.Value {
+	if x != nil {
+		return x.ResponseSchema
+	}
+	return nil
+}
+
+func (x *Interrupt) GetExpiresAt() string {
+	if x != nil && x.ExpiresAt != nil {
+		return *x.ExpiresAt
+	}
+	return ""
+}
+
+func (x *Interrupt) GetMetadata() *structpb.Value {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type ToolCall_Function struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Arguments     string                 `protobuf:"bytes,2,opt,name=arguments,proto3" json:"arguments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ToolCall_Function) Reset() {
+	*x = ToolCall_Function{}
+	mi := &file_types_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ToolCall_Function) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ToolCall_Function) ProtoMessage() {}
+
+func (x *ToolCall_Function) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ToolCall_Function.ProtoReflect.Descriptor instead.
+func (*ToolCall_Function) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *ToolCall_Function) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ToolCall_Function) GetArguments() string {
+	if x != nil {
+		return x.Arguments
+	}
+	return ""
+}
+
+var File_types_proto protoreflect.FileDescriptor
+
+const file_types_proto_rawDesc = "" +
+	"\n" +
+	"\vtypes.proto\x12\x05ag_ui\x1a\x1cgoogle/protobuf/struct.proto\"\xa2\x01\n" +
+	"\bToolCall\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x124\n" +
+	"\bfunction\x18\x03 \x01(\v2\x18.ag_ui.ToolCall.FunctionR\bfunction\x1a<\n" +
+	"\bFunction\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
+	"\targuments\x18\x02 \x01(\tR\targuments\"K\n" +
+	"\x16InputContentDataSource\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\x12\x1b\n" +
+	"\tmime_type\x18\x02 \x01(\tR\bmimeType\"]\n" +
+	"\x15InputContentUrlSource\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\x12 \n" +
+	"\tmime_type\x18\x02 \x01(\tH\x00R\bmimeType\x88\x01\x01B\f\n" +
+	"\n" +
+	"_mime_type\"\x85\x01\n" +
+	"\x12InputContentSource\x123\n" +
+	"\x04data\x18\x01 \x01(\v2\x1d.ag_ui.InputContentDataSourceH\x00R\x04data\x120\n" +
+	"\x03url\x18\x02 \x01(\v2\x1c.ag_ui.InputContentUrlSourceH\x00R\x03urlB\b\n" +
+	"\x06source\"#\n" +
+	"\rTextInputPart\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\"\x89\x01\n" +
+	"\x0eImageInputPart\x121\n" +
+	"\x06source\x18\x01 \x01(\v2\x19.ag_ui.InputContentSourceR\x06source\x127\n" +
+	"\bmetadata\x18\x02 \x01(\v2\x16.google.protobuf.ValueH\x00R\bmetadata\x88\x01\x01B\v\n" +
+	"\t_metadata\"\x89\x01\n" +
+	"\x0eAudioInputPart\x121\n" +
+	"\x06source\x18\x01 \x01(\v2\x19.ag_ui.InputContentSourceR\x06source\x127\n" +
+	"\bmetadata\x18\x02 \x01(\v2\x16.google.protobuf.ValueH\x00R\bmetadata\x88\x01\x01B\v\n" +
+	"\t_metadata\"\x89\x01\n" +
+	"\x0eVideoInputPart\x121\n" +
+	"\x06source\x18\x01 \x01(\v2\x19.ag_ui.InputContentSourceR\x06source\x127\n" +
+	"\bmetadata\x18\x02 \x01(\v2\x16.google.protobuf.ValueH\x00R\bmetadata\x88\x01\x01B\v\n" +
+	"\t_metadata\"\x8c\x01\n" +
+	"\x11DocumentInputPart\x121\n" +
+	"\x06source\x18\x01 \x01(\v2\x19.ag_ui.InputContentSourceR\x06source\x127\n" +
+	"\bmetadata\x18\x02 \x01(\v2\x16.google.protobuf.ValueH\x00R\bmetadata\x88\x01\x01B\v\n" +
+	"\t_metadata\"\x87\x02\n" +
+	"\fInputContent\x12*\n" +
+	"\x04text\x18\x01 \x01(\v2\x14.ag_ui.TextInputPartH\x00R\x04text\x12-\n" +
+	"\x05image\x18\x02 \x01(\v2\x15.ag_ui.ImageInputPartH\x00R\x05image\x12-\n" +
+	"\x05audio\x18\x03 \x01(\v2\x15.ag_ui.AudioInputPartH\x00R\x05audio\x12-\n" +
+	"\x05video\x18\x04 \x01(\v2\x15.ag_ui.VideoInputPartH\x00R\x05video\x126\n" +
+	"\bdocument\x18\x05 \x01(\v2\x18.ag_ui.DocumentInputPartH\x00R\bdocumentB\x06\n" +
+	"\x04part\"\xc1\x02\n" +
+	"\aMessage\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\x12\x1d\n" +
+	"\acontent\x18\x03 \x01(\tH\x00R\acontent\x88\x01\x01\x12\x17\n" +
+	"\x04name\x18\x04 \x01(\tH\x01R\x04name\x88\x01\x01\x12.\n" +
+	"\n" +
+	"tool_calls\x18\x05 \x03(\v2\x0f.ag_ui.ToolCallR\ttoolCalls\x12%\n" +
+	"\ftool_call_id\x18\x06 \x01(\tH\x02R\n" +
+	"toolCallId\x88\x01\x01\x12\x19\n" +
+	"\x05error\x18\a \x01(\tH\x03R\x05error\x88\x01\x01\x128\n" +
+	"\rcontent_parts\x18\b \x03(\v2\x13.ag_ui.InputContentR\fcontentPartsB\n" +
+	"\n" +
+	"\b_contentB\a\n" +
+	"\x05_nameB\x0f\n" +
+	"\r_tool_call_idB\b\n" +
+	"\x06_error\"\xe9\x02\n" +
+	"\tInterrupt\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12\x1d\n" +
+	"\amessage\x18\x03 \x01(\tH\x00R\amessage\x88\x01\x01\x12%\n" +
+	"\ftool_call_id\x18\x04 \x01(\tH\x01R\n" +
+	"toolCallId\x88\x01\x01\x12D\n" +
+	"\x0fresponse_schema\x18\x05 \x01(\v2\x16.google.protobuf.ValueH\x02R\x0eresponseSchema\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\tH\x03R\texpiresAt\x88\x01\x01\x127\n" +
+	"\bmetadata\x18\a \x01(\v2\x16.google.protobuf.ValueH\x04R\bmetadata\x88\x01\x01B\n" +
+	"\n" +
+	"\b_messageB\x0f\n" +
+	"\r_tool_call_idB\x12\n" +
+	"\x10_response_schemaB\r\n" +
+	"\v_expires_atB\v\n" +
+	"\t_metadataB\x17\xaa\x02\x14AGUI.ProtocolBuffersb\x06proto3"
+
+var (
+	file_types_proto_rawDescOnce sync.Once
+	file_types_proto_rawDescData []byte
+)
+
+func file_types_proto_rawDescGZIP() []byte {
+	file_types_proto_rawDescOnce.Do(func() {
+		file_types_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_types_proto_rawDesc), len(file_types_proto_rawDesc)))
+	})
+	return file_types_proto_rawDescData
+}
+
+var file_types_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_types_proto_goTypes = []any{
+	(*ToolCall)(nil),               // 0: ag_ui.ToolCall
+	(*InputContentDataSource)(nil), // 1: ag_ui.InputContentDataSource
+	(*InputContentUrlSource)(nil),  // 2: ag_ui.InputContentUrlSource
+	(*InputContentSource)(nil),     // 3: ag_ui.InputContentSource
+	(*TextInputPart)(nil),          // 4: ag_ui.TextInputPart
+	(*ImageInputPart)(nil),         // 5: ag_ui.ImageInputPart
+	(*AudioInputPart)(nil),         // 6: ag_ui.AudioInputPart
+	(*VideoInputPart)(nil),         // 7: ag_ui.VideoInputPart
+	(*DocumentInputPart)(nil),      // 8: ag_ui.DocumentInputPart
+	(*InputContent)(nil),           // 9: ag_ui.InputContent
+	(*Message)(nil),                // 10: ag_ui.Message
+	(*Interrupt)(nil),              // 11: ag_ui.Interrupt
+	(*ToolCall_Function)(nil),      // 12: ag_ui.ToolCall.Function
+	(*structpb.Value)(nil),         // 13: google.protobuf.Value
+}
+var file_types_proto_depIdxs = []int32{
+	12, // 0: ag_ui.ToolCall.function:type_name -> ag_ui.ToolCall.Function
+	1,  // 1: ag_ui.InputContentSource.data:type_name -> ag_ui.InputContentDataSource
+	2,  // 2: ag_ui.InputContentSource.url:type_name -> ag_ui.InputContentUrlSource
+	3,  // 3: ag_ui.ImageInputPart.source:type_name -> ag_ui.InputContentSource
+	13, // 4: ag_ui.ImageInputPart.metadata:type_name -> google.protobuf.Value
+	3,  // 5: ag_ui.AudioInputPart.source:type_name -> ag_ui.InputContentSource
+	13, // 6: ag_ui.AudioInputPart.metadata:type_name -> google.protobuf.Value
+	3,  // 7: ag_ui.VideoInputPart.source:type_name -> ag_ui.InputContentSource
+	13, // 8: ag_ui.VideoInputPart.metadata:type_name -> google.protobuf.Value
+	3,  // 9: ag_ui.DocumentInputPart.source:type_name -> ag_ui.InputContentSource
+	13, // 10: ag_ui.DocumentInputPart.metadata:type_name -> google.protobuf.Value
+	4,  // 11: ag_ui.InputContent.text:type_name -> ag_ui.TextInputPart
+	5,  // 12: ag_ui.InputContent.image:type_name -> ag_ui.ImageInputPart
+	6,  // 13: ag_ui.InputContent.audio:type_name -> ag_ui.AudioInputPart
+	7,  // 14: ag_ui.InputContent.video:type_name -> ag_ui.VideoInputPart
+	8,  // 15: ag_ui.InputContent.document:type_name -> ag_ui.DocumentInputPart
+	0,  // 16: ag_ui.Message.tool_calls:type_name -> ag_ui.ToolCall
+	9,  // 17: ag_ui.Message.content_parts:type_name -> ag_ui.InputContent
+	13, // 18: ag_ui.Interrupt.response_schema:type_name -> google.protobuf.Value
+	13, // 19: ag_ui.Interrupt.metadata:type_name -> google.protobuf.Value
+	20, // [20:20] is the sub-list for method output_type
+	20, // [20:20] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
+}
+
+func init() { file_types_proto_init() }
+func file_types_proto_init() {
+	if File_types_proto != nil {
+		return
+	}
+	file_types_proto_msgTypes[2].OneofWrappers = []any{}
+	file_types_proto_msgTypes[3].OneofWrappers = []any{
+		(*InputContentSource_Data)(nil),
+		(*InputContentSource_Url)(nil),
+	}
+	file_types_proto_msgTypes[5].OneofWrappers = []any{}
+	file_types_proto_msgTypes[6].OneofWrappers = []any{}
+	file_types_proto_msgTypes[7].OneofWrappers = []any{}
+	file_types_proto_msgTypes[8].OneofWrappers = []any{}
+	file_types_proto_msgTypes[9].OneofWrappers = []any{
+		(*InputContent_Text)(nil),
+		(*InputContent_Image)(nil),
+		(*InputContent_Audio)(nil),
+		(*InputContent_Video)(nil),
+		(*InputContent_Document)(nil),
+	}
+	file_types_proto_msgTypes[10].OneofWrappers = []any{}
+	file_types_proto_msgTypes[11].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_types_proto_rawDesc), len(file_types_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_types_proto_goTypes,
+		DependencyIndexes: file_types_proto_depIdxs,
+		MessageInfos:      file_types_proto_msgTypes,
+	}.Build()
+	File_types_proto = out.File
+	file_types_proto_goTypes = nil
+	file_types_proto_depIdxs = nil
+}
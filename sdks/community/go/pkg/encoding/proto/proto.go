@@ -0,0 +1,380 @@
+// Package proto implements the AG-UI protobuf wire format shared with the
+// TypeScript and Python SDKs.
+//
+// Events are encoded as the ag_ui.Event oneof message. Only event types that
+// exist in the shared schema can be encoded; fields that the schema does not
+// carry (such as RunErrorEvent.Details) are dropped.
+package proto
+
+import (
+	"errors"
+	"fmt"
+
+	gproto "google.golang.org/protobuf/proto"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/encoding"
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/encoding/proto/pb"
+)
+
+// ContentType is the media type of AG-UI protobuf event streams
+const ContentType = "application/vnd.ag-ui.event+proto"
+
+// ErrUnsupportedEventType is returned for event types that the protobuf schema does not define
+var ErrUnsupportedEventType = errors.New("event type not supported by protobuf encoding")
+
+// Marshal encodes an event as an ag_ui.Event protobuf message
+func Marshal(event events.Event) ([]byte, error) {
+	if event == nil {
+		return nil, &encoding.EncodingError{Format: "protobuf", Message: "cannot encode nil event"}
+	}
+
+	msg, err := toProtoEvent(event)
+	if err != nil {
+		return nil, &encoding.EncodingError{Format: "protobuf", Event: event, Message: "failed to convert event", Cause: err}
+	}
+
+	data, err := gproto.Marshal(msg)
+	if err != nil {
+		return nil, &encoding.EncodingError{Format: "protobuf", Event: event, Message: "failed to marshal event", Cause: err}
+	}
+	return data, nil
+}
+
+// Unmarshal decodes an ag_ui.Event protobuf message into an event
+func Unmarshal(data []byte) (events.Event, error) {
+	var msg pb.Event
+	if err := gproto.Unmarshal(data, &msg); err != nil {
+		return nil, &encoding.DecodingError{Format: "protobuf", Data: data, Message: "failed to unmarshal event", Cause: err}
+	}
+
+	event, err := fromProtoEvent(&msg)
+	if err != nil {
+		return nil, &encoding.DecodingError{Format: "protobuf", Data: data, Message: "failed to convert event", Cause: err}
+	}
+	return event, nil
+}
+
+// toProtoEvent converts an event into the protobuf oneof wrapper
+func toProtoEvent(event events.Event) (*pb.Event, error) {
+	base, err := toProtoBase(event)
+	if err != nil {
+		return nil, err
+	}
+
+	switch e := event.(type) {
+	case *events.TextMessageStartEvent:
+		return &pb.Event{Event: &pb.Event_TextMessageStart{TextMessageStart: &pb.TextMessageStartEvent{
+			BaseEvent: base,
+			MessageId: e.MessageID,
+			Role:      e.Role,
+			Name:      optionalString(e.Name),
+		}}}, nil
+
+	case *events.TextMessageContentEvent:
+		return &pb.Event{Event: &pb.Event_TextMessageContent{TextMessageContent: &pb.TextMessageContentEvent{
+			BaseEvent: base,
+			MessageId: e.MessageID,
+			Delta:     e.Delta,
+		}}}, nil
+
+	case *events.TextMessageEndEvent:
+		return &pb.Event{Event: &pb.Event_TextMessageEnd{TextMessageEnd: &pb.TextMessageEndEvent{
+			BaseEvent: base,
+			MessageId: e.MessageID,
+		}}}, nil
+
+	case *events.TextMessageChunkEvent:
+		return &pb.Event{Event: &pb.Event_TextMessageChunk{TextMessageChunk: &pb.TextMessageChunkEvent{
+			BaseEvent: base,
+			MessageId: e.MessageID,
+			Role:      e.Role,
+			Delta:     e.Delta,
+			Name:      e.Name,
+		}}}, nil
+
+	case *events.ToolCallStartEvent:
+		if e.Index != nil {
+			return nil, fmt.Errorf("TOOL_CALL_START with an index is not supported by the protobuf encoding")
+		}
+		return &pb.Event{Event: &pb.Event_ToolCallStart{ToolCallStart: &pb.ToolCallStartEvent{
+			BaseEvent:       base,
+			ToolCallId:      e.ToolCallID,
+			ToolCallName:    e.ToolCallName,
+			ParentMessageId: e.ParentMessageID,
+		}}}, nil
+
+	case *events.ToolCallArgsEvent:
+		if e.Index != nil {
+			return nil, fmt.Errorf("TOOL_CALL_ARGS with an index is not supported by the protobuf encoding")
+		}
+		return &pb.Event{Event: &pb.Event_ToolCallArgs{ToolCallArgs: &pb.ToolCallArgsEvent{
+			BaseEvent:  base,
+			ToolCallId: e.ToolCallID,
+			Delta:      e.Delta,
+		}}}, nil
+
+	case *events.ToolCallEndEvent:
+		if e.Index != nil {
+			return nil, fmt.Errorf("TOOL_CALL_END with an index is not supported by the protobuf encoding")
+		}
+		return &pb.Event{Event: &pb.Event_ToolCallEnd{ToolCallEnd: &pb.ToolCallEndEvent{
+			BaseEvent:  base,
+			ToolCallId: e.ToolCallID,
+		}}}, nil
+
+	case *events.ToolCallChunkEvent:
+		return &pb.Event{Event: &pb.Event_ToolCallChunk{ToolCallChunk: &pb.ToolCallChunkEvent{
+			BaseEvent:       base,
+			ToolCallId:      e.ToolCallID,
+			ToolCallName:    e.ToolCallName,
+			ParentMessageId: e.ParentMessageID,
+			Delta:           e.Delta,
+		}}}, nil
+
+	case *events.StateSnapshotEvent:
+		snapshot, err := toValue(e.Snapshot)
+		if err != nil {
+			return nil, fmt.Errorf("snapshot: %w", err)
+		}
+		return &pb.Event{Event: &pb.Event_StateSnapshot{StateSnapshot: &pb.StateSnapshotEvent{
+			BaseEvent: base,
+			Snapshot:  snapshot,
+		}}}, nil
+
+	case *events.StateDeltaEvent:
+		if e.PatchFormat() != events.StatePatchJSONPatch {
+			return nil, fmt.Errorf("STATE_DELTA with %s is not supported by the protobuf encoding", e.PatchFormat())
+		}
+		delta, err := toProtoPatch(e.Delta)
+		if err != nil {
+			return nil, err
+		}
+		return &pb.Event{Event: &pb.Event_StateDelta{StateDelta: &pb.StateDeltaEvent{
+			BaseEvent: base,
+			Delta:     delta,
+		}}}, nil
+
+	case *events.MessagesSnapshotEvent:
+		if e.Partial {
+			return nil, fmt.Errorf("partial MESSAGES_SNAPSHOT is not supported by the protobuf encoding")
+		}
+		messages, err := toProtoMessages(e.Messages)
+		if err != nil {
+			return nil, err
+		}
+		return &pb.Event{Event: &pb.Event_MessagesSnapshot{MessagesSnapshot: &pb.MessagesSnapshotEvent{
+			BaseEvent: base,
+			Messages:  messages,
+		}}}, nil
+
+	case *events.RawEvent:
+		raw, err := toValue(e.Event)
+		if err != nil {
+			return nil, fmt.Errorf("event: %w", err)
+		}
+		return &pb.Event{Event: &pb.Event_Raw{Raw: &pb.RawEvent{
+			BaseEvent: base,
+			Event:     raw,
+			Source:    e.Source,
+		}}}, nil
+
+	case *events.CustomEvent:
+		value, err := toValue(e.Value)
+		if err != nil {
+			return nil, fmt.Errorf("value: %w", err)
+		}
+		return &pb.Event{Event: &pb.Event_Custom{Custom: &pb.CustomEvent{
+			BaseEvent: base,
+			Name:      e.Name,
+			Value:     value,
+		}}}, nil
+
+	case *events.RunStartedEvent:
+		return &pb.Event{Event: &pb.Event_RunStarted{RunStarted: &pb.RunStartedEvent{
+			BaseEvent: base,
+			ThreadId:  e.ThreadIDValue,
+			RunId:     e.RunIDValue,
+		}}}, nil
+
+	case *events.RunFinishedEvent:
+		msg, err := toProtoRunFinished(e)
+		if err != nil {
+			return nil, err
+		}
+		msg.BaseEvent = base
+		return &pb.Event{Event: &pb.Event_RunFinished{RunFinished: msg}}, nil
+
+	case *events.RunErrorEvent:
+		return &pb.Event{Event: &pb.Event_RunError{RunError: &pb.RunErrorEvent{
+			BaseEvent: base,
+			Code:      e.Code,
+			Message:   e.Message,
+		}}}, nil
+
+	case *events.StepStartedEvent:
+		return &pb.Event{Event: &pb.Event_StepStarted{StepStarted: &pb.StepStartedEvent{
+			BaseEvent: base,
+			StepName:  e.StepName,
+		}}}, nil
+
+	case *events.StepFinishedEvent:
+		return &pb.Event{Event: &pb.Event_StepFinished{StepFinished: &pb.StepFinishedEvent{
+			BaseEvent: base,
+			StepName:  e.StepName,
+		}}}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrUnsupportedEventType, event.Type())
+}
+
+// fromProtoEvent converts the protobuf oneof wrapper into an event. The event
+// type is taken from the populated oneof field rather than the base event type,
+// since the schema's EventType enum does not cover every oneof member.
+func fromProtoEvent(msg *pb.Event) (events.Event, error) {
+	switch e := msg.GetEvent().(type) {
+	case *pb.Event_TextMessageStart:
+		v := e.TextMessageStart
+		return &events.TextMessageStartEvent{
+			BaseEvent: fromProtoBase(events.EventTypeTextMessageStart, v.GetBaseEvent()),
+			MessageID: v.GetMessageId(),
+			Role:      v.Role,
+			Name:      v.GetName(),
+		}, nil
+
+	case *pb.Event_TextMessageContent:
+		v := e.TextMessageContent
+		return &events.TextMessageContentEvent{
+			BaseEvent: fromProtoBase(events.EventTypeTextMessageContent, v.GetBaseEvent()),
+			MessageID: v.GetMessageId(),
+			Delta:     v.GetDelta(),
+		}, nil
+
+	case *pb.Event_TextMessageEnd:
+		v := e.TextMessageEnd
+		return &events.TextMessageEndEvent{
+			BaseEvent: fromProtoBase(events.EventTypeTextMessageEnd, v.GetBaseEvent()),
+			MessageID: v.GetMessageId(),
+		}, nil
+
+	case *pb.Event_TextMessageChunk:
+		v := e.TextMessageChunk
+		return &events.TextMessageChunkEvent{
+			BaseEvent: fromProtoBase(events.EventTypeTextMessageChunk, v.GetBaseEvent()),
+			MessageID: v.MessageId,
+			Role:      v.Role,
+			Delta:     v.Delta,
+			Name:      v.Name,
+		}, nil
+
+	case *pb.Event_ToolCallStart:
+		v := e.ToolCallStart
+		return &events.ToolCallStartEvent{
+			BaseEvent:       fromProtoBase(events.EventTypeToolCallStart, v.GetBaseEvent()),
+			ToolCallID:      v.GetToolCallId(),
+			ToolCallName:    v.GetToolCallName(),
+			ParentMessageID: v.ParentMessageId,
+		}, nil
+
+	case *pb.Event_ToolCallArgs:
+		v := e.ToolCallArgs
+		return &events.ToolCallArgsEvent{
+			BaseEvent:  fromProtoBase(events.EventTypeToolCallArgs, v.GetBaseEvent()),
+			ToolCallID: v.GetToolCallId(),
+			Delta:      v.GetDelta(),
+		}, nil
+
+	case *pb.Event_ToolCallEnd:
+		v := e.ToolCallEnd
+		return &events.ToolCallEndEvent{
+			BaseEvent:  fromProtoBase(events.EventTypeToolCallEnd, v.GetBaseEvent()),
+			ToolCallID: v.GetToolCallId(),
+		}, nil
+
+	case *pb.Event_ToolCallChunk:
+		v := e.ToolCallChunk
+		return &events.ToolCallChunkEvent{
+			BaseEvent:       fromProtoBase(events.EventTypeToolCallChunk, v.GetBaseEvent()),
+			ToolCallID:      v.ToolCallId,
+			ToolCallName:    v.ToolCallName,
+			ParentMessageID: v.ParentMessageId,
+			Delta:           v.Delta,
+		}, nil
+
+	case *pb.Event_StateSnapshot:
+		v := e.StateSnapshot
+		return &events.StateSnapshotEvent{
+			BaseEvent: fromProtoBase(events.EventTypeStateSnapshot, v.GetBaseEvent()),
+			Snapshot:  fromValue(v.GetSnapshot()),
+		}, nil
+
+	case *pb.Event_StateDelta:
+		v := e.StateDelta
+		delta, err := fromProtoPatch(v.GetDelta())
+		if err != nil {
+			return nil, err
+		}
+		return &events.StateDeltaEvent{
+			BaseEvent: fromProtoBase(events.EventTypeStateDelta, v.GetBaseEvent()),
+			Delta:     delta,
+		}, nil
+
+	case *pb.Event_MessagesSnapshot:
+		v := e.MessagesSnapshot
+		return &events.MessagesSnapshotEvent{
+			BaseEvent: fromProtoBase(events.EventTypeMessagesSnapshot, v.GetBaseEvent()),
+			Messages:  fromProtoMessages(v.GetMessages()),
+		}, nil
+
+	case *pb.Event_Raw:
+		v := e.Raw
+		return &events.RawEvent{
+			BaseEvent: fromProtoBase(events.EventTypeRaw, v.GetBaseEvent()),
+			Event:     fromValue(v.GetEvent()),
+			Source:    v.Source,
+		}, nil
+
+	case *pb.Event_Custom:
+		v := e.Custom
+		return &events.CustomEvent{
+			BaseEvent: fromProtoBase(events.EventTypeCustom, v.GetBaseEvent()),
+			Name:      v.GetName(),
+			Value:     fromValue(v.GetValue()),
+		}, nil
+
+	case *pb.Event_RunStarted:
+		v := e.RunStarted
+		return &events.RunStartedEvent{
+			BaseEvent:     fromProtoBase(events.EventTypeRunStarted, v.GetBaseEvent()),
+			ThreadIDValue: v.GetThreadId(),
+			RunIDValue:    v.GetRunId(),
+		}, nil
+
+	case *pb.Event_RunFinished:
+		return fromProtoRunFinished(e.RunFinished), nil
+
+	case *pb.Event_RunError:
+		v := e.RunError
+		return &events.RunErrorEvent{
+			BaseEvent: fromProtoBase(events.EventTypeRunError, v.GetBaseEvent()),
+			Code:      v.Code,
+			Message:   v.GetMessage(),
+		}, nil
+
+	case *pb.Event_StepStarted:
+		v := e.StepStarted
+		return &events.StepStartedEvent{
+			BaseEvent: fromProtoBase(events.EventTypeStepStarted, v.GetBaseEvent()),
+			StepName:  v.GetStepName(),
+		}, nil
+
+	case *pb.Event_StepFinished:
+		v := e.StepFinished
+		return &events.StepFinishedEvent{
+			BaseEvent: fromProtoBase(events.EventTypeStepFinished, v.GetBaseEvent()),
+			StepName:  v.GetStepName(),
+		}, nil
+	}
+
+	return nil, fmt.Errorf("event message has no event set")
+}
@@ -0,0 +1,244 @@
+package proto
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const fixtureTimestamp = int64(1700000000000)
+
+// wireFixtures are ag_ui.Event messages laid out exactly as the TypeScript
+// SDK's @ag-ui/proto encode() writes them (fields in tag order, proto3 defaults
+// omitted, chunk events with an unset base event type).
+var wireFixtures = []struct {
+	name  string
+	hex   string
+	event func() events.Event
+}{
+	{
+		name: "RunStarted",
+		hex:  "621c0a09080b1080d095ffbc3112087468726561642d311a0572756e2d31",
+		event: func() events.Event {
+			return events.NewRunStartedEvent("thread-1", "run-1")
+		},
+	},
+	{
+		name: "TextMessageContent",
+		hex:  "12190a0908011080d095ffbc3112056d73672d311a0548656c6c6f",
+		event: func() events.Event {
+			return events.NewTextMessageContentEvent("msg-1", "Hello")
+		},
+	},
+	{
+		name: "ToolCallStartWithParent",
+		hex:  "22220a0908031080d095ffbc31120663616c6c2d311a0673656172636822056d73672d31",
+		event: func() events.Event {
+			return events.NewToolCallStartEvent("call-1", "search", events.WithParentMessageID("msg-1"))
+		},
+	},
+	{
+		name: "StateDelta",
+		hex:  "42220a0908071080d095ffbc311215080212062f636f756e742209110000000000000040",
+		event: func() events.Event {
+			return events.NewStateDeltaEvent([]events.JSONPatchOperation{{Op: "replace", Path: "/count", Value: 2}})
+		},
+	},
+	{
+		name: "TextMessageChunk",
+		hex:  "8a01140a071080d095ffbc3112056d73672d3122024869",
+		event: func() events.Event {
+			return events.NewTextMessageChunkEvent(nil, nil, nil).WithChunkMessageID("msg-1").WithChunkDelta("Hi")
+		},
+	},
+	{
+		name: "RunError",
+		hex:  "72230a09080d1080d095ffbc31120754494d454f55541a0d746f6f6b20746f6f206c6f6e67",
+		event: func() events.Event {
+			return events.NewRunErrorEvent("took too long", events.WithErrorCode("TIMEOUT"))
+		},
+	},
+}
+
+func TestWireFixtures(t *testing.T) {
+	for _, fixture := range wireFixtures {
+		t.Run(fixture.name, func(t *testing.T) {
+			expected, err := hex.DecodeString(fixture.hex)
+			require.NoError(t, err)
+
+			event := fixture.event()
+			event.SetTimestamp(fixtureTimestamp)
+
+			data, err := Marshal(event)
+			require.NoError(t, err)
+			assert.Equal(t, fixture.hex, hex.EncodeToString(data))
+
+			decoded, err := Unmarshal(expected)
+			require.NoError(t, err)
+			assert.Equal(t, event.Type(), decoded.Type())
+			require.NotNil(t, decoded.Timestamp())
+			assert.Equal(t, fixtureTimestamp, *decoded.Timestamp())
+
+			want, err := event.ToJSON()
+			require.NoError(t, err)
+			got, err := decoded.ToJSON()
+			require.NoError(t, err)
+			assert.JSONEq(t, string(want), string(got))
+		})
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	cases := map[string]events.Event{
+		"MessagesSnapshot": events.NewMessagesSnapshotEvent([]events.Message{
+			{ID: "msg-1", Role: types.RoleSystem, Content: "be helpful"},
+			{
+				ID:   "msg-2",
+				Role: types.RoleUser,
+				Content: []types.InputContent{
+					{Type: types.InputContentTypeText, Text: "what is this?"},
+					{
+						Type:   types.InputContentTypeImage,
+						Source: &types.InputContentSource{Type: types.InputContentSourceTypeURL, Value: "https://example.com/a.png", MimeType: "image/png"},
+					},
+				},
+			},
+			{
+				ID:   "msg-3",
+				Role: types.RoleAssistant,
+				ToolCalls: []events.ToolCall{{
+					ID:       "call-1",
+					Type:     "function",
+					Function: events.Function{Name: "lookup", Arguments: `{"q":"a"}`},
+				}},
+			},
+			{ID: "msg-4", Role: types.RoleTool, Content: "result", ToolCallID: "call-1"},
+		}),
+		"RunFinishedWithInterrupt": events.NewRunFinishedEventWithOptions("thread-1", "run-1",
+			events.WithResult(map[string]any{"ok": true}),
+			events.WithOutcome(events.RunFinishedOutcome{
+				Type: events.RunFinishedOutcomeTypeInterrupt,
+				Interrupts: []types.Interrupt{{
+					ID:             "int-1",
+					Reason:         "tool_call",
+					ToolCallID:     "call-1",
+					ResponseSchema: map[string]any{"type": "object"},
+				}},
+			}),
+		),
+		"Custom": events.NewCustomEvent("progress", events.WithValue(map[string]any{"percent": 50.0})),
+		"Raw":    events.NewRawEvent(map[string]any{"provider": "x"}, events.WithSource("upstream")),
+		"StateSnapshot": events.NewStateSnapshotEvent(map[string]any{
+			"items": []any{"a", "b"},
+			"count": 2.0,
+		}),
+	}
+
+	for name, event := range cases {
+		t.Run(name, func(t *testing.T) {
+			event.GetBaseEvent().RawEvent = map[string]any{"source": "test"}
+
+			data, err := Marshal(event)
+			require.NoError(t, err)
+
+			decoded, err := Unmarshal(data)
+			require.NoError(t, err)
+			require.NoError(t, decoded.Validate())
+
+			want, err := event.ToJSON()
+			require.NoError(t, err)
+			got, err := decoded.ToJSON()
+			require.NoError(t, err)
+			assert.JSONEq(t, string(want), string(got))
+		})
+	}
+}
+
+func TestUnsupportedEventType(t *testing.T) {
+	_, err := Marshal(events.NewActivitySnapshotEvent("msg-1", "PLAN", map[string]any{}))
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrUnsupportedEventType))
+
+	_, err = Marshal(events.NewMessagesSnapshotEvent([]events.Message{{
+		ID: "msg-1", Role: types.RoleAssistant,
+		Content: []types.AssistantContent{{Type: types.AssistantContentTypeText, Text: "hi"}},
+	}}))
+	assert.ErrorContains(t, err, "assistant content parts cannot be represented")
+
+	_, err = Marshal(nil)
+	assert.Error(t, err)
+
+	_, err = Unmarshal([]byte{0xff, 0xff})
+	assert.Error(t, err)
+
+	_, err = Unmarshal(nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no event set")
+}
+
+func TestStreamFraming(t *testing.T) {
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf)
+	require.NoError(t, enc.Encode(events.NewRunStartedEvent("thread-1", "run-1")))
+	require.NoError(t, enc.Encode(events.NewTextMessageContentEvent("msg-1", "Hello")))
+
+	first := buf.Bytes()
+	length := binary.BigEndian.Uint32(first[:4])
+	_, err := Unmarshal(first[4 : 4+length])
+	require.NoError(t, err)
+
+	dec := NewDecoder(&buf)
+	event, err := dec.Next()
+	require.NoError(t, err)
+	assert.Equal(t, events.EventTypeRunStarted, event.Type())
+
+	event, err = dec.Next()
+	require.NoError(t, err)
+	assert.Equal(t, events.EventTypeTextMessageContent, event.Type())
+
+	_, err = dec.Next()
+	assert.ErrorIs(t, err, io.EOF)
+
+	_, err = NewDecoder(bytes.NewReader([]byte{0, 0, 0, 9, 1})).Next()
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+}
+
+func TestStreamMaxMessageSize(t *testing.T) {
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf)
+	require.NoError(t, enc.Encode(events.NewTextMessageContentEvent("msg-1", strings.Repeat("x", 100))))
+	require.NoError(t, enc.Encode(events.NewRunStartedEvent("thread-1", "run-1")))
+	stream := buf.Bytes()
+
+	// The oversized message is skipped and decoding continues after it
+	dec := NewDecoder(bytes.NewReader(stream), WithMaxMessageSize(64))
+	_, err := dec.Next()
+	assert.ErrorIs(t, err, ErrMessageTooLarge)
+	event, err := dec.Next()
+	require.NoError(t, err)
+	assert.Equal(t, events.EventTypeRunStarted, event.Type())
+
+	dec = NewDecoder(bytes.NewReader(stream), WithMaxMessageSize(0))
+	event, err = dec.Next()
+	require.NoError(t, err)
+	assert.Equal(t, events.EventTypeTextMessageContent, event.Type())
+
+	// Larger messages fail by default
+	oversized := make([]byte, lengthPrefixSize+DefaultMaxMessageSize+1)
+	binary.BigEndian.PutUint32(oversized, DefaultMaxMessageSize+1)
+	_, err = NewDecoder(bytes.NewReader(oversized)).Next()
+	assert.ErrorIs(t, err, ErrMessageTooLarge)
+
+	// A crafted length prefix is rejected without allocating its length
+	_, err = NewDecoder(bytes.NewReader([]byte{0xff, 0xff, 0xff, 0xff, 1})).Next()
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+}
@@ -0,0 +1,113 @@
+package proto
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+)
+
+// lengthPrefixSize is the size of the big-endian length prefix that precedes each message
+const lengthPrefixSize = 4
+
+// DefaultMaxMessageSize is the largest message a Decoder accepts unless
+// configured otherwise
+const DefaultMaxMessageSize = 16 << 20
+
+// ErrMessageTooLarge is returned by Decoder.Next for a message whose length
+// prefix exceeds the maximum message size
+var ErrMessageTooLarge = errors.New("protobuf message exceeds maximum size")
+
+// Encoder writes length-prefixed protobuf events to a stream, using the same
+// framing as the TypeScript SDK: a 4-byte big-endian length followed by the
+// encoded ag_ui.Event message.
+type Encoder struct {
+	w io.Writer
+}
+
+// NewEncoder creates a protobuf stream encoder writing to w
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: w}
+}
+
+// Encode writes a single length-prefixed event
+func (e *Encoder) Encode(event events.Event) error {
+	if e.w == nil {
+		return fmt.Errorf("writer cannot be nil")
+	}
+
+	data, err := Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	frame := make([]byte, lengthPrefixSize+len(data))
+	binary.BigEndian.PutUint32(frame, uint32(len(data)))
+	copy(frame[lengthPrefixSize:], data)
+
+	if _, err := e.w.Write(frame); err != nil {
+		return fmt.Errorf("protobuf write failed: %w", err)
+	}
+	return nil
+}
+
+// Decoder reads length-prefixed protobuf events from a stream
+type Decoder struct {
+	r              io.Reader
+	maxMessageSize int
+}
+
+// DecoderOption defines options for creating decoders
+type DecoderOption func(*Decoder)
+
+// WithMaxMessageSize limits the size of a single message, so that a peer
+// cannot force a large allocation with a crafted length prefix. Zero means
+// no limit. The default is DefaultMaxMessageSize.
+func WithMaxMessageSize(n int) DecoderOption {
+	return func(d *Decoder) {
+		d.maxMessageSize = n
+	}
+}
+
+// NewDecoder creates a protobuf stream decoder reading from r
+func NewDecoder(r io.Reader, options ...DecoderOption) *Decoder {
+	d := &Decoder{r: r, maxMessageSize: DefaultMaxMessageSize}
+	for _, opt := range options {
+		opt(d)
+	}
+	return d
+}
+
+// Next reads the next event. It returns io.EOF when the stream ends cleanly
+// between messages and io.ErrUnexpectedEOF when a message is truncated. A
+// message larger than the maximum size is skipped without being buffered and
+// ErrMessageTooLarge is returned, so callers may keep calling Next.
+func (d *Decoder) Next() (events.Event, error) {
+	var prefix [lengthPrefixSize]byte
+	if _, err := io.ReadFull(d.r, prefix[:]); err != nil {
+		return nil, err
+	}
+
+	length := int64(binary.BigEndian.Uint32(prefix[:]))
+	if d.maxMessageSize > 0 && length > int64(d.maxMessageSize) {
+		if _, err := io.CopyN(io.Discard, d.r, length); err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %d bytes, limit is %d", ErrMessageTooLarge, length, d.maxMessageSize)
+	}
+
+	data := make([]byte, length)
+	if _, err := io.ReadFull(d.r, data); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+
+	return Unmarshal(data)
+}
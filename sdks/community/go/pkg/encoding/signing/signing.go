@@ -0,0 +1,98 @@
+// Package signing authenticates AG-UI events with HMAC-SHA256 so that clients
+// can detect events that were modified in transit, for example by a proxy
+// relaying the stream. Signatures are computed over the canonical JSON form of
+// the event (see events.MarshalCanonical), so they do not depend on key order
+// or whitespace.
+package signing
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+)
+
+var (
+	// ErrInvalidSignature is returned when a signature does not match the event
+	ErrInvalidSignature = errors.New("invalid event signature")
+	// ErrMissingSignature is returned when an event that must be signed is not
+	ErrMissingSignature = errors.New("missing event signature")
+	// ErrEmptyKey is returned when signing or verifying without a key
+	ErrEmptyKey = errors.New("signing key cannot be empty")
+)
+
+// Sign returns the base64url-encoded HMAC-SHA256 of the event's canonical JSON
+func Sign(event events.Event, key []byte) (string, error) {
+	data, err := events.MarshalCanonical(event)
+	if err != nil {
+		return "", fmt.Errorf("failed to canonicalize event: %w", err)
+	}
+	return sign(data, key)
+}
+
+// Verify checks a signature produced by Sign in constant time. It returns
+// ErrInvalidSignature when the event or signature has been altered.
+func Verify(event events.Event, signature string, key []byte) error {
+	data, err := events.MarshalCanonical(event)
+	if err != nil {
+		return fmt.Errorf("failed to canonicalize event: %w", err)
+	}
+	return verify(data, signature, key)
+}
+
+// SignJSON signs an event that is already encoded as JSON. The result equals
+// Sign of the decoded event.
+func SignJSON(data []byte, key []byte) (string, error) {
+	canonical, err := events.CanonicalJSON(data)
+	if err != nil {
+		return "", fmt.Errorf("failed to canonicalize event: %w", err)
+	}
+	return sign(canonical, key)
+}
+
+// VerifyJSON verifies the signature of an event encoded as JSON, exactly as it
+// was received. Verifying the received bytes rather than a re-encoded event
+// ensures that fields the decoder does not know about are covered as well.
+func VerifyJSON(data []byte, signature string, key []byte) error {
+	canonical, err := events.CanonicalJSON(data)
+	if err != nil {
+		return fmt.Errorf("failed to canonicalize event: %w", err)
+	}
+	return verify(canonical, signature, key)
+}
+
+// sign computes the encoded MAC of canonical event JSON
+func sign(canonical []byte, key []byte) (string, error) {
+	if len(key) == 0 {
+		return "", ErrEmptyKey
+	}
+	return base64.RawURLEncoding.EncodeToString(mac(canonical, key)), nil
+}
+
+// verify compares a received signature against the MAC of canonical event JSON
+func verify(canonical []byte, signature string, key []byte) error {
+	if len(key) == 0 {
+		return ErrEmptyKey
+	}
+	if signature == "" {
+		return ErrMissingSignature
+	}
+	received, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	if !hmac.Equal(received, mac(canonical, key)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// mac computes the HMAC-SHA256 of data
+func mac(data []byte, key []byte) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write(data)
+	return h.Sum(nil)
+}
@@ -0,0 +1,40 @@
+package signing
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignVerify(t *testing.T) {
+	key := []byte("shared-secret")
+	event := events.NewStateSnapshotEvent(map[string]any{"b": 1, "a": []any{"x"}})
+
+	signature, err := Sign(event, key)
+	require.NoError(t, err)
+	require.NoError(t, Verify(event, signature, key))
+
+	// Key order and whitespace do not matter for raw JSON
+	data := []byte(`{ "type":"STATE_SNAPSHOT", "snapshot":{"a":["x"],"b":1}, "timestamp":` +
+		strconv.FormatInt(*event.Timestamp(), 10) + `}`)
+	assert.NoError(t, VerifyJSON(data, signature, key))
+
+	jsonSignature, err := SignJSON(data, key)
+	require.NoError(t, err)
+	assert.Equal(t, signature, jsonSignature)
+
+	event.Snapshot = map[string]any{"b": 2, "a": []any{"x"}}
+	assert.ErrorIs(t, Verify(event, signature, key), ErrInvalidSignature)
+	assert.ErrorIs(t, VerifyJSON(data, signature, []byte("other-key")), ErrInvalidSignature)
+	assert.ErrorIs(t, VerifyJSON(data, "not base64!", key), ErrInvalidSignature)
+	assert.ErrorIs(t, VerifyJSON(data, "", key), ErrMissingSignature)
+
+	_, err = Sign(event, nil)
+	assert.ErrorIs(t, err, ErrEmptyKey)
+	assert.ErrorIs(t, Verify(event, signature, nil), ErrEmptyKey)
+	_, err = Sign(nil, key)
+	assert.Error(t, err)
+}
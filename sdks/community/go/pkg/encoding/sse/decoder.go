@@ -0,0 +1,635 @@
+package sse
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/encoding/signing"
+)
+
+// Errors returned by Decoder.Next when a configured limit is exceeded. The
+// offending frame is skipped, so callers may keep calling Next.
+var (
+	ErrEventTooLarge  = errors.New("SSE event exceeds maximum size")
+	ErrContentTooLong = errors.New("message content exceeds maximum length")
+)
+
+// ErrIdleTimeout is returned by Decoder.Next when no event arrives within the
+// duration set by WithIdleTimeout. The stream is left intact, so callers may
+// keep calling Next to wait longer.
+var ErrIdleTimeout = errors.New("no SSE event received before the idle timeout")
+
+// ErrUnsupportedCompression is returned for content encodings the decoder cannot read
+var ErrUnsupportedCompression = errors.New("unsupported content encoding")
+
+// Decoder reads AG-UI events from a Server-Sent Events stream
+type Decoder struct {
+	reader *bufio.Reader
+	err    error
+
+	compression string
+
+	maxEventBytes    int
+	maxContentLength int
+	allowUnknown     bool
+	registry         *events.Registry
+	strictMessages   bool
+	strictFields     bool
+	verificationKey  []byte
+	observer         events.Observer
+	logger           *slog.Logger
+	idleTimeout      time.Duration
+	errorHandler     func(raw []byte, err error) bool
+
+	idle    *idleReader
+	pending *frameState
+
+	lastEventID string
+}
+
+// DecoderOption defines options for creating decoders
+type DecoderOption func(*Decoder)
+
+// WithMaxEventBytes limits the size of the data carried by a single event.
+// Larger events are discarded without being buffered and Next returns
+// ErrEventTooLarge. Zero means no limit.
+func WithMaxEventBytes(n int) DecoderOption {
+	return func(d *Decoder) {
+		d.maxEventBytes = n
+	}
+}
+
+// WithCompression decompresses the stream with the named content encoding.
+// "gzip" (or "x-gzip") is supported, and "" or "identity" leaves the stream as
+// is. Decompression is incremental, so frames are decoded as soon as the
+// compressed bytes that carry them have arrived.
+func WithCompression(encoding string) DecoderOption {
+	return func(d *Decoder) {
+		d.compression = encoding
+	}
+}
+
+// AllowUnknownEvents makes Next return events with unrecognized types as
+// *events.UnknownEvent instead of failing with events.ErrUnknownEventType,
+// so that older clients keep working against servers that add event types.
+func AllowUnknownEvents(allow bool) DecoderOption {
+	return func(d *Decoder) {
+		d.allowUnknown = allow
+	}
+}
+
+// WithEventRegistry makes Next decode events of the types registered with
+// registry using their factories. Unregistered types are still unknown (see
+// AllowUnknownEvents).
+func WithEventRegistry(registry *events.Registry) DecoderOption {
+	return func(d *Decoder) {
+		d.registry = registry
+	}
+}
+
+// WithStrictMessages makes Next validate the messages of MESSAGES_SNAPSHOT
+// events, returning an error that wraps *events.MessageValidationError for
+// messages whose content does not fit their role. The frame is skipped, so
+// callers may keep calling Next.
+func WithStrictMessages(strict bool) DecoderOption {
+	return func(d *Decoder) {
+		d.strictMessages = strict
+	}
+}
+
+// WithDisallowUnknownFields makes Next fail for events, and messages of
+// MESSAGES_SNAPSHOT events, that carry fields their type does not define,
+// which catches typos and protocol drift in conformance tests. The error
+// wraps an *events.UnknownFieldError naming the field. The frame is skipped,
+// so callers may keep calling Next. By default unknown fields are ignored so
+// that newer servers remain readable.
+func WithDisallowUnknownFields() DecoderOption {
+	return func(d *Decoder) {
+		d.strictFields = true
+	}
+}
+
+// WithVerificationKey requires every event to carry a signature field with a
+// valid HMAC-SHA256 signature for key, as written by an encoder configured
+// with WithSigningKey. Next returns an error wrapping
+// signing.ErrMissingSignature or signing.ErrInvalidSignature for frames that
+// fail verification; the frame is skipped, so callers may keep calling Next.
+func WithVerificationKey(key []byte) DecoderOption {
+	return func(d *Decoder) {
+		d.verificationKey = key
+	}
+}
+
+// WithMaxContentLength limits the length in bytes of individual message
+// content fields: text and reasoning deltas, tool results and snapshot
+// message content. Next returns ErrContentTooLong for events exceeding it.
+// Zero means no limit.
+func WithMaxContentLength(n int) DecoderOption {
+	return func(d *Decoder) {
+		d.maxContentLength = n
+	}
+}
+
+// WithDecoderObserver reports every event returned by Next to observer, with
+// the size of its JSON payload. Frames that fail to decode are not reported.
+func WithDecoderObserver(observer events.Observer) DecoderOption {
+	return func(d *Decoder) {
+		d.observer = observer
+	}
+}
+
+// WithDecoderLogger logs every event returned by Next at debug level and every
+// error other than io.EOF as a warning, such as a frame that fails signature
+// verification, message validation or a content limit. The attribute keys are
+// those defined in the events package. By default nothing is logged.
+func WithDecoderLogger(logger *slog.Logger) DecoderOption {
+	return func(d *Decoder) {
+		d.logger = logger
+	}
+}
+
+// WithIdleTimeout makes Next return ErrIdleTimeout when it has waited d for
+// an event. Comments and heartbeats do not count as events, so a stalled
+// agent behind a live connection is detected. The stream is read in a
+// background goroutine, which exits once the reader returns an error; close
+// the underlying reader when abandoning the decoder.
+func WithIdleTimeout(d time.Duration) DecoderOption {
+	return func(dec *Decoder) {
+		dec.idleTimeout = d
+	}
+}
+
+// WithErrorHandler passes every frame that fails to decode or is rejected by
+// a check (signature, message validation, size or content limits) to handler
+// with the frame's raw JSON payload, which is nil for frames discarded for
+// their size. When handler returns true, Next skips the frame and reads the
+// next one; when it returns false, Next returns the error and so does every
+// later call. Errors reading the stream itself are not passed to handler.
+func WithErrorHandler(handler func(raw []byte, err error) bool) DecoderOption {
+	return func(d *Decoder) {
+		d.errorHandler = handler
+	}
+}
+
+// frameState holds a frame whose reading was interrupted by the idle timeout
+// or a cancelled context, so that the next call to Next resumes it
+type frameState struct {
+	frame     sseFrame
+	data      bytes.Buffer
+	hasData   bool
+	hasID     bool
+	oversized bool
+	line      []byte
+}
+
+// sseFrame holds the fields of a single dispatched SSE record
+type sseFrame struct {
+	event     string
+	id        string
+	signature string
+	data      []byte
+}
+
+// NewDecoder creates a new SSE decoder reading from r
+func NewDecoder(r io.Reader, options ...DecoderOption) *Decoder {
+	d := &Decoder{}
+
+	for _, opt := range options {
+		opt(d)
+	}
+	if d.logger == nil {
+		d.logger = events.DiscardLogger
+	}
+
+	switch strings.ToLower(strings.TrimSpace(d.compression)) {
+	case "", "identity":
+	case "gzip", "x-gzip":
+		r = &gzipReader{source: r}
+	default:
+		d.err = fmt.Errorf("%w: %s", ErrUnsupportedCompression, d.compression)
+	}
+	d.idle = &idleReader{source: r, results: make(chan readResult, 1)}
+	d.reader = bufio.NewReader(d.idle)
+
+	return d
+}
+
+// NewResponseDecoder creates a decoder for an HTTP response body, applying
+// the response's Content-Encoding unless WithCompression overrides it
+func NewResponseDecoder(resp *http.Response, options ...DecoderOption) *Decoder {
+	options = append([]DecoderOption{WithCompression(resp.Header.Get("Content-Encoding"))}, options...)
+	return NewDecoder(resp.Body, options...)
+}
+
+// gzipReader creates the gzip reader on first use, since reading the gzip
+// header would otherwise block the constructor until the server sends data
+type gzipReader struct {
+	source io.Reader
+	reader *gzip.Reader
+}
+
+// Read implements io.Reader
+func (g *gzipReader) Read(p []byte) (int, error) {
+	if g.reader == nil {
+		reader, err := gzip.NewReader(g.source)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return 0, io.EOF
+			}
+			return 0, fmt.Errorf("gzip stream: %w", err)
+		}
+		g.reader = reader
+	}
+	return g.reader.Read(p)
+}
+
+// Next reads and decodes the next event from the stream. The id field of the
+// frame, if any, is stored in the event's EventID.
+// It returns io.EOF once the stream ends. A decoding error only affects the
+// current frame, so callers may keep calling Next to continue past it.
+func (d *Decoder) Next() (events.Event, error) {
+	return d.NextContext(context.Background())
+}
+
+// NextContext is like Next, but returns the context's error as soon as ctx is
+// done, even while waiting for the stream. The interrupted frame is resumed
+// by the next call, so the stream is left intact; close the underlying reader
+// when abandoning the decoder. ctx is also passed to the logger, so that
+// handlers can correlate records with the caller's trace.
+func (d *Decoder) NextContext(ctx context.Context) (events.Event, error) {
+	event, err := d.next(ctx)
+	if err != nil {
+		if !errors.Is(err, io.EOF) {
+			d.logger.LogAttrs(ctx, slog.LevelWarn, "failed to decode SSE event",
+				slog.Any(events.LogKeyError, err))
+		}
+		return nil, err
+	}
+	return event, nil
+}
+
+// next implements NextContext
+func (d *Decoder) next(ctx context.Context) (events.Event, error) {
+	if d.err != nil {
+		return nil, d.err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	d.idle.ctx = ctx
+	defer func() { d.idle.ctx = nil }()
+	if d.idleTimeout > 0 {
+		d.idle.deadline = time.Now().Add(d.idleTimeout)
+	}
+
+	for {
+		frame, err := d.readFrame()
+		var event events.Event
+		switch {
+		case errors.Is(err, ErrEventTooLarge):
+			// The oversized frame was discarded while reading
+			frame = &sseFrame{}
+		case err != nil:
+			return nil, err
+		default:
+			if event, err = d.decodeFrame(ctx, frame); err == nil {
+				return event, nil
+			}
+		}
+
+		if d.errorHandler == nil {
+			return nil, err
+		}
+		if !d.errorHandler(frame.data, err) {
+			d.err = err
+			return nil, err
+		}
+		d.logger.LogAttrs(ctx, slog.LevelWarn, "skipped invalid SSE event",
+			slog.Any(events.LogKeyError, err))
+	}
+}
+
+// decodeFrame decodes and checks the event carried by a frame
+func (d *Decoder) decodeFrame(ctx context.Context, frame *sseFrame) (events.Event, error) {
+	if len(d.verificationKey) > 0 {
+		if err := signing.VerifyJSON(frame.data, frame.signature, d.verificationKey); err != nil {
+			return nil, fmt.Errorf("failed to verify SSE event: %w", err)
+		}
+	}
+
+	decode := events.EventFromJSON
+	if d.strictFields {
+		decode = events.EventFromJSONStrict
+	}
+	if d.registry != nil {
+		decode = d.registry.Decode
+		if d.strictFields {
+			decode = d.registry.DecodeStrict
+		}
+	}
+	event, err := decode(frame.data)
+	if err != nil {
+		if d.allowUnknown && errors.Is(err, events.ErrUnknownEventType) {
+			unknown, err := events.NewUnknownEvent(frame.data)
+			if err != nil {
+				return nil, err
+			}
+			unknown.EventID = frame.id
+			d.observe(ctx, unknown, frame)
+			return unknown, nil
+		}
+		return nil, fmt.Errorf("failed to decode SSE event: %w", err)
+	}
+
+	if frame.id != "" {
+		event.GetBaseEvent().EventID = frame.id
+	}
+
+	if snapshot, ok := event.(*events.MessagesSnapshotEvent); ok && d.strictMessages {
+		if err := events.ValidateMessages(snapshot.Messages); err != nil {
+			return nil, fmt.Errorf("failed to decode SSE event: %w", err)
+		}
+	}
+
+	if d.maxContentLength > 0 {
+		if err := checkContentLength(event, d.maxContentLength); err != nil {
+			return nil, err
+		}
+	}
+
+	d.observe(ctx, event, frame)
+	return event, nil
+}
+
+// observe reports a decoded event to the configured observer and logger
+func (d *Decoder) observe(ctx context.Context, event events.Event, frame *sseFrame) {
+	if d.observer != nil {
+		d.observer.ObserveEvent(event.Type(), len(frame.data))
+	}
+	d.logger.LogAttrs(ctx, slog.LevelDebug, "decoded SSE event", events.LogAttrs(event, len(frame.data))...)
+}
+
+// LastEventID returns the id field of the most recently dispatched frame,
+// which a reconnecting client sends back in the Last-Event-ID header. As in
+// the SSE specification it persists across frames until another id field
+// replaces it, and the id of a frame cut off before its end is ignored.
+func (d *Decoder) LastEventID() string {
+	return d.lastEventID
+}
+
+// readFrame reads lines until a complete frame carrying data has been dispatched.
+// Comment lines (starting with ':') are ignored, and multiple data fields are
+// joined with newlines as required by the SSE specification.
+// When the data exceeds the configured maximum the rest of the frame is
+// skipped without buffering and ErrEventTooLarge is returned.
+func (d *Decoder) readFrame() (*sseFrame, error) {
+	state := d.pending
+	if state == nil {
+		state = &frameState{}
+	}
+	d.pending = nil
+
+	for {
+		line, err := d.readLine(state.line)
+		state.line = nil
+		if err != nil {
+			if errors.Is(err, ErrIdleTimeout) {
+				state.line = line
+				d.pending = state
+				return nil, fmt.Errorf("%w (%s)", ErrIdleTimeout, d.idleTimeout)
+			}
+			if d.idle.ctx != nil && errors.Is(err, d.idle.ctx.Err()) {
+				state.line = line
+				d.pending = state
+				return nil, err
+			}
+			if errors.Is(err, io.EOF) {
+				// Pending data without a terminating blank line is discarded
+				return nil, io.EOF
+			}
+			return nil, fmt.Errorf("SSE read failed: %w", err)
+		}
+
+		line = bytes.TrimSuffix(line, []byte("\n"))
+		line = bytes.TrimSuffix(line, []byte("\r"))
+
+		if len(line) == 0 {
+			// The last event ID only advances once its frame is dispatched,
+			// so a truncated frame is requested again after reconnecting
+			if state.hasID {
+				d.lastEventID = state.frame.id
+			}
+			if state.oversized {
+				return nil, fmt.Errorf("%w of %d bytes", ErrEventTooLarge, d.maxEventBytes)
+			}
+			if state.hasData {
+				state.frame.data = state.data.Bytes()
+				return &state.frame, nil
+			}
+			state.frame = sseFrame{}
+			state.hasID = false
+			continue
+		}
+
+		if line[0] == ':' || state.oversized {
+			// Comment or heartbeat line, or the remainder of an oversized frame
+			continue
+		}
+
+		field, value := parseField(line)
+		switch field {
+		case "event":
+			state.frame.event = string(value)
+		case "data":
+			if state.hasData {
+				state.data.WriteByte('\n')
+			}
+			state.data.Write(value)
+			state.hasData = true
+			if d.maxEventBytes > 0 && state.data.Len() > d.maxEventBytes {
+				state.oversized = true
+				state.data.Reset()
+			}
+		case "id":
+			if !bytes.ContainsRune(value, 0) {
+				state.frame.id = string(value)
+				state.hasID = true
+			}
+		case signatureField:
+			state.frame.signature = string(value)
+		default:
+			// Unknown fields (including retry) are ignored
+		}
+	}
+}
+
+// readLine reads a single line including its terminator, continuing the
+// partial line in prefix. With an event size limit configured, bytes beyond
+// the limit are discarded as they are read so that a single huge line cannot
+// exhaust memory; the truncated line is still long enough to push the frame
+// over the limit.
+func (d *Decoder) readLine(prefix []byte) ([]byte, error) {
+	if d.maxEventBytes <= 0 {
+		line, err := d.reader.ReadBytes('\n')
+		if len(prefix) > 0 {
+			line = append(prefix, line...)
+		}
+		return line, err
+	}
+
+	// Allow room for the field name in front of the data
+	limit := d.maxEventBytes + len("data: ") + 1
+	line := prefix
+	for {
+		chunk, err := d.reader.ReadSlice('\n')
+		if room := limit - len(line); room > 0 {
+			if len(chunk) > room {
+				line = append(line, chunk[:room]...)
+			} else {
+				line = append(line, chunk...)
+			}
+		}
+		if errors.Is(err, bufio.ErrBufferFull) {
+			continue
+		}
+		if err == nil && len(line) > 0 && line[len(line)-1] != '\n' {
+			// Keep the terminator on truncated lines
+			line = append(line, '\n')
+		}
+		return line, err
+	}
+}
+
+// checkContentLength reports ErrContentTooLong when a message content field
+// of the event is longer than max bytes
+func checkContentLength(event events.Event, max int) error {
+	check := func(field string, content string) error {
+		if len(content) > max {
+			return fmt.Errorf("%w: %s %s is %d bytes, limit is %d", ErrContentTooLong, event.Type(), field, len(content), max)
+		}
+		return nil
+	}
+
+	switch e := event.(type) {
+	case *events.TextMessageContentEvent:
+		return check("delta", e.Delta)
+	case *events.TextMessageChunkEvent:
+		if e.Delta != nil {
+			return check("delta", *e.Delta)
+		}
+	case *events.TextReasoningContentEvent:
+		return check("delta", e.Delta)
+	case *events.ReasoningMessageContentEvent:
+		return check("delta", e.Delta)
+	case *events.ReasoningMessageChunkEvent:
+		if e.Delta != nil {
+			return check("delta", *e.Delta)
+		}
+	case *events.ToolCallResultEvent:
+		return check("content", e.Content)
+	case *events.MessagesSnapshotEvent:
+		for i, msg := range e.Messages {
+			if text, ok := msg.ContentString(); ok {
+				if err := check(fmt.Sprintf("messages[%d].content", i), text); err != nil {
+					return err
+				}
+			}
+			if parts, ok := msg.ContentInputContents(); ok {
+				for j, part := range parts {
+					if err := check(fmt.Sprintf("messages[%d].content[%d].text", i, j), part.Text); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// parseField splits an SSE line into its field name and value,
+// removing a single leading space from the value.
+func parseField(line []byte) (string, []byte) {
+	idx := bytes.IndexByte(line, ':')
+	if idx < 0 {
+		return string(line), nil
+	}
+	value := line[idx+1:]
+	value = bytes.TrimPrefix(value, []byte(" "))
+	return string(line[:idx]), value
+}
+
+// readResult is the outcome of one read from the source of an idleReader
+type readResult struct {
+	data []byte
+	err  error
+}
+
+// idleReader fails reads that are still waiting for the source at the
+// deadline with ErrIdleTimeout, or when ctx is done with the context's error.
+// An interrupted read keeps running in the background and its data is
+// returned by the next call. Without a deadline or cancellable context it
+// reads from the source directly.
+type idleReader struct {
+	source   io.Reader
+	deadline time.Time
+	ctx      context.Context
+	results  chan readResult
+	inFlight bool
+	buffered []byte
+	err      error
+}
+
+// Read implements io.Reader
+func (r *idleReader) Read(p []byte) (int, error) {
+	if len(r.buffered) == 0 && r.err == nil {
+		var done <-chan struct{}
+		if r.ctx != nil {
+			done = r.ctx.Done()
+		}
+		if !r.inFlight && r.deadline.IsZero() && done == nil {
+			return r.source.Read(p)
+		}
+
+		if !r.inFlight {
+			r.inFlight = true
+			go func(size int) {
+				buf := make([]byte, size)
+				n, err := r.source.Read(buf)
+				r.results <- readResult{data: buf[:n], err: err}
+			}(len(p))
+		}
+
+		var timeout <-chan time.Time
+		if !r.deadline.IsZero() {
+			timer := time.NewTimer(time.Until(r.deadline))
+			defer timer.Stop()
+			timeout = timer.C
+		}
+		select {
+		case result := <-r.results:
+			r.inFlight = false
+			r.buffered, r.err = result.data, result.err
+		case <-timeout:
+			return 0, ErrIdleTimeout
+		case <-done:
+			return 0, r.ctx.Err()
+		}
+	}
+
+	n := copy(p, r.buffered)
+	r.buffered = r.buffered[n:]
+	if len(r.buffered) == 0 && r.err != nil {
+		return n, r.err
+	}
+	return n, nil
+}
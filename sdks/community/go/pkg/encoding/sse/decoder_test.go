@@ -0,0 +1,651 @@
+package sse
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/encoding"
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/encoding/signing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncoderFraming(t *testing.T) {
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf)
+
+	require.NoError(t, enc.Encode(events.NewTextMessageContentEvent("msg-1", "hello")))
+
+	out := buf.String()
+	assert.True(t, strings.HasPrefix(out, "event: TEXT_MESSAGE_CONTENT\ndata: {"))
+	assert.True(t, strings.HasSuffix(out, "}\n\n"))
+
+	assert.Error(t, enc.Encode(nil))
+}
+
+func TestEncoderSplitsMultilineData(t *testing.T) {
+	var frame bytes.Buffer
+	writeDataLines(&frame, []byte("{\n  \"a\": 1\r\n}"))
+	assert.Equal(t, "data: {\ndata:   \"a\": 1\ndata: }\n", frame.String())
+}
+
+func TestDecoderRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf)
+
+	input := []events.Event{
+		events.NewRunStartedEvent("thread-1", "run-1"),
+		events.NewTextMessageStartEvent("msg-1", events.WithRole("assistant")),
+		events.NewTextMessageContentEvent("msg-1", "line one\nline two"),
+		events.NewTextMessageEndEvent("msg-1"),
+		events.NewRunFinishedEvent("thread-1", "run-1"),
+	}
+	for _, event := range input {
+		require.NoError(t, enc.Encode(event))
+	}
+
+	// Feed the stream one byte at a time to exercise frames split across reads
+	dec := NewDecoder(iotest.OneByteReader(&buf))
+	decoded := make([]events.Event, 0, len(input))
+	for _, expected := range input {
+		event, err := dec.Next()
+		require.NoError(t, err)
+		assert.Equal(t, expected.Type(), event.Type())
+		decoded = append(decoded, event)
+	}
+
+	content, ok := decoded[2].(*events.TextMessageContentEvent)
+	require.True(t, ok)
+	assert.Equal(t, "line one\nline two", content.Delta)
+
+	_, err := dec.Next()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestDecoderMultilineDataAndComments(t *testing.T) {
+	stream := ": heartbeat\r\n" +
+		"event: TEXT_MESSAGE_CONTENT\r\n" +
+		"data: {\"type\":\"TEXT_MESSAGE_CONTENT\",\r\n" +
+		"data: \"messageId\":\"msg-1\",\"delta\":\"hi\"}\r\n" +
+		"\r\n" +
+		":ping\n\n"
+
+	dec := NewDecoder(strings.NewReader(stream))
+	event, err := dec.Next()
+	require.NoError(t, err)
+
+	content, ok := event.(*events.TextMessageContentEvent)
+	require.True(t, ok)
+	assert.Equal(t, "msg-1", content.MessageID)
+	assert.Equal(t, "hi", content.Delta)
+
+	_, err = dec.Next()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestDecoderDiscardsIncompleteFrameAtEOF(t *testing.T) {
+	dec := NewDecoder(strings.NewReader("data: {\"type\":\"RUN_STARTED\",\"threadId\":\"t\",\"runId\":\"r\"}\n"))
+	_, err := dec.Next()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestDecoderContinuesAfterBadFrame(t *testing.T) {
+	stream := "data: {not json}\n\n" +
+		"data: {\"type\":\"RUN_STARTED\",\"threadId\":\"t\",\"runId\":\"r\"}\n\n"
+
+	dec := NewDecoder(strings.NewReader(stream))
+	_, err := dec.Next()
+	require.Error(t, err)
+	assert.False(t, errors.Is(err, io.EOF))
+
+	event, err := dec.Next()
+	require.NoError(t, err)
+	assert.Equal(t, events.EventTypeRunStarted, event.Type())
+}
+
+func TestDecoderReadError(t *testing.T) {
+	dec := NewDecoder(iotest.ErrReader(errors.New("boom")))
+	_, err := dec.Next()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "boom")
+}
+
+func TestDecoderMaxEventBytes(t *testing.T) {
+	huge := strings.Repeat("x", 64*1024)
+	stream := "data: {\"type\":\"TEXT_MESSAGE_CONTENT\",\"messageId\":\"m\",\"delta\":\"" + huge + "\"}\n" +
+		"data: more\n\n" +
+		"data: {\"type\":\"RUN_STARTED\",\"threadId\":\"t\",\"runId\":\"r\"}\n\n"
+
+	dec := NewDecoder(iotest.HalfReader(strings.NewReader(stream)), WithMaxEventBytes(1024))
+	_, err := dec.Next()
+	assert.ErrorIs(t, err, ErrEventTooLarge)
+
+	// The oversized frame is skipped and decoding continues
+	event, err := dec.Next()
+	require.NoError(t, err)
+	assert.Equal(t, events.EventTypeRunStarted, event.Type())
+
+	_, err = dec.Next()
+	assert.ErrorIs(t, err, io.EOF)
+
+	// Multiple data lines count towards the same limit
+	stream = "data: 1234\ndata: 5678\n\n"
+	_, err = NewDecoder(strings.NewReader(stream), WithMaxEventBytes(8)).Next()
+	assert.ErrorIs(t, err, ErrEventTooLarge)
+}
+
+func TestDecoderMaxContentLength(t *testing.T) {
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf)
+	require.NoError(t, enc.Encode(events.NewTextMessageContentEvent("msg-1", "short")))
+	require.NoError(t, enc.Encode(events.NewTextMessageContentEvent("msg-1", "much too long")))
+	require.NoError(t, enc.Encode(events.NewToolCallResultEvent("msg-2", "call-1", "a long tool result")))
+	require.NoError(t, enc.Encode(events.NewMessagesSnapshotEvent([]events.Message{
+		{ID: "msg-3", Role: "user", Content: "a long user message"},
+	})))
+
+	dec := NewDecoder(&buf, WithMaxContentLength(8))
+	event, err := dec.Next()
+	require.NoError(t, err)
+	assert.Equal(t, "short", event.(*events.TextMessageContentEvent).Delta)
+
+	for i := 0; i < 3; i++ {
+		_, err = dec.Next()
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrContentTooLong)
+	}
+	assert.Contains(t, err.Error(), "messages[0].content")
+
+	_, err = dec.Next()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestDecoderStrictMessages(t *testing.T) {
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf)
+	require.NoError(t, enc.Encode(events.NewMessagesSnapshotEvent([]events.Message{
+		{ID: "msg-1", Role: "system", Content: []any{"not", "a", "string"}},
+	})))
+	require.NoError(t, enc.Encode(events.NewMessagesSnapshotEvent([]events.Message{
+		{ID: "msg-2", Role: "user", Content: "hi"},
+	})))
+
+	dec := NewDecoder(&buf, WithStrictMessages(true))
+	_, err := dec.Next()
+	var validationErr *events.MessageValidationError
+	require.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, "content", validationErr.Field)
+
+	event, err := dec.Next()
+	require.NoError(t, err)
+	assert.Len(t, event.(*events.MessagesSnapshotEvent).Messages, 1)
+}
+
+func TestDecoderDisallowUnknownFields(t *testing.T) {
+	stream := "data: {\"type\":\"RUN_STARTED\",\"threadId\":\"t\",\"runId\":\"r\",\"runIdd\":\"typo\"}\n\n" +
+		"data: {\"type\":\"MESSAGES_SNAPSHOT\",\"messages\":[{\"id\":\"m\",\"role\":\"user\",\"content\":\"hi\",\"avatar\":\"a\"}]}\n\n" +
+		"data: {\"type\":\"STEP_STARTED\",\"stepName\":\"plan\"}\n\n"
+
+	// Lenient by default
+	dec := NewDecoder(strings.NewReader(stream))
+	for i := 0; i < 3; i++ {
+		_, err := dec.Next()
+		require.NoError(t, err)
+	}
+
+	dec = NewDecoder(strings.NewReader(stream), WithDisallowUnknownFields())
+	var fieldErr *events.UnknownFieldError
+	_, err := dec.Next()
+	require.True(t, errors.As(err, &fieldErr))
+	assert.Equal(t, "runIdd", fieldErr.Field)
+
+	_, err = dec.Next()
+	require.True(t, errors.As(err, &fieldErr))
+	assert.Equal(t, "messages[0].avatar", fieldErr.Field)
+
+	event, err := dec.Next()
+	require.NoError(t, err)
+	assert.Equal(t, events.EventTypeStepStarted, event.Type())
+}
+
+func TestDecoderAllowUnknownEvents(t *testing.T) {
+	stream := "event: FUTURE_EVENT\ndata: {\"type\":\"FUTURE_EVENT\",\"value\":1}\n\n" +
+		"data: {\"type\":\"RUN_STARTED\",\"threadId\":\"t\",\"runId\":\"r\"}\n\n"
+
+	_, err := NewDecoder(strings.NewReader(stream)).Next()
+	assert.ErrorIs(t, err, events.ErrUnknownEventType)
+
+	dec := NewDecoder(strings.NewReader(stream), AllowUnknownEvents(true))
+	event, err := dec.Next()
+	require.NoError(t, err)
+	unknown, ok := event.(*events.UnknownEvent)
+	require.True(t, ok)
+	assert.Equal(t, events.EventType("FUTURE_EVENT"), unknown.Type())
+	assert.JSONEq(t, `{"type":"FUTURE_EVENT","value":1}`, string(unknown.Data))
+
+	// Unknown events are re-encoded verbatim
+	var buf bytes.Buffer
+	require.NoError(t, NewEncoder(&buf).Encode(unknown))
+	assert.Equal(t, "event: FUTURE_EVENT\ndata: {\"type\":\"FUTURE_EVENT\",\"value\":1}\n\n", buf.String())
+
+	event, err = dec.Next()
+	require.NoError(t, err)
+	assert.Equal(t, events.EventTypeRunStarted, event.Type())
+}
+
+func TestDecoderWithEventRegistry(t *testing.T) {
+	registry := events.NewRegistry()
+	require.NoError(t, registry.Register("FUTURE_EVENT", func(data json.RawMessage) (events.Event, error) {
+		event := &events.CustomEvent{BaseEvent: events.NewBaseEvent("FUTURE_EVENT")}
+		return event, json.Unmarshal(data, &event.Value)
+	}))
+	defer registry.Unregister("FUTURE_EVENT")
+
+	stream := "data: {\"type\":\"FUTURE_EVENT\",\"value\":1}\n\n" +
+		"data: {\"type\":\"OTHER_EVENT\"}\n\n"
+	dec := NewDecoder(strings.NewReader(stream), WithEventRegistry(registry), WithDisallowUnknownFields())
+	event, err := dec.Next()
+	require.NoError(t, err)
+	require.IsType(t, &events.CustomEvent{}, event)
+	assert.Equal(t, events.EventType("FUTURE_EVENT"), event.Type())
+	assert.Equal(t, map[string]any{"type": "FUTURE_EVENT", "value": float64(1)}, event.(*events.CustomEvent).Value)
+
+	_, err = dec.Next()
+	assert.ErrorIs(t, err, events.ErrUnknownEventType)
+}
+
+func TestDecoderGzipIsIncremental(t *testing.T) {
+	pr, pw := io.Pipe()
+	zw := gzip.NewWriter(pw)
+	enc := NewEncoder(zw)
+
+	dec := NewDecoder(pr, WithCompression("gzip"))
+	// The encoder flushes the gzip writer into the pipe itself, so the reader
+	// must not block on delivering events while Encode is running
+	received := make(chan events.Event, 2)
+	go func() {
+		defer close(received)
+		for {
+			event, err := dec.Next()
+			if err != nil {
+				return
+			}
+			received <- event
+		}
+	}()
+
+	// Each flushed frame is decoded before the compressed stream ends
+	for _, event := range []events.Event{
+		events.NewRunStartedEvent("thread-1", "run-1"),
+		events.NewTextMessageContentEvent("msg-1", "hello"),
+	} {
+		require.NoError(t, enc.Encode(event))
+		select {
+		case got := <-received:
+			assert.Equal(t, event.Type(), got.Type())
+		case <-time.After(time.Second):
+			t.Fatal("frame was not decoded after flush")
+		}
+	}
+
+	require.NoError(t, zw.Close())
+	require.NoError(t, pw.Close())
+	_, open := <-received
+	assert.False(t, open)
+}
+
+func TestResponseDecoderDetectsContentEncoding(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Content-Encoding", "gzip")
+		zw := gzip.NewWriter(w)
+		require.NoError(t, NewEncoder(zw).Encode(events.NewRunStartedEvent("thread-1", "run-1")))
+		require.NoError(t, zw.Close())
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	// Ask explicitly so the transport leaves the body compressed
+	req.Header.Set("Accept-Encoding", "gzip")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	dec := NewResponseDecoder(resp)
+	event, err := dec.Next()
+	require.NoError(t, err)
+	assert.Equal(t, events.EventTypeRunStarted, event.Type())
+	_, err = dec.Next()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestDecoderCompressionErrors(t *testing.T) {
+	_, err := NewDecoder(strings.NewReader(""), WithCompression("br")).Next()
+	assert.ErrorIs(t, err, ErrUnsupportedCompression)
+
+	_, err = NewDecoder(strings.NewReader("data: plain\n\n"), WithCompression("gzip")).Next()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "gzip")
+
+	_, err = NewDecoder(strings.NewReader(""), WithCompression("gzip")).Next()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestDecoderLastEventID(t *testing.T) {
+	stream := "id: 1\ndata: {\"type\":\"RUN_STARTED\",\"threadId\":\"t\",\"runId\":\"r\"}\n\n" +
+		"data: {\"type\":\"STEP_STARTED\",\"stepName\":\"s\"}\n\n" +
+		"id: 3\ndata: {\"type\":\"STEP_FINISHED\",\"stepName\":\"s\"}\n\n"
+
+	dec := NewDecoder(strings.NewReader(stream))
+	assert.Equal(t, "", dec.LastEventID())
+
+	expected := []string{"1", "1", "3"}
+	for _, id := range expected {
+		_, err := dec.Next()
+		require.NoError(t, err)
+		assert.Equal(t, id, dec.LastEventID())
+	}
+}
+
+func TestDecoderLastEventIDIgnoresTruncatedFrame(t *testing.T) {
+	// The connection drops after the id line of the second frame
+	stream := "event: RUN_STARTED\nid: 1\ndata: {\"type\":\"RUN_STARTED\",\"threadId\":\"t\",\"runId\":\"r\"}\n\n" +
+		"event: RUN_FINISHED\nid: 2\n"
+
+	dec := NewDecoder(strings.NewReader(stream))
+	event, err := dec.Next()
+	require.NoError(t, err)
+	assert.Equal(t, events.EventTypeRunStarted, event.Type())
+	assert.Equal(t, "1", dec.LastEventID())
+
+	_, err = dec.Next()
+	assert.ErrorIs(t, err, io.EOF)
+	assert.Equal(t, "1", dec.LastEventID())
+}
+
+func TestEventIDRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf)
+	gen := events.NewEventIDGenerator("run-1:")
+
+	require.NoError(t, enc.Encode(gen.Assign(events.NewRunStartedEvent("thread-1", "run-1"))))
+	require.NoError(t, enc.Encode(events.NewStepStartedEvent("plan")))
+	require.NoError(t, enc.Encode(gen.Assign(events.NewStepFinishedEvent("plan"))))
+	assert.Contains(t, buf.String(), "event: RUN_STARTED\nid: run-1:1\ndata: ")
+
+	dec := NewDecoder(&buf)
+	for _, id := range []string{"run-1:1", "", "run-1:2"} {
+		event, err := dec.Next()
+		require.NoError(t, err)
+		assert.Equal(t, id, event.GetBaseEvent().EventID)
+	}
+
+	// The id is transport metadata and stays out of the payload
+	event := events.NewStepStartedEvent("plan")
+	event.EventID = "frame\n7"
+	data, err := event.ToJSON()
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "frame")
+
+	buf.Reset()
+	require.NoError(t, enc.Encode(event))
+	assert.Contains(t, buf.String(), "id: frame7\n")
+}
+
+func TestSignedFrames(t *testing.T) {
+	key := []byte("relay-secret")
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf, WithSigningKey(key))
+	require.NoError(t, enc.Encode(events.NewTextMessageContentEvent("msg-1", "hello")))
+	require.NoError(t, enc.Encode(events.NewTextMessageContentEvent("msg-1", "world")))
+	assert.Contains(t, buf.String(), "\nsignature: ")
+
+	// A proxy rewrites the second frame
+	stream := strings.Replace(buf.String(), `"world"`, `"w0rld"`, 1)
+	stream += "data: {\"type\":\"TEXT_MESSAGE_END\",\"messageId\":\"msg-1\"}\n\n"
+
+	dec := NewDecoder(strings.NewReader(stream), WithVerificationKey(key))
+	event, err := dec.Next()
+	require.NoError(t, err)
+	assert.Equal(t, "hello", event.(*events.TextMessageContentEvent).Delta)
+
+	_, err = dec.Next()
+	assert.ErrorIs(t, err, signing.ErrInvalidSignature)
+	_, err = dec.Next()
+	assert.ErrorIs(t, err, signing.ErrMissingSignature)
+
+	// Decoders without a key ignore the signature field
+	dec = NewDecoder(strings.NewReader(stream))
+	for i := 0; i < 3; i++ {
+		_, err := dec.Next()
+		require.NoError(t, err)
+	}
+}
+
+func TestObservers(t *testing.T) {
+	type observation struct {
+		eventType events.EventType
+		bytes     int
+	}
+	recorder := func(into *[]observation) events.Observer {
+		return events.ObserverFunc(func(eventType events.EventType, bytes int) {
+			*into = append(*into, observation{eventType, bytes})
+		})
+	}
+
+	input := []events.Event{
+		events.NewRunStartedEvent("thread-1", "run-1"),
+		events.NewTextMessageContentEvent("msg-1", "hello"),
+	}
+
+	var encoded []observation
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf, WithEncoderObserver(recorder(&encoded)))
+	for _, event := range input {
+		require.NoError(t, enc.Encode(event))
+	}
+	// Malformed frames are not reported by the decoder
+	buf.WriteString("data: {not json}\n\n")
+
+	var decoded []observation
+	dec := NewDecoder(&buf, WithDecoderObserver(recorder(&decoded)))
+	for {
+		_, err := dec.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+	}
+
+	require.Len(t, encoded, 2)
+	for i, event := range input {
+		data, err := event.ToJSON()
+		require.NoError(t, err)
+		assert.Equal(t, observation{event.Type(), len(data)}, encoded[i])
+	}
+	assert.Equal(t, encoded, decoded)
+}
+
+func TestLoggers(t *testing.T) {
+	records := func(buf *bytes.Buffer) []map[string]any {
+		var out []map[string]any
+		for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+			var record map[string]any
+			require.NoError(t, json.Unmarshal([]byte(line), &record))
+			out = append(out, record)
+		}
+		return out
+	}
+	newLogger := func(buf *bytes.Buffer) *slog.Logger {
+		return slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	}
+
+	event := events.NewTextMessageContentEvent("msg-1", "hello")
+	event.EventID = "evt-1"
+	data, err := event.ToJSON()
+	require.NoError(t, err)
+
+	var stream, encLog bytes.Buffer
+	enc := NewEncoder(&stream, WithEncoderLogger(newLogger(&encLog)))
+	require.NoError(t, enc.Encode(event))
+	require.Error(t, enc.Encode(nil))
+	stream.WriteString("data: {not json}\n\n")
+
+	var decLog bytes.Buffer
+	dec := NewDecoder(&stream, WithDecoderLogger(newLogger(&decLog)))
+	for {
+		_, err := dec.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+	}
+
+	for name, logged := range map[string][]map[string]any{"encoder": records(&encLog), "decoder": records(&decLog)} {
+		require.Len(t, logged, 2, name)
+		assert.Equal(t, "DEBUG", logged[0]["level"], name)
+		assert.Equal(t, string(events.EventTypeTextMessageContent), logged[0][events.LogKeyEventType], name)
+		assert.Equal(t, "evt-1", logged[0][events.LogKeyEventID], name)
+		assert.Equal(t, float64(len(data)), logged[0][events.LogKeyBytes], name)
+		assert.Equal(t, "WARN", logged[1]["level"], name)
+		assert.NotEmpty(t, logged[1][events.LogKeyError], name)
+	}
+
+	// Without a logger nothing is written and nothing fails
+	require.NoError(t, NewEncoder(io.Discard).Encode(event))
+}
+
+func TestDecoder_IdleTimeout(t *testing.T) {
+	pr, pw := io.Pipe()
+	defer pr.Close()
+	write := func(s string) {
+		go func() { _, _ = pw.Write([]byte(s)) }()
+	}
+
+	dec := NewDecoder(pr, WithIdleTimeout(50*time.Millisecond))
+
+	write("data: {\"type\":\"RUN_STARTED\",\"threadId\":\"t\",\"runId\":\"r\"}\n\n")
+	event, err := dec.Next()
+	require.NoError(t, err)
+	assert.Equal(t, events.EventTypeRunStarted, event.Type())
+
+	// Heartbeats and a partial frame do not count as an event
+	write(":ping\n\nid: evt-2\ndata: {\"type\":\"TEXT_MESSAGE_")
+	start := time.Now()
+	_, err = dec.Next()
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrIdleTimeout))
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+
+	// The interrupted frame resumes where it stopped
+	write("CONTENT\",\"messageId\":\"m\",\"delta\":\"hi\"}\n\n")
+	event, err = dec.Next()
+	require.NoError(t, err)
+	require.Equal(t, events.EventTypeTextMessageContent, event.Type())
+	assert.Equal(t, "hi", event.(*events.TextMessageContentEvent).Delta)
+	assert.Equal(t, "evt-2", event.GetBaseEvent().EventID)
+
+	require.NoError(t, pw.Close())
+	_, err = dec.Next()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestDecoder_NextContext(t *testing.T) {
+	var _ encoding.ContextEventReader = (*Decoder)(nil)
+
+	pr, pw := io.Pipe()
+	defer pr.Close()
+	write := func(s string) {
+		go func() { _, _ = pw.Write([]byte(s)) }()
+	}
+
+	dec := NewDecoder(pr)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// A cancelled read stops waiting, leaving the partial frame for the next call
+	write("id: evt-1\ndata: {\"type\":\"TEXT_MESSAGE_")
+	_, err := dec.NextContext(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	_, err = dec.NextContext(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	write("CONTENT\",\"messageId\":\"m\",\"delta\":\"hi\"}\n\n")
+	event, err := encoding.NextContext(context.Background(), dec)
+	require.NoError(t, err)
+	require.Equal(t, events.EventTypeTextMessageContent, event.Type())
+	assert.Equal(t, "hi", event.(*events.TextMessageContentEvent).Delta)
+	assert.Equal(t, "evt-1", event.GetBaseEvent().EventID)
+
+	require.NoError(t, pw.Close())
+	_, err = dec.Next()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestEncoder_EncodeContext(t *testing.T) {
+	var _ encoding.ContextEventWriter = (*Encoder)(nil)
+
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf)
+	require.NoError(t, encoding.EncodeContext(context.Background(), enc, events.NewStepStartedEvent("plan")))
+	assert.Contains(t, buf.String(), "event: STEP_STARTED\n")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	buf.Reset()
+	assert.ErrorIs(t, enc.EncodeContext(ctx, events.NewStepStartedEvent("plan")), context.Canceled)
+	assert.Empty(t, buf.String())
+}
+
+func TestDecoder_ErrorHandler(t *testing.T) {
+	stream := "data: {\"type\":\"RUN_STARTED\",\"threadId\":\"t\",\"runId\":\"r\"}\n\n" +
+		"data: {not json}\n\n" +
+		"data: {\"type\":\"TEXT_MESSAGE_CONTENT\",\"messageId\":\"m\",\"delta\":\"" + strings.Repeat("x", 100) + "\"}\n\n" +
+		"data: {\"type\":\"RUN_FINISHED\",\"threadId\":\"t\",\"runId\":\"r\"}\n\n"
+
+	type failure struct {
+		raw string
+		err error
+	}
+	var failures []failure
+	dec := NewDecoder(strings.NewReader(stream), WithMaxEventBytes(80), WithErrorHandler(func(raw []byte, err error) bool {
+		failures = append(failures, failure{string(raw), err})
+		return true
+	}))
+
+	var decoded []events.EventType
+	for {
+		event, err := dec.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		decoded = append(decoded, event.Type())
+	}
+	assert.Equal(t, []events.EventType{events.EventTypeRunStarted, events.EventTypeRunFinished}, decoded)
+	require.Len(t, failures, 2)
+	assert.Equal(t, "{not json}", failures[0].raw)
+	assert.Contains(t, failures[0].err.Error(), "failed to decode SSE event")
+	assert.Empty(t, failures[1].raw)
+	assert.True(t, errors.Is(failures[1].err, ErrEventTooLarge))
+
+	// Returning false aborts the stream
+	dec = NewDecoder(strings.NewReader(stream), WithErrorHandler(func([]byte, error) bool { return false }))
+	_, err := dec.Next()
+	require.NoError(t, err)
+	_, err = dec.Next()
+	require.Error(t, err)
+	_, again := dec.Next()
+	assert.Equal(t, err, again)
+}
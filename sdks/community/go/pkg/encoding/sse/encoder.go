@@ -0,0 +1,168 @@
+package sse
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/encoding/signing"
+)
+
+// signatureField is the SSE field that carries an event's HMAC signature
+const signatureField = "signature"
+
+// Encoder writes AG-UI events to an io.Writer as Server-Sent Events frames
+type Encoder struct {
+	w          io.Writer
+	signingKey []byte
+	observer   events.Observer
+	logger     *slog.Logger
+}
+
+// EncoderOption defines options for creating encoders
+type EncoderOption func(*Encoder)
+
+// WithSigningKey signs every event with HMAC-SHA256 using key and writes the
+// signature as a signature field of the frame. Standard SSE clients ignore the
+// field; decoders configured with WithVerificationKey check it.
+func WithSigningKey(key []byte) EncoderOption {
+	return func(e *Encoder) {
+		e.signingKey = key
+	}
+}
+
+// WithEncoderObserver reports every event written by the encoder to observer,
+// with the size of its JSON payload
+func WithEncoderObserver(observer events.Observer) EncoderOption {
+	return func(e *Encoder) {
+		e.observer = observer
+	}
+}
+
+// WithEncoderLogger logs every written event at debug level and every failed
+// write as a warning, using the attribute keys defined in the events package.
+// By default nothing is logged.
+func WithEncoderLogger(logger *slog.Logger) EncoderOption {
+	return func(e *Encoder) {
+		e.logger = logger
+	}
+}
+
+// NewEncoder creates a new SSE encoder that writes frames to w. When w is an
+// http.Flusher (or has a Flush() error method) it is flushed after every frame.
+func NewEncoder(w io.Writer, options ...EncoderOption) *Encoder {
+	e := &Encoder{w: w}
+	for _, opt := range options {
+		opt(e)
+	}
+	if e.logger == nil {
+		e.logger = events.DiscardLogger
+	}
+	return e
+}
+
+// Encode writes a single event as an SSE frame.
+// Format: event: <type>\n[id: <id>\n]data: <json>\n\n
+// The id field is written when the event carries an EventID, followed by a
+// signature field when the encoder has a signing key.
+func (e *Encoder) Encode(event events.Event) error {
+	return e.EncodeContext(context.Background(), event)
+}
+
+// EncodeContext is like Encode, but fails without writing when ctx is
+// already done. ctx is also passed to the logger, so that handlers can
+// correlate records with the caller's trace.
+func (e *Encoder) EncodeContext(ctx context.Context, event events.Event) error {
+	size, err := e.encode(ctx, event)
+	if err != nil {
+		attrs := append(events.LogAttrs(event, -1), slog.Any(events.LogKeyError, err))
+		e.logger.LogAttrs(ctx, slog.LevelWarn, "failed to encode SSE event", attrs...)
+		return err
+	}
+	e.logger.LogAttrs(ctx, slog.LevelDebug, "encoded SSE event", events.LogAttrs(event, size)...)
+	return nil
+}
+
+// encode implements EncodeContext, returning the size of the event's JSON payload
+func (e *Encoder) encode(ctx context.Context, event events.Event) (int, error) {
+	if event == nil {
+		return 0, fmt.Errorf("event cannot be nil")
+	}
+
+	if e.w == nil {
+		return 0, fmt.Errorf("writer cannot be nil")
+	}
+
+	data, err := event.ToJSON()
+	if err != nil {
+		return 0, fmt.Errorf("event encoding failed: %w", err)
+	}
+
+	var frame bytes.Buffer
+	frame.WriteString("event: ")
+	frame.WriteString(string(event.Type()))
+	frame.WriteByte('\n')
+	if id := event.GetBaseEvent().EventID; id != "" {
+		frame.WriteString("id: ")
+		frame.WriteString(sanitizeID(id))
+		frame.WriteByte('\n')
+	}
+	if len(e.signingKey) > 0 {
+		signature, err := signing.SignJSON(data, e.signingKey)
+		if err != nil {
+			return 0, fmt.Errorf("event signing failed: %w", err)
+		}
+		frame.WriteString(signatureField)
+		frame.WriteString(": ")
+		frame.WriteString(signature)
+		frame.WriteByte('\n')
+	}
+	writeDataLines(&frame, data)
+	frame.WriteByte('\n')
+
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	if _, err := e.w.Write(frame.Bytes()); err != nil {
+		return 0, fmt.Errorf("SSE write failed: %w", err)
+	}
+	if e.observer != nil {
+		e.observer.ObserveEvent(event.Type(), len(data))
+	}
+
+	return len(data), flushIfSupported(e.w)
+}
+
+// flushIfSupported flushes w if it buffers output, so that each frame reaches the
+// client as soon as it is written
+func flushIfSupported(w io.Writer) error {
+	switch f := w.(type) {
+	case flusher:
+		if err := f.Flush(); err != nil {
+			return fmt.Errorf("SSE flush failed: %w", err)
+		}
+	case flusherWithoutError:
+		f.Flush()
+	}
+	return nil
+}
+
+// writeDataLines writes payload as one or more data fields, splitting on line breaks
+// so multi-line payloads stay within the SSE framing rules.
+func writeDataLines(frame *bytes.Buffer, payload []byte) {
+	payload = bytes.ReplaceAll(payload, []byte("\r\n"), []byte("\n"))
+	for _, line := range bytes.Split(payload, []byte("\n")) {
+		frame.WriteString("data: ")
+		frame.Write(bytes.TrimSuffix(line, []byte("\r")))
+		frame.WriteByte('\n')
+	}
+}
+
+// sanitizeID removes characters that would break the id field out of its line
+func sanitizeID(id string) string {
+	return strings.NewReplacer("\r", "", "\n", "", "\x00", "").Replace(id)
+}
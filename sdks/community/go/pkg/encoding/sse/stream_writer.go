@@ -0,0 +1,227 @@
+package sse
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+)
+
+// ErrStreamClosed is returned when writing to a closed StreamWriter
+var ErrStreamClosed = errors.New("SSE stream is closed")
+
+// heartbeatFrame is the SSE comment written to keep idle connections open
+const heartbeatFrame = ":ping\n\n"
+
+// CloseBehavior selects the terminal event StreamWriter.Close writes when a
+// run was started on the stream but has not finished
+type CloseBehavior int
+
+const (
+	// CloseSilently writes no terminal event
+	CloseSilently CloseBehavior = iota
+	// CloseWithRunError writes a RUN_ERROR event with the CANCELLED code
+	CloseWithRunError
+	// CloseWithRunFinished writes a RUN_FINISHED event for the open run
+	CloseWithRunFinished
+)
+
+// StreamWriter streams events to an HTTP client as Server-Sent Events. Each
+// event is flushed as soon as it is written. It is safe for concurrent use.
+type StreamWriter struct {
+	mu        sync.Mutex
+	w         http.ResponseWriter
+	encoder   *Encoder
+	lastWrite time.Time
+	closed    bool
+
+	// openRun is the RUN_STARTED event of a run that has not yet finished
+	openRun *events.RunStartedEvent
+
+	encoderOptions []EncoderOption
+	heartbeat      time.Duration
+	closeBehavior  CloseBehavior
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// StreamWriterOption defines options for creating stream writers
+type StreamWriterOption func(*StreamWriter)
+
+// WithEncoderOptions configures the encoder used for each event, for example
+// to sign events with WithSigningKey
+func WithEncoderOptions(options ...EncoderOption) StreamWriterOption {
+	return func(s *StreamWriter) {
+		s.encoderOptions = append(s.encoderOptions, options...)
+	}
+}
+
+// WithHeartbeat writes an SSE comment (":ping") whenever nothing has been
+// written for interval, so that proxies do not drop the connection while the
+// agent is busy. Heartbeats stop when the writer is closed or a write fails.
+// Zero disables heartbeats.
+func WithHeartbeat(interval time.Duration) StreamWriterOption {
+	return func(s *StreamWriter) {
+		s.heartbeat = interval
+	}
+}
+
+// WithCloseBehavior makes Close end a run that was started but not finished
+// with a terminal event, so that clients see a well-formed end of stream even
+// when the handler stops early, for example because its context was
+// cancelled. The default is CloseSilently.
+func WithCloseBehavior(behavior CloseBehavior) StreamWriterOption {
+	return func(s *StreamWriter) {
+		s.closeBehavior = behavior
+	}
+}
+
+// NewStreamWriter prepares w for an event stream: it sets the
+// Content-Type: text/event-stream, Cache-Control: no-cache and
+// Connection: keep-alive headers, sends the 200 status and flushes so that
+// the client sees the response start immediately. Call Close when the stream
+// ends to stop heartbeats.
+func NewStreamWriter(w http.ResponseWriter, options ...StreamWriterOption) *StreamWriter {
+	s := &StreamWriter{w: w}
+	for _, opt := range options {
+		opt(s)
+	}
+	s.encoder = NewEncoder(w, s.encoderOptions...)
+
+	header := w.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	_ = flushIfSupported(w)
+	s.lastWrite = time.Now()
+
+	if s.heartbeat > 0 {
+		s.stop = make(chan struct{})
+		s.done = make(chan struct{})
+		go s.heartbeatLoop()
+	}
+
+	return s
+}
+
+// WriteEvent writes and flushes a single event
+func (s *StreamWriter) WriteEvent(event events.Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return ErrStreamClosed
+	}
+	if err := s.encoder.Encode(event); err != nil {
+		return fmt.Errorf("failed to write event: %w", err)
+	}
+	s.lastWrite = time.Now()
+
+	switch e := event.(type) {
+	case *events.RunStartedEvent:
+		s.openRun = e
+	case *events.RunFinishedEvent, *events.RunErrorEvent:
+		s.openRun = nil
+	}
+	return nil
+}
+
+// Close stops heartbeats and makes further writes fail with ErrStreamClosed.
+// When configured with WithCloseBehavior and a run is still open, it first
+// writes and flushes the terminal event, returning any error from doing so.
+// The connection itself is closed by the HTTP server once the handler
+// returns. Close waits for a heartbeat in progress and is safe to call more
+// than once.
+func (s *StreamWriter) Close() error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	err := s.writeTerminalEvent()
+	s.closed = true
+	s.mu.Unlock()
+
+	if s.stop != nil {
+		close(s.stop)
+		<-s.done
+	}
+	return err
+}
+
+// writeTerminalEvent ends the open run according to the close behavior. The
+// caller must hold s.mu.
+func (s *StreamWriter) writeTerminalEvent() error {
+	if s.openRun == nil {
+		return nil
+	}
+
+	var terminal events.Event
+	switch s.closeBehavior {
+	case CloseWithRunError:
+		terminal = events.NewRunErrorEvent(events.ErrRunCancelled.Error(),
+			events.WithErrorCode(events.RunErrorCodeCancelled),
+			events.WithRunID(s.openRun.RunID()))
+	case CloseWithRunFinished:
+		terminal = events.NewRunFinishedEvent(s.openRun.ThreadID(), s.openRun.RunID())
+	default:
+		return nil
+	}
+
+	s.openRun = nil
+	if err := s.encoder.Encode(terminal); err != nil {
+		return fmt.Errorf("failed to write terminal event: %w", err)
+	}
+	return nil
+}
+
+// heartbeatLoop writes a heartbeat each time the stream has been idle for
+// the heartbeat interval
+func (s *StreamWriter) heartbeatLoop() {
+	defer close(s.done)
+
+	timer := time.NewTimer(s.heartbeat)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+		case <-s.stop:
+			return
+		}
+
+		wait, err := s.pingIfIdle()
+		if err != nil {
+			return
+		}
+		timer.Reset(wait)
+	}
+}
+
+// pingIfIdle writes a heartbeat when nothing was written for the heartbeat
+// interval and returns how long to wait before checking again
+func (s *StreamWriter) pingIfIdle() (time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return 0, ErrStreamClosed
+	}
+	if idle := time.Since(s.lastWrite); idle < s.heartbeat {
+		return s.heartbeat - idle, nil
+	}
+
+	if _, err := s.w.Write([]byte(heartbeatFrame)); err != nil {
+		return 0, err
+	}
+	if err := flushIfSupported(s.w); err != nil {
+		return 0, err
+	}
+	s.lastWrite = time.Now()
+	return s.heartbeat, nil
+}
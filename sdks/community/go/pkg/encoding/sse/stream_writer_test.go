@@ -0,0 +1,228 @@
+package sse
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// flushCounter records how many times it was flushed and what was written by then
+type flushCounter struct {
+	*httptest.ResponseRecorder
+	flushed []string
+}
+
+// Flush implements http.Flusher
+func (f *flushCounter) Flush() {
+	f.flushed = append(f.flushed, f.Body.String())
+	f.ResponseRecorder.Flush()
+}
+
+func TestStreamWriter(t *testing.T) {
+	rec := &flushCounter{ResponseRecorder: httptest.NewRecorder()}
+	writer := NewStreamWriter(rec)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/event-stream", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "no-cache", rec.Header().Get("Cache-Control"))
+	assert.Equal(t, "keep-alive", rec.Header().Get("Connection"))
+	require.Len(t, rec.flushed, 1, "headers are flushed before any event")
+
+	require.NoError(t, writer.WriteEvent(events.NewRunStartedEvent("thread-1", "run-1")))
+	require.NoError(t, writer.WriteEvent(events.NewRunFinishedEvent("thread-1", "run-1")))
+
+	// Every event is flushed right after it is written
+	require.Len(t, rec.flushed, 3)
+	assert.True(t, strings.HasSuffix(rec.flushed[1], "\n\n"))
+	assert.Contains(t, rec.flushed[1], "RUN_STARTED")
+	assert.NotContains(t, rec.flushed[1], "RUN_FINISHED")
+	assert.Contains(t, rec.flushed[2], "RUN_FINISHED")
+
+	assert.Error(t, writer.WriteEvent(nil))
+}
+
+func TestStreamWriter_EncoderOptions(t *testing.T) {
+	rec := httptest.NewRecorder()
+	writer := NewStreamWriter(rec, WithEncoderOptions(WithSigningKey([]byte("secret"))))
+	require.NoError(t, writer.WriteEvent(events.NewStepStartedEvent("plan")))
+
+	dec := NewDecoder(rec.Body, WithVerificationKey([]byte("secret")))
+	event, err := dec.Next()
+	require.NoError(t, err)
+	assert.Equal(t, events.EventTypeStepStarted, event.Type())
+}
+
+// syncRecorder is a response recorder that may be read while a heartbeat
+// goroutine writes to it
+type syncRecorder struct {
+	mu  sync.Mutex
+	rec *httptest.ResponseRecorder
+}
+
+func newSyncRecorder() *syncRecorder {
+	return &syncRecorder{rec: httptest.NewRecorder()}
+}
+
+func (s *syncRecorder) Header() http.Header { return s.rec.Header() }
+
+func (s *syncRecorder) WriteHeader(code int) { s.rec.WriteHeader(code) }
+
+func (s *syncRecorder) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rec.Write(p)
+}
+
+func (s *syncRecorder) Flush() {}
+
+func (s *syncRecorder) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rec.Body.String()
+}
+
+func TestStreamWriter_Heartbeat(t *testing.T) {
+	t.Run("PingsWhenIdle", func(t *testing.T) {
+		rec := newSyncRecorder()
+		writer := NewStreamWriter(rec, WithHeartbeat(10*time.Millisecond))
+		defer writer.Close()
+
+		require.Eventually(t, func() bool {
+			return strings.Count(rec.String(), ":ping\n\n") >= 2
+		}, time.Second, 5*time.Millisecond)
+
+		require.NoError(t, writer.WriteEvent(events.NewRunStartedEvent("thread-1", "run-1")))
+		require.NoError(t, writer.Close())
+
+		// Heartbeats are comments, so decoders skip them
+		dec := NewDecoder(strings.NewReader(rec.String()))
+		event, err := dec.Next()
+		require.NoError(t, err)
+		assert.Equal(t, events.EventTypeRunStarted, event.Type())
+	})
+
+	t.Run("NoPingWhileEventsFlow", func(t *testing.T) {
+		rec := newSyncRecorder()
+		writer := NewStreamWriter(rec, WithHeartbeat(50*time.Millisecond))
+		defer writer.Close()
+
+		for i := 0; i < 10; i++ {
+			require.NoError(t, writer.WriteEvent(events.NewStepStartedEvent("step")))
+			time.Sleep(10 * time.Millisecond)
+		}
+		assert.NotContains(t, rec.String(), ":ping")
+	})
+
+	t.Run("StopsOnClose", func(t *testing.T) {
+		rec := newSyncRecorder()
+		writer := NewStreamWriter(rec, WithHeartbeat(5*time.Millisecond))
+		require.NoError(t, writer.Close())
+		require.NoError(t, writer.Close())
+
+		body := rec.String()
+		time.Sleep(30 * time.Millisecond)
+		assert.Equal(t, body, rec.String())
+		assert.ErrorIs(t, writer.WriteEvent(events.NewStepStartedEvent("late")), ErrStreamClosed)
+	})
+
+	t.Run("ConcurrentWrites", func(t *testing.T) {
+		rec := newSyncRecorder()
+		writer := NewStreamWriter(rec, WithHeartbeat(time.Millisecond))
+
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 20; j++ {
+					assert.NoError(t, writer.WriteEvent(events.NewStepStartedEvent("step")))
+					time.Sleep(time.Millisecond)
+				}
+			}()
+		}
+		wg.Wait()
+		require.NoError(t, writer.Close())
+
+		// Frames are never interleaved
+		dec := NewDecoder(strings.NewReader(rec.String()))
+		count := 0
+		for {
+			_, err := dec.Next()
+			if err != nil {
+				break
+			}
+			count++
+		}
+		assert.Equal(t, 80, count)
+	})
+}
+
+func TestStreamWriter_CloseBehavior(t *testing.T) {
+	// decodeAll returns the events written to rec
+	decodeAll := func(t *testing.T, rec *httptest.ResponseRecorder) []events.Event {
+		dec := NewDecoder(rec.Body)
+		var decoded []events.Event
+		for {
+			event, err := dec.Next()
+			if err != nil {
+				return decoded
+			}
+			decoded = append(decoded, event)
+		}
+	}
+
+	t.Run("RunError", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		writer := NewStreamWriter(rec, WithCloseBehavior(CloseWithRunError))
+		require.NoError(t, writer.WriteEvent(events.NewRunStartedEvent("thread-1", "run-1")))
+		require.NoError(t, writer.Close())
+		require.NoError(t, writer.Close())
+
+		decoded := decodeAll(t, rec)
+		require.Len(t, decoded, 2)
+		runErr, ok := decoded[1].(*events.RunErrorEvent)
+		require.True(t, ok)
+		require.NotNil(t, runErr.Code)
+		assert.Equal(t, events.RunErrorCodeCancelled, *runErr.Code)
+		assert.Equal(t, "run-1", runErr.RunID())
+		assert.ErrorIs(t, runErr.AsError(), events.ErrRunCancelled)
+	})
+
+	t.Run("RunFinished", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		writer := NewStreamWriter(rec, WithCloseBehavior(CloseWithRunFinished))
+		require.NoError(t, writer.WriteEvent(events.NewRunStartedEvent("thread-1", "run-1")))
+		require.NoError(t, writer.Close())
+
+		decoded := decodeAll(t, rec)
+		require.Len(t, decoded, 2)
+		finished, ok := decoded[1].(*events.RunFinishedEvent)
+		require.True(t, ok)
+		assert.Equal(t, "thread-1", finished.ThreadID())
+		assert.Equal(t, "run-1", finished.RunID())
+	})
+
+	t.Run("FinishedRun", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		writer := NewStreamWriter(rec, WithCloseBehavior(CloseWithRunError))
+		require.NoError(t, writer.WriteEvent(events.NewRunStartedEvent("thread-1", "run-1")))
+		require.NoError(t, writer.WriteEvent(events.NewRunFinishedEvent("thread-1", "run-1")))
+		require.NoError(t, writer.Close())
+		assert.Len(t, decodeAll(t, rec), 2)
+	})
+
+	t.Run("Silent", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		writer := NewStreamWriter(rec)
+		require.NoError(t, writer.WriteEvent(events.NewRunStartedEvent("thread-1", "run-1")))
+		require.NoError(t, writer.Close())
+		assert.Len(t, decodeAll(t, rec), 1)
+	})
+}
@@ -0,0 +1,57 @@
+package sse
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+)
+
+// Transport carries events over an HTTP Server-Sent Events stream and
+// satisfies transport.Transport. A server transport can only Send and a
+// client transport can only Recv; the other direction returns an error
+// wrapping errors.ErrUnsupported, since Server-Sent Events only flow from
+// server to client.
+type Transport struct {
+	writer  *StreamWriter
+	decoder *Decoder
+	body    io.Closer
+}
+
+// NewServerTransport creates a transport that streams events to an HTTP
+// client through a StreamWriter configured with options
+func NewServerTransport(w http.ResponseWriter, options ...StreamWriterOption) *Transport {
+	return &Transport{writer: NewStreamWriter(w, options...)}
+}
+
+// NewClientTransport creates a transport that reads events from the body of
+// an HTTP response, decoded with options
+func NewClientTransport(resp *http.Response, options ...DecoderOption) *Transport {
+	return &Transport{decoder: NewResponseDecoder(resp, options...), body: resp.Body}
+}
+
+// Send writes and flushes an event
+func (t *Transport) Send(event events.Event) error {
+	if t.writer == nil {
+		return fmt.Errorf("%w: cannot send on a client SSE transport", errors.ErrUnsupported)
+	}
+	return t.writer.WriteEvent(event)
+}
+
+// Recv reads the next event, returning io.EOF at the end of the stream
+func (t *Transport) Recv() (events.Event, error) {
+	if t.decoder == nil {
+		return nil, fmt.Errorf("%w: cannot receive on a server SSE transport", errors.ErrUnsupported)
+	}
+	return t.decoder.Next()
+}
+
+// Close closes the stream writer or the response body
+func (t *Transport) Close() error {
+	if t.writer != nil {
+		return t.writer.Close()
+	}
+	return t.body.Close()
+}
@@ -171,8 +171,10 @@ func (w *SSEWriter) createSSEFrame(jsonData []byte, eventType string, event even
 		frame.WriteString(fmt.Sprintf("event: %s\n", eventType))
 	}
 
-	// Add event ID if available
-	if event != nil && event.Timestamp() != nil {
+	// Add event ID if available, preferring an explicitly assigned one
+	if event != nil && event.GetBaseEvent() != nil && event.GetBaseEvent().EventID != "" {
+		frame.WriteString(fmt.Sprintf("id: %s\n", sanitizeID(event.GetBaseEvent().EventID)))
+	} else if event != nil && event.Timestamp() != nil {
 		frame.WriteString(fmt.Sprintf("id: %s_%d\n", event.Type(), *event.Timestamp()))
 	}
 
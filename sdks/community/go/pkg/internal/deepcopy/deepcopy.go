@@ -0,0 +1,72 @@
+// Package deepcopy copies values so that the copy shares no mutable state,
+// such as maps, slices or pointed-to values, with the original.
+package deepcopy
+
+import "reflect"
+
+// Copy returns a deep copy of v. Pointers, maps, slices and interface values
+// are copied recursively; unexported struct fields, channels and functions
+// are copied shallowly. Values must not contain reference cycles.
+func Copy[T any](v T) T {
+	// The assertion only fails for a nil interface, whose copy is nil too
+	copied, _ := copyValue(reflect.ValueOf(&v).Elem()).Interface().(T)
+	return copied
+}
+
+// copyValue returns a deep copy of v with the same type
+func copyValue(v reflect.Value) reflect.Value {
+	out := reflect.New(v.Type()).Elem()
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return out
+		}
+		copied := reflect.New(v.Type().Elem())
+		copied.Elem().Set(copyValue(v.Elem()))
+		out.Set(copied)
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return out
+		}
+		out.Set(copyValue(v.Elem()))
+
+	case reflect.Map:
+		if v.IsNil() {
+			return out
+		}
+		out.Set(reflect.MakeMapWithSize(v.Type(), v.Len()))
+		iter := v.MapRange()
+		for iter.Next() {
+			out.SetMapIndex(copyValue(iter.Key()), copyValue(iter.Value()))
+		}
+
+	case reflect.Slice:
+		if v.IsNil() {
+			return out
+		}
+		out.Set(reflect.MakeSlice(v.Type(), v.Len(), v.Len()))
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(copyValue(v.Index(i)))
+		}
+
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(copyValue(v.Index(i)))
+		}
+
+	case reflect.Struct:
+		out.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if field := out.Field(i); field.CanSet() {
+				field.Set(copyValue(v.Field(i)))
+			}
+		}
+
+	default:
+		out.Set(v)
+	}
+
+	return out
+}
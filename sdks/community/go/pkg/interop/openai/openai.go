@@ -0,0 +1,449 @@
+// Package openai converts AG-UI messages to and from the OpenAI chat
+// completions message format, so that agents wrapping the OpenAI API do not
+// have to hand-write the mapping of roles, tool calls and tool results.
+//
+// The package defines its own wire types mirroring the chat completions JSON,
+// which marshal to exactly what the API expects and can be converted to the
+// types of any OpenAI client library.
+package openai
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/types"
+)
+
+// Chat completion roles
+const (
+	RoleSystem    = "system"
+	RoleDeveloper = "developer"
+	RoleUser      = "user"
+	RoleAssistant = "assistant"
+	RoleTool      = "tool"
+)
+
+// Content part types
+const (
+	PartTypeText       = "text"
+	PartTypeImageURL   = "image_url"
+	PartTypeInputAudio = "input_audio"
+)
+
+// ChatMessage is a message of a chat completions request. Content is either
+// a string or a []ContentPart, and is nil for assistant messages that only
+// carry tool calls.
+type ChatMessage struct {
+	Role       string     `json:"role"`
+	Content    any        `json:"content"`
+	Name       string     `json:"name,omitempty"`
+	ToolCalls  []ToolCall `json:"tool_calls,omitempty"`
+	ToolCallID string     `json:"tool_call_id,omitempty"`
+}
+
+// ContentPart is a single part of multimodal user content
+type ContentPart struct {
+	Type       string      `json:"type"`
+	Text       string      `json:"text,omitempty"`
+	ImageURL   *ImageURL   `json:"image_url,omitempty"`
+	InputAudio *InputAudio `json:"input_audio,omitempty"`
+}
+
+// ImageURL references an image by URL or data URI
+type ImageURL struct {
+	URL    string `json:"url"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// InputAudio carries base64-encoded audio
+type InputAudio struct {
+	Data   string `json:"data"`
+	Format string `json:"format"`
+}
+
+// ToolCall is a function call requested by the assistant
+type ToolCall struct {
+	ID       string       `json:"id"`
+	Type     string       `json:"type"`
+	Function FunctionCall `json:"function"`
+}
+
+// FunctionCall names the function and carries its JSON-encoded arguments
+type FunctionCall struct {
+	Name      string `json:"name"`
+	Arguments string `json:"arguments"`
+}
+
+// UnsupportedPolicy controls how messages without an OpenAI equivalent, such
+// as activity and reasoning messages, are converted
+type UnsupportedPolicy int
+
+const (
+	// UnsupportedDrop omits the messages
+	UnsupportedDrop UnsupportedPolicy = iota
+	// UnsupportedAsSystemNote renders the messages as system messages so that
+	// the model still sees them
+	UnsupportedAsSystemNote
+	// UnsupportedReject fails the conversion
+	UnsupportedReject
+)
+
+// Option defines options for converting messages
+type Option func(*config)
+
+// config holds the settings applied by Option values
+type config struct {
+	unsupported UnsupportedPolicy
+	newID       func() string
+}
+
+// WithUnsupportedPolicy sets how activity and reasoning messages are converted.
+// The default is UnsupportedDrop.
+func WithUnsupportedPolicy(policy UnsupportedPolicy) Option {
+	return func(c *config) {
+		c.unsupported = policy
+	}
+}
+
+// WithIDGenerator sets the function used to assign IDs to messages converted
+// from OpenAI, which carry none. The default is events.GenerateMessageID.
+func WithIDGenerator(newID func() string) Option {
+	return func(c *config) {
+		c.newID = newID
+	}
+}
+
+// newConfig applies options over the defaults
+func newConfig(options []Option) *config {
+	c := &config{unsupported: UnsupportedDrop, newID: events.GenerateMessageID}
+	for _, opt := range options {
+		opt(c)
+	}
+	return c
+}
+
+// ToOpenAIMessages converts AG-UI messages to chat completions messages
+func ToOpenAIMessages(msgs []types.Message, options ...Option) ([]ChatMessage, error) {
+	cfg := newConfig(options)
+
+	converted := make([]ChatMessage, 0, len(msgs))
+	for i, msg := range msgs {
+		chat, ok, err := toOpenAIMessage(msg, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("message %d (%s): %w", i, msg.ID, err)
+		}
+		if ok {
+			converted = append(converted, chat)
+		}
+	}
+	return converted, nil
+}
+
+// toOpenAIMessage converts a single message; ok is false for dropped messages
+func toOpenAIMessage(msg types.Message, cfg *config) (chat ChatMessage, ok bool, err error) {
+	switch msg.Role {
+	case types.RoleSystem, types.RoleDeveloper:
+		return ChatMessage{Role: string(msg.Role), Content: msg.Text(), Name: msg.Name}, true, nil
+
+	case types.RoleUser:
+		content, err := userContent(msg)
+		if err != nil {
+			return ChatMessage{}, false, err
+		}
+		return ChatMessage{Role: RoleUser, Content: content, Name: msg.Name}, true, nil
+
+	case types.RoleAssistant:
+		chat := ChatMessage{Role: RoleAssistant, Name: msg.Name}
+		if text := msg.Text(); text != "" || len(msg.ToolCalls) == 0 {
+			chat.Content = text
+		}
+		for _, call := range msg.ToolCalls {
+			chat.ToolCalls = append(chat.ToolCalls, ToolCall{
+				ID:       call.ID,
+				Type:     "function",
+				Function: FunctionCall{Name: call.Function.Name, Arguments: call.Function.Arguments},
+			})
+		}
+		return chat, true, nil
+
+	case types.RoleTool:
+		if msg.ToolCallID == "" {
+			return ChatMessage{}, false, fmt.Errorf("tool message has no toolCallId")
+		}
+		content := msg.Text()
+		if content == "" && msg.Error != "" {
+			content = "Error: " + msg.Error
+		}
+		return ChatMessage{Role: RoleTool, Content: content, ToolCallID: msg.ToolCallID}, true, nil
+
+	case types.RoleActivity, types.RoleReasoning:
+		switch cfg.unsupported {
+		case UnsupportedAsSystemNote:
+			return ChatMessage{Role: RoleSystem, Content: systemNote(msg)}, true, nil
+		case UnsupportedReject:
+			return ChatMessage{}, false, fmt.Errorf("role %s has no OpenAI equivalent", msg.Role)
+		default:
+			return ChatMessage{}, false, nil
+		}
+	}
+
+	return ChatMessage{}, false, fmt.Errorf("unsupported role %q", msg.Role)
+}
+
+// userContent converts user content to a string or content parts
+func userContent(msg types.Message) (any, error) {
+	parts, ok := msg.ContentParts()
+	if !ok {
+		return msg.Text(), nil
+	}
+
+	converted := make([]ContentPart, 0, len(parts))
+	for j, part := range parts {
+		contentPart, err := toContentPart(part)
+		if err != nil {
+			return nil, fmt.Errorf("content part %d: %w", j, err)
+		}
+		converted = append(converted, contentPart)
+	}
+	return converted, nil
+}
+
+// toContentPart converts a multimodal fragment to an OpenAI content part
+func toContentPart(part types.InputContent) (ContentPart, error) {
+	if part.Type == types.InputContentTypeText {
+		return ContentPart{Type: PartTypeText, Text: part.Text}, nil
+	}
+
+	mimeType, url, data := part.MimeType, part.URL, part.Data
+	if part.Source != nil {
+		if part.Source.MimeType != "" {
+			mimeType = part.Source.MimeType
+		}
+		switch part.Source.Type {
+		case types.InputContentSourceTypeURL:
+			url = part.Source.Value
+		case types.InputContentSourceTypeData:
+			data = part.Source.Value
+		}
+	}
+
+	isImage := part.Type == types.InputContentTypeImage || strings.HasPrefix(mimeType, "image/")
+	isAudio := part.Type == types.InputContentTypeAudio || strings.HasPrefix(mimeType, "audio/")
+	switch {
+	case isImage && url != "":
+		return ContentPart{Type: PartTypeImageURL, ImageURL: &ImageURL{URL: url}}, nil
+	case isImage && data != "":
+		if mimeType == "" {
+			return ContentPart{}, fmt.Errorf("inline image has no mime type")
+		}
+		return ContentPart{Type: PartTypeImageURL, ImageURL: &ImageURL{URL: "data:" + mimeType + ";base64," + data}}, nil
+	case isAudio && data != "":
+		format, ok := audioFormats[mimeType]
+		if !ok {
+			return ContentPart{}, fmt.Errorf("audio format %q is not supported", mimeType)
+		}
+		return ContentPart{Type: PartTypeInputAudio, InputAudio: &InputAudio{Data: data, Format: format}}, nil
+	}
+
+	return ContentPart{}, fmt.Errorf("%s content with mime type %q is not supported", part.Type, mimeType)
+}
+
+// audioFormats maps audio mime types to OpenAI input audio formats
+var audioFormats = map[string]string{
+	"audio/wav":   "wav",
+	"audio/x-wav": "wav",
+	"audio/wave":  "wav",
+	"audio/mpeg":  "mp3",
+	"audio/mp3":   "mp3",
+}
+
+// systemNote renders a message without an OpenAI role as system text
+func systemNote(msg types.Message) string {
+	label := string(msg.Role)
+	if msg.ActivityType != "" {
+		label += " " + msg.ActivityType
+	}
+
+	text := msg.Text()
+	if text == "" && msg.Content != nil {
+		if data, err := json.Marshal(msg.Content); err == nil {
+			text = string(data)
+		}
+	}
+	return fmt.Sprintf("[%s] %s", label, text)
+}
+
+// FromOpenAIMessages converts chat completions messages to AG-UI messages,
+// assigning each a new ID
+func FromOpenAIMessages(msgs []ChatMessage, options ...Option) ([]types.Message, error) {
+	cfg := newConfig(options)
+
+	converted := make([]types.Message, 0, len(msgs))
+	for i, chat := range msgs {
+		msg, err := fromOpenAIMessage(chat, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("message %d (%s): %w", i, chat.Role, err)
+		}
+		converted = append(converted, msg)
+	}
+	return converted, nil
+}
+
+// fromOpenAIMessage converts a single chat message
+func fromOpenAIMessage(chat ChatMessage, cfg *config) (types.Message, error) {
+	msg := types.Message{ID: cfg.newID(), Name: chat.Name}
+
+	switch chat.Role {
+	case RoleSystem, RoleDeveloper, RoleAssistant:
+		msg.Role = types.Role(chat.Role)
+		text, err := textContent(chat.Content)
+		if err != nil {
+			return types.Message{}, err
+		}
+		if text != "" || chat.Role != RoleAssistant {
+			msg.Content = text
+		}
+		for _, call := range chat.ToolCalls {
+			msg.ToolCalls = append(msg.ToolCalls, types.ToolCall{
+				ID:       call.ID,
+				Type:     "function",
+				Function: types.FunctionCall{Name: call.Function.Name, Arguments: call.Function.Arguments},
+			})
+		}
+
+	case RoleUser:
+		msg.Role = types.RoleUser
+		content, err := fromUserContent(chat.Content)
+		if err != nil {
+			return types.Message{}, err
+		}
+		msg.Content = content
+
+	case RoleTool:
+		if chat.ToolCallID == "" {
+			return types.Message{}, fmt.Errorf("tool message has no tool_call_id")
+		}
+		msg.Role = types.RoleTool
+		msg.ToolCallID = chat.ToolCallID
+		text, err := textContent(chat.Content)
+		if err != nil {
+			return types.Message{}, err
+		}
+		msg.Content = text
+
+	default:
+		return types.Message{}, fmt.Errorf("unsupported role %q", chat.Role)
+	}
+
+	return msg, nil
+}
+
+// decodeParts converts content decoded from JSON into content parts
+func decodeParts(content any) ([]ContentPart, bool, error) {
+	switch value := content.(type) {
+	case []ContentPart:
+		return value, true, nil
+	case []any:
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, false, err
+		}
+		var parts []ContentPart
+		if err := json.Unmarshal(data, &parts); err != nil {
+			return nil, false, fmt.Errorf("invalid content parts: %w", err)
+		}
+		return parts, true, nil
+	}
+	return nil, false, nil
+}
+
+// textContent flattens string or text part content into a string
+func textContent(content any) (string, error) {
+	if content == nil {
+		return "", nil
+	}
+	if text, ok := content.(string); ok {
+		return text, nil
+	}
+
+	parts, ok, err := decodeParts(content)
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return "", fmt.Errorf("unsupported content type %T", content)
+	}
+
+	var b strings.Builder
+	for _, part := range parts {
+		if part.Type != PartTypeText {
+			return "", fmt.Errorf("content part type %q is only supported in user messages", part.Type)
+		}
+		b.WriteString(part.Text)
+	}
+	return b.String(), nil
+}
+
+// fromUserContent converts user content to a string or multimodal fragments
+func fromUserContent(content any) (any, error) {
+	if content == nil {
+		return "", nil
+	}
+	if text, ok := content.(string); ok {
+		return text, nil
+	}
+
+	parts, ok, err := decodeParts(content)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("unsupported content type %T", content)
+	}
+
+	converted := make([]types.InputContent, 0, len(parts))
+	for j, part := range parts {
+		switch {
+		case part.Type == PartTypeText:
+			converted = append(converted, types.InputContent{Type: types.InputContentTypeText, Text: part.Text})
+		case part.Type == PartTypeImageURL && part.ImageURL != nil:
+			source, err := imageSource(part.ImageURL.URL)
+			if err != nil {
+				return nil, fmt.Errorf("content part %d: %w", j, err)
+			}
+			converted = append(converted, types.InputContent{Type: types.InputContentTypeImage, Source: source})
+		case part.Type == PartTypeInputAudio && part.InputAudio != nil:
+			converted = append(converted, types.InputContent{
+				Type: types.InputContentTypeAudio,
+				Source: &types.InputContentSource{
+					Type:     types.InputContentSourceTypeData,
+					Value:    part.InputAudio.Data,
+					MimeType: "audio/" + part.InputAudio.Format,
+				},
+			})
+		default:
+			return nil, fmt.Errorf("content part %d: unsupported type %q", j, part.Type)
+		}
+	}
+	return converted, nil
+}
+
+// imageSource converts an image URL to a content source, unpacking inline
+// base64 data URIs into data sources
+func imageSource(url string) (*types.InputContentSource, error) {
+	if !types.IsDataURI(url) {
+		return &types.InputContentSource{Type: types.InputContentSourceTypeURL, Value: url}, nil
+	}
+
+	header, data, _ := strings.Cut(url[len("data:"):], ",")
+	mimeType, isBase64 := strings.CutSuffix(header, ";base64")
+	if !isBase64 {
+		return &types.InputContentSource{Type: types.InputContentSourceTypeURL, Value: url}, nil
+	}
+	if _, _, err := types.ParseDataURI(url); err != nil {
+		return nil, err
+	}
+	return &types.InputContentSource{Type: types.InputContentSourceTypeData, Value: data, MimeType: mimeType}, nil
+}
@@ -0,0 +1,143 @@
+package openai
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// conversation exercises every role and a multimodal user message
+var conversation = []types.Message{
+	{ID: "m1", Role: types.RoleSystem, Content: "be brief"},
+	{ID: "m2", Role: types.RoleUser, Content: []types.InputContent{
+		{Type: types.InputContentTypeText, Text: "what is this?"},
+		{Type: types.InputContentTypeImage, Source: &types.InputContentSource{Type: types.InputContentSourceTypeURL, Value: "https://example.com/a.png"}},
+		{Type: types.InputContentTypeImage, Source: &types.InputContentSource{Type: types.InputContentSourceTypeData, Value: "iVBORw0", MimeType: "image/png"}},
+		{Type: types.InputContentTypeAudio, Source: &types.InputContentSource{Type: types.InputContentSourceTypeData, Value: "UklGR", MimeType: "audio/wav"}},
+	}},
+	{ID: "m3", Role: types.RoleActivity, ActivityType: "PLAN", Content: map[string]any{"step": "search"}},
+	{ID: "m4", Role: types.RoleAssistant, ToolCalls: []types.ToolCall{{
+		ID: "call-1", Type: "function", Function: types.FunctionCall{Name: "lookup", Arguments: `{"q":"png"}`},
+	}}},
+	{ID: "m5", Role: types.RoleTool, ToolCallID: "call-1", Content: "a cat"},
+	{ID: "m6", Role: types.RoleReasoning, Content: "the user wants a description"},
+	{ID: "m7", Role: types.RoleAssistant, Content: "It is a cat."},
+}
+
+func TestToOpenAIMessages(t *testing.T) {
+	converted, err := ToOpenAIMessages(conversation)
+	require.NoError(t, err)
+
+	data, err := json.Marshal(converted)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"role":"system","content":"be brief"},
+		{"role":"user","content":[
+			{"type":"text","text":"what is this?"},
+			{"type":"image_url","image_url":{"url":"https://example.com/a.png"}},
+			{"type":"image_url","image_url":{"url":"data:image/png;base64,iVBORw0"}},
+			{"type":"input_audio","input_audio":{"data":"UklGR","format":"wav"}}
+		]},
+		{"role":"assistant","content":null,"tool_calls":[
+			{"id":"call-1","type":"function","function":{"name":"lookup","arguments":"{\"q\":\"png\"}"}}
+		]},
+		{"role":"tool","content":"a cat","tool_call_id":"call-1"},
+		{"role":"assistant","content":"It is a cat."}
+	]`, string(data))
+
+	t.Run("UnsupportedPolicies", func(t *testing.T) {
+		converted, err := ToOpenAIMessages(conversation, WithUnsupportedPolicy(UnsupportedAsSystemNote))
+		require.NoError(t, err)
+		require.Len(t, converted, 7)
+		assert.Equal(t, ChatMessage{Role: RoleSystem, Content: `[activity PLAN] {"step":"search"}`}, converted[2])
+		assert.Equal(t, ChatMessage{Role: RoleSystem, Content: "[reasoning] the user wants a description"}, converted[5])
+
+		_, err = ToOpenAIMessages(conversation, WithUnsupportedPolicy(UnsupportedReject))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "message 2 (m3)")
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		for _, msg := range []types.Message{
+			{ID: "x", Role: types.RoleTool, Content: "orphan"},
+			{ID: "x", Role: "critic", Content: "?"},
+			{ID: "x", Role: types.RoleUser, Content: []types.InputContent{
+				{Type: types.InputContentTypeVideo, Source: &types.InputContentSource{Type: types.InputContentSourceTypeURL, Value: "https://example.com/v.mp4"}},
+			}},
+		} {
+			_, err := ToOpenAIMessages([]types.Message{msg})
+			assert.Error(t, err, msg.Role)
+		}
+	})
+}
+
+func TestFromOpenAIMessages(t *testing.T) {
+	payload := `[
+		{"role":"developer","content":"be brief"},
+		{"role":"user","content":[
+			{"type":"text","text":"hi"},
+			{"type":"image_url","image_url":{"url":"https://example.com/a.png"}},
+			{"type":"input_audio","input_audio":{"data":"UklGR","format":"mp3"}}
+		]},
+		{"role":"assistant","content":null,"tool_calls":[
+			{"id":"call-1","type":"function","function":{"name":"lookup","arguments":"{}"}}
+		]},
+		{"role":"tool","tool_call_id":"call-1","content":[{"type":"text","text":"done"}]}
+	]`
+	var chat []ChatMessage
+	require.NoError(t, json.Unmarshal([]byte(payload), &chat))
+
+	next := 0
+	msgs, err := FromOpenAIMessages(chat, WithIDGenerator(func() string {
+		next++
+		return fmt.Sprintf("msg-%d", next)
+	}))
+	require.NoError(t, err)
+	require.Len(t, msgs, 4)
+
+	assert.Equal(t, types.Message{ID: "msg-1", Role: types.RoleDeveloper, Content: "be brief"}, msgs[0])
+	assert.Equal(t, []types.InputContent{
+		{Type: types.InputContentTypeText, Text: "hi"},
+		{Type: types.InputContentTypeImage, Source: &types.InputContentSource{Type: types.InputContentSourceTypeURL, Value: "https://example.com/a.png"}},
+		{Type: types.InputContentTypeAudio, Source: &types.InputContentSource{Type: types.InputContentSourceTypeData, Value: "UklGR", MimeType: "audio/mp3"}},
+	}, msgs[1].Content)
+	assert.Nil(t, msgs[2].Content)
+	assert.Equal(t, "lookup", msgs[2].ToolCalls[0].Function.Name)
+	assert.Equal(t, types.Message{ID: "msg-4", Role: types.RoleTool, Content: "done", ToolCallID: "call-1"}, msgs[3])
+
+	// Converting back yields the original chat messages
+	roundTrip, err := ToOpenAIMessages(msgs)
+	require.NoError(t, err)
+	data, err := json.Marshal(roundTrip)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"input_audio":{"data":"UklGR","format":"mp3"}`)
+
+	// Inline images arrive as data URIs and become data sources
+	msgs, err = FromOpenAIMessages([]ChatMessage{{Role: RoleUser, Content: []ContentPart{
+		{Type: PartTypeImageURL, ImageURL: &ImageURL{URL: "data:image/png;base64,iVBORw0KGgo="}},
+	}}})
+	require.NoError(t, err)
+	assert.Equal(t, []types.InputContent{{
+		Type:   types.InputContentTypeImage,
+		Source: &types.InputContentSource{Type: types.InputContentSourceTypeData, Value: "iVBORw0KGgo=", MimeType: "image/png"},
+	}}, msgs[0].Content)
+	roundTrip, err = ToOpenAIMessages(msgs)
+	require.NoError(t, err)
+	assert.Equal(t, "data:image/png;base64,iVBORw0KGgo=", roundTrip[0].Content.([]ContentPart)[0].ImageURL.URL)
+
+	_, err = FromOpenAIMessages([]ChatMessage{{Role: RoleUser, Content: []ContentPart{
+		{Type: PartTypeImageURL, ImageURL: &ImageURL{URL: "data:image/png;base64,***"}},
+	}}})
+	assert.ErrorIs(t, err, types.ErrInvalidDataURI)
+
+	_, err = FromOpenAIMessages([]ChatMessage{{Role: "function", Content: "x"}})
+	assert.Error(t, err)
+	_, err = FromOpenAIMessages([]ChatMessage{{Role: RoleTool, Content: "x"}})
+	assert.Error(t, err)
+	_, err = FromOpenAIMessages([]ChatMessage{{Role: RoleAssistant, Content: []any{map[string]any{"type": "image_url"}}}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,203 @@
+// Package record captures AG-UI event streams to line-delimited JSON and
+// replays them, so that a session observed in the field can be reproduced
+// locally against a handler.
+//
+// Each line of a recording is a JSON object of the form
+//
+//	{"recordedAt":1700000000000,"id":"42","event":{"type":"RUN_STARTED",...}}
+//
+// where recordedAt is the capture time in Unix milliseconds, id is the event's
+// transport ID (omitted when empty) and event is the canonical JSON encoding
+// of the event.
+package record
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+)
+
+// maxLineBytes bounds a single recorded line when replaying
+const maxLineBytes = 16 * 1024 * 1024
+
+// entry is a single line of a recording
+type entry struct {
+	RecordedAt int64           `json:"recordedAt"`
+	ID         string          `json:"id,omitempty"`
+	Event      json.RawMessage `json:"event"`
+}
+
+// Recorder writes events to a recording. It is safe for concurrent use.
+type Recorder struct {
+	mu  sync.Mutex
+	w   io.Writer
+	now func() time.Time
+}
+
+// NewRecorder creates a recorder writing to w
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{w: w, now: time.Now}
+}
+
+// Create creates or truncates the named file and returns a recorder writing to
+// it. Close the recorder to close the file.
+func Create(path string) (*Recorder, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create recording: %w", err)
+	}
+	return NewRecorder(file), nil
+}
+
+// Record appends an event to the recording
+func (r *Recorder) Record(event events.Event) error {
+	data, err := events.MarshalCanonical(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	line, err := json.Marshal(entry{
+		RecordedAt: r.now().UnixMilli(),
+		ID:         event.GetBaseEvent().EventID,
+		Event:      data,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode recording entry: %w", err)
+	}
+	if _, err := r.w.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write recording: %w", err)
+	}
+	return nil
+}
+
+// Close closes the underlying writer if it implements io.Closer
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if closer, ok := r.w.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// Replayer reads a recording back as a stream of events
+type Replayer struct {
+	r     io.Reader
+	speed float64
+
+	// closer is closed when a replay finishes, for replayers created by Open
+	closer io.Closer
+}
+
+// ReplayerOption defines options for creating replayers
+type ReplayerOption func(*Replayer)
+
+// WithPacing delays each event by the time that passed between it and the
+// previous event when recorded, divided by speed: 1 replays in real time, 2
+// twice as fast. Without pacing events are delivered as fast as they are read.
+func WithPacing(speed float64) ReplayerOption {
+	return func(p *Replayer) {
+		p.speed = speed
+	}
+}
+
+// NewReplayer creates a replayer reading a recording from r
+func NewReplayer(r io.Reader, options ...ReplayerOption) *Replayer {
+	p := &Replayer{r: r}
+	for _, opt := range options {
+		opt(p)
+	}
+	return p
+}
+
+// Open opens a recording file for replay. The file is closed when the replay
+// finishes.
+func Open(path string, options ...ReplayerOption) (*Replayer, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open recording: %w", err)
+	}
+	p := NewReplayer(file, options...)
+	p.closer = file
+	return p, nil
+}
+
+// Replay streams the recorded events. The event channel closes at the end of
+// the recording, on the first malformed entry, or when ctx is cancelled. A
+// failure is sent on the error channel before both channels close.
+func (p *Replayer) Replay(ctx context.Context) (<-chan events.Event, <-chan error) {
+	out := make(chan events.Event)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer func() {
+			if p.closer != nil {
+				_ = p.closer.Close()
+			}
+			close(out)
+			close(errs)
+		}()
+
+		if err := p.replay(ctx, out); err != nil && ctx.Err() == nil {
+			errs <- err
+		}
+	}()
+
+	return out, errs
+}
+
+// replay reads entries and sends their events until the recording ends
+func (p *Replayer) replay(ctx context.Context, out chan<- events.Event) error {
+	scanner := bufio.NewScanner(p.r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineBytes)
+
+	var previous int64
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var e entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return fmt.Errorf("recording line %d: %w", line, err)
+		}
+		event, err := events.EventFromJSON(e.Event)
+		if err != nil {
+			return fmt.Errorf("recording line %d: %w", line, err)
+		}
+		event.GetBaseEvent().EventID = e.ID
+
+		if p.speed > 0 && previous != 0 && e.RecordedAt > previous {
+			delay := time.Duration(float64(time.Duration(e.RecordedAt-previous)*time.Millisecond) / p.speed)
+			timer := time.NewTimer(delay)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			}
+		}
+		previous = e.RecordedAt
+
+		select {
+		case out <- event:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read recording: %w", err)
+	}
+	return nil
+}
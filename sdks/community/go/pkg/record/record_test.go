@@ -0,0 +1,124 @@
+package record
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// drain collects replayed events and the replay error
+func drain(out <-chan events.Event, errs <-chan error) ([]events.Event, error) {
+	var collected []events.Event
+	for event := range out {
+		collected = append(collected, event)
+	}
+	return collected, <-errs
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.jsonl")
+	recorder, err := Create(path)
+	require.NoError(t, err)
+
+	clock := time.UnixMilli(1700000000000)
+	recorder.now = func() time.Time { return clock }
+
+	started := events.NewRunStartedEvent("thread-1", "run-1")
+	started.EventID = "1"
+	recorded := []events.Event{
+		started,
+		events.NewStateSnapshotEvent(map[string]any{"b": 1, "a": []any{"x"}}),
+		events.NewRunFinishedEvent("thread-1", "run-1"),
+	}
+	for _, event := range recorded {
+		require.NoError(t, recorder.Record(event))
+		clock = clock.Add(40 * time.Millisecond)
+	}
+	require.NoError(t, recorder.Close())
+
+	replayer, err := Open(path)
+	require.NoError(t, err)
+	replayed, err := drain(replayer.Replay(context.Background()))
+	require.NoError(t, err)
+	require.Len(t, replayed, len(recorded))
+	assert.Equal(t, "1", replayed[0].GetBaseEvent().EventID)
+	for i := range recorded {
+		want, err := events.MarshalCanonical(recorded[i])
+		require.NoError(t, err)
+		got, err := events.MarshalCanonical(replayed[i])
+		require.NoError(t, err)
+		assert.Equal(t, string(want), string(got))
+	}
+
+	// Paced replay at double speed waits about half the recorded gaps
+	replayer, err = Open(path, WithPacing(2))
+	require.NoError(t, err)
+	start := time.Now()
+	replayed, err = drain(replayer.Replay(context.Background()))
+	require.NoError(t, err)
+	assert.Len(t, replayed, len(recorded))
+	assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+}
+
+func TestRecordingFormatIsStable(t *testing.T) {
+	var buf bytes.Buffer
+	recorder := NewRecorder(&buf)
+	recorder.now = func() time.Time { return time.UnixMilli(1700000000000) }
+
+	event := events.NewStateSnapshotEvent(map[string]any{"z": 1, "a": 2})
+	event.SetTimestamp(1700000000000)
+	require.NoError(t, recorder.Record(event))
+	assert.Equal(t,
+		`{"recordedAt":1700000000000,"event":{"snapshot":{"a":2,"z":1},"timestamp":1700000000000,"type":"STATE_SNAPSHOT"}}`+"\n",
+		buf.String())
+}
+
+func TestReplayErrors(t *testing.T) {
+	recording := `{"recordedAt":1,"event":{"type":"RUN_STARTED","threadId":"t","runId":"r"}}` + "\n" +
+		"\n" +
+		`{"recordedAt":2,"event":{"type":"NOT_AN_EVENT"}}` + "\n"
+
+	replayed, err := drain(NewReplayer(strings.NewReader(recording)).Replay(context.Background()))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "line 3")
+	assert.Len(t, replayed, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = drain(NewReplayer(strings.NewReader(recording), WithPacing(1)).Replay(ctx))
+	assert.NoError(t, err)
+
+	_, err = Open(filepath.Join(t.TempDir(), "missing.jsonl"))
+	assert.Error(t, err)
+}
+
+func TestReplay_CancelReleasesGoroutine(t *testing.T) {
+	testutil.VerifyNoGoroutineLeaks(t)
+
+	var buf bytes.Buffer
+	recorder := NewRecorder(&buf)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, recorder.Record(events.NewStepStartedEvent("step")))
+	}
+
+	// The consumer reads one event and abandons the stream
+	ctx, cancel := context.WithCancel(context.Background())
+	out, errs := NewReplayer(&buf).Replay(ctx)
+	<-out
+	cancel()
+
+	select {
+	case err, ok := <-errs:
+		assert.False(t, ok, "cancellation is not reported as an error: %v", err)
+	case <-time.After(time.Second):
+		t.Fatal("replay did not stop after cancellation")
+	}
+}
@@ -0,0 +1,319 @@
+// Package server turns a Go function into an AG-UI agent endpoint. Handler
+// decodes the run input, streams the events the function writes to an
+// EventSink as Server-Sent Events and frames them with the run lifecycle
+// events.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/types"
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/encoding/sse"
+)
+
+// ErrLifecycleEvent is returned by EventSink.Emit for RUN_STARTED,
+// RUN_FINISHED and RUN_ERROR events, which Handler writes itself
+var ErrLifecycleEvent = errors.New("run lifecycle events are written by the handler")
+
+// RunFunc runs an agent for one request, writing its events to sink. ctx is
+// cancelled when the client disconnects. Returning nil finishes the run with
+// RUN_FINISHED and returning an error ends it with RUN_ERROR.
+type RunFunc func(ctx context.Context, input types.RunAgentInput, sink EventSink) error
+
+// EventSink writes the events of a run to the client. Unless the handler was
+// created with WithoutSequenceValidation, every event is checked with an
+// events.SequenceValidator before it is written, and an event that would make
+// the stream invalid, such as content for a message that was never started,
+// is rejected with a *events.SequenceError instead. Other errors come from the
+// underlying write and mean the client has gone away. It is safe for
+// concurrent use.
+type EventSink interface {
+	// Emit writes any event other than the run lifecycle events
+	Emit(event events.Event) error
+
+	// TextStart starts an assistant text message
+	TextStart(messageID string) error
+	// TextContent appends delta to a text message
+	TextContent(messageID, delta string) error
+	// TextEnd ends a text message
+	TextEnd(messageID string) error
+
+	// ToolCallStart starts a call to the tool named name, optionally as part
+	// of the message parentMessageID
+	ToolCallStart(toolCallID, name, parentMessageID string) error
+	// ToolCallArgs appends a fragment of the JSON arguments of a tool call
+	ToolCallArgs(toolCallID, delta string) error
+	// ToolCallEnd ends a tool call
+	ToolCallEnd(toolCallID string) error
+	// ToolCallResult writes the result of a tool call as the message messageID
+	ToolCallResult(messageID, toolCallID, content string) error
+
+	// StepStarted starts a named step of the run
+	StepStarted(name string) error
+	// StepFinished finishes a named step
+	StepFinished(name string) error
+
+	// StateSnapshot replaces the shared state
+	StateSnapshot(state any) error
+	// StateDelta updates the shared state with JSON Patch operations
+	StateDelta(ops []events.JSONPatchOperation) error
+
+	// Custom writes an application-specific event
+	Custom(name string, value any) error
+
+	// SetResult sets the result carried by the RUN_FINISHED event
+	SetResult(result any)
+}
+
+// handlerConfig holds the settings applied by HandlerOption values
+type handlerConfig struct {
+	streamOptions     []sse.StreamWriterOption
+	skipSequenceCheck bool
+}
+
+// HandlerOption defines options for creating handlers
+type HandlerOption func(*handlerConfig)
+
+// WithStreamOptions configures the SSE stream of every run, for example with
+// sse.WithHeartbeat
+func WithStreamOptions(options ...sse.StreamWriterOption) HandlerOption {
+	return func(c *handlerConfig) {
+		c.streamOptions = append(c.streamOptions, options...)
+	}
+}
+
+// WithoutSequenceValidation writes the events of the sink without checking
+// their order, for agents that produce events the validator does not know
+// about or that check the stream themselves
+func WithoutSequenceValidation() HandlerOption {
+	return func(c *handlerConfig) {
+		c.skipSequenceCheck = true
+	}
+}
+
+// Handler returns an HTTP handler that serves an agent. It accepts POST
+// requests with a JSON RunAgentInput body, rejecting invalid input with
+// 400 Bad Request, and streams the run as Server-Sent Events: RUN_STARTED,
+// the events run writes to its sink, then RUN_FINISHED, or RUN_ERROR when
+// run returns an error or panics. With sequence validation, a run that
+// returns while a message, tool call or step is still open ends with a
+// RUN_ERROR carrying the INTERNAL code.
+func Handler(run RunFunc, options ...HandlerOption) http.Handler {
+	config := &handlerConfig{}
+	for _, opt := range options {
+		opt(config)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		var input types.RunAgentInput
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			http.Error(w, fmt.Sprintf("invalid run input: %v", err), http.StatusBadRequest)
+			return
+		}
+		if err := input.Validate(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		writer := sse.NewStreamWriter(w, config.streamOptions...)
+		defer writer.Close()
+
+		s := &sink{writer: writer}
+		if !config.skipSequenceCheck {
+			s.validator = events.NewSequenceValidator()
+		}
+		if err := s.write(events.NewRunStartedEvent(input.ThreadID, input.RunID)); err != nil {
+			return
+		}
+
+		if err := invoke(r.Context(), run, input, s); err != nil {
+			_ = s.end(runErrorEvent(input.RunID, err))
+			return
+		}
+
+		finished := events.NewRunFinishedEventWithOptions(input.ThreadID, input.RunID, events.WithResult(s.result()))
+		var seqErr *events.SequenceError
+		if err := s.write(finished); errors.As(err, &seqErr) {
+			_ = s.end(events.NewRunErrorEvent(fmt.Sprintf("agent ended the run with an invalid stream: %v", err),
+				events.WithErrorCode(events.RunErrorCodeInternal), events.WithRunID(input.RunID)))
+		}
+	})
+}
+
+// invoke calls run, converting a panic into an error
+func invoke(ctx context.Context, run RunFunc, input types.RunAgentInput, s *sink) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = &events.RunError{Code: events.RunErrorCodeInternal, Message: fmt.Sprintf("agent panicked: %v", p)}
+		}
+	}()
+	return run(ctx, input, s)
+}
+
+// runErrorCodes maps errors returned by agents to well-known RUN_ERROR codes
+var runErrorCodes = []struct {
+	err  error
+	code string
+}{
+	{context.Canceled, events.RunErrorCodeCancelled},
+	{context.DeadlineExceeded, events.RunErrorCodeTimeout},
+	{events.ErrRunCancelled, events.RunErrorCodeCancelled},
+	{events.ErrRunTimeout, events.RunErrorCodeTimeout},
+	{events.ErrRunRateLimited, events.RunErrorCodeRateLimited},
+	{events.ErrRunInvalidInput, events.RunErrorCodeInvalidInput},
+	{events.ErrRunInternal, events.RunErrorCodeInternal},
+}
+
+// runErrorEvent converts an agent error into a RUN_ERROR event. A *RunError
+// keeps its code and details; other errors get the code of the well-known
+// sentinel they match, if any.
+func runErrorEvent(runID string, err error) *events.RunErrorEvent {
+	var runErr *events.RunError
+	if errors.As(err, &runErr) {
+		options := []events.RunErrorOption{events.WithRunID(runID), events.WithErrorDetails(runErr.Details)}
+		if runErr.Code != "" {
+			options = append(options, events.WithErrorCode(runErr.Code))
+		}
+		return events.NewRunErrorEvent(runErr.Message, options...)
+	}
+
+	options := []events.RunErrorOption{events.WithRunID(runID)}
+	for _, known := range runErrorCodes {
+		if errors.Is(err, known.err) {
+			options = append(options, events.WithErrorCode(known.code))
+			break
+		}
+	}
+	return events.NewRunErrorEvent(err.Error(), options...)
+}
+
+// sink implements EventSink on top of a StreamWriter
+type sink struct {
+	writer *sse.StreamWriter
+
+	mu        sync.Mutex
+	validator *events.SequenceValidator
+	res       any
+}
+
+// Emit implements EventSink
+func (s *sink) Emit(event events.Event) error {
+	if event == nil {
+		return fmt.Errorf("event cannot be nil")
+	}
+	switch event.Type() {
+	case events.EventTypeRunStarted, events.EventTypeRunFinished, events.EventTypeRunError:
+		return fmt.Errorf("%w: %s", ErrLifecycleEvent, event.Type())
+	}
+	return s.write(event)
+}
+
+// write checks event against the sequence, if validated, and writes it. The
+// lock keeps the validator in step with the order of the stream.
+func (s *sink) write(event events.Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.validator != nil {
+		if err := s.validator.Check(event); err != nil {
+			return err
+		}
+	}
+	return s.writer.WriteEvent(event)
+}
+
+// end writes a RUN_ERROR, which ends the run whatever state it is in
+func (s *sink) end(event *events.RunErrorEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.writer.WriteEvent(event)
+}
+
+// TextStart implements EventSink
+func (s *sink) TextStart(messageID string) error {
+	return s.Emit(events.NewTextMessageStartEvent(messageID, events.WithRole("assistant")))
+}
+
+// TextContent implements EventSink
+func (s *sink) TextContent(messageID, delta string) error {
+	return s.Emit(events.NewTextMessageContentEvent(messageID, delta))
+}
+
+// TextEnd implements EventSink
+func (s *sink) TextEnd(messageID string) error {
+	return s.Emit(events.NewTextMessageEndEvent(messageID))
+}
+
+// ToolCallStart implements EventSink
+func (s *sink) ToolCallStart(toolCallID, name, parentMessageID string) error {
+	var options []events.ToolCallStartOption
+	if parentMessageID != "" {
+		options = append(options, events.WithParentMessageID(parentMessageID))
+	}
+	return s.Emit(events.NewToolCallStartEvent(toolCallID, name, options...))
+}
+
+// ToolCallArgs implements EventSink
+func (s *sink) ToolCallArgs(toolCallID, delta string) error {
+	return s.Emit(events.NewToolCallArgsEvent(toolCallID, delta))
+}
+
+// ToolCallEnd implements EventSink
+func (s *sink) ToolCallEnd(toolCallID string) error {
+	return s.Emit(events.NewToolCallEndEvent(toolCallID))
+}
+
+// ToolCallResult implements EventSink
+func (s *sink) ToolCallResult(messageID, toolCallID, content string) error {
+	return s.Emit(events.NewToolCallResultEvent(messageID, toolCallID, content))
+}
+
+// StepStarted implements EventSink
+func (s *sink) StepStarted(name string) error {
+	return s.Emit(events.NewStepStartedEvent(name))
+}
+
+// StepFinished implements EventSink
+func (s *sink) StepFinished(name string) error {
+	return s.Emit(events.NewStepFinishedEvent(name))
+}
+
+// StateSnapshot implements EventSink
+func (s *sink) StateSnapshot(state any) error {
+	return s.Emit(events.NewStateSnapshotEvent(state))
+}
+
+// StateDelta implements EventSink
+func (s *sink) StateDelta(ops []events.JSONPatchOperation) error {
+	return s.Emit(events.NewStateDeltaEvent(ops))
+}
+
+// Custom implements EventSink
+func (s *sink) Custom(name string, value any) error {
+	return s.Emit(events.NewCustomEvent(name, events.WithValue(value)))
+}
+
+// SetResult implements EventSink
+func (s *sink) SetResult(result any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.res = result
+}
+
+// result returns the result set by the agent
+func (s *sink) result() any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.res
+}
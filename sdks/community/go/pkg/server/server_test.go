@@ -0,0 +1,192 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	clientsse "github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/client/sse"
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runInput returns a minimal valid run input
+func runInput() types.RunAgentInput {
+	return types.RunAgentInput{
+		ThreadID:       "thread-1",
+		RunID:          "run-1",
+		State:          map[string]any{},
+		Messages:       []types.Message{},
+		Tools:          []types.Tool{},
+		Context:        []types.Context{},
+		ForwardedProps: map[string]any{},
+	}
+}
+
+// run serves agent and runs it once, returning the streamed event types and
+// the run's outcome
+func run(t *testing.T, agent RunFunc, options ...HandlerOption) ([]events.EventType, *events.RunFinishedEvent, error) {
+	server := httptest.NewServer(Handler(agent, options...))
+	defer server.Close()
+
+	var received []events.EventType
+	client := clientsse.NewClient(clientsse.Config{Endpoint: server.URL})
+	finished, err := client.RunAgentResult(context.Background(), runInput(), func(event events.Event) {
+		received = append(received, event.Type())
+	})
+	return received, finished, err
+}
+
+func TestHandler(t *testing.T) {
+	received, finished, err := run(t, func(ctx context.Context, input types.RunAgentInput, sink EventSink) error {
+		assert.Equal(t, "run-1", input.RunID)
+		require.NoError(t, sink.StepStarted("answer"))
+		require.NoError(t, sink.TextStart("msg-1"))
+		require.NoError(t, sink.TextContent("msg-1", "hello"))
+		require.NoError(t, sink.TextEnd("msg-1"))
+		require.NoError(t, sink.ToolCallStart("call-1", "search", "msg-1"))
+		require.NoError(t, sink.ToolCallArgs("call-1", `{"q":"go"}`))
+		require.NoError(t, sink.ToolCallEnd("call-1"))
+		require.NoError(t, sink.ToolCallResult("msg-2", "call-1", "found"))
+		require.NoError(t, sink.StateSnapshot(map[string]any{"count": 1}))
+		require.NoError(t, sink.StateDelta([]events.JSONPatchOperation{{Op: "replace", Path: "/count", Value: 2}}))
+		require.NoError(t, sink.Custom("app.ping", nil))
+		require.NoError(t, sink.StepFinished("answer"))
+
+		err := sink.Emit(events.NewRunFinishedEvent("thread-1", "run-1"))
+		assert.True(t, errors.Is(err, ErrLifecycleEvent))
+
+		sink.SetResult(map[string]any{"answer": 42})
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []events.EventType{
+		events.EventTypeRunStarted,
+		events.EventTypeStepStarted,
+		events.EventTypeTextMessageStart,
+		events.EventTypeTextMessageContent,
+		events.EventTypeTextMessageEnd,
+		events.EventTypeToolCallStart,
+		events.EventTypeToolCallArgs,
+		events.EventTypeToolCallEnd,
+		events.EventTypeToolCallResult,
+		events.EventTypeStateSnapshot,
+		events.EventTypeStateDelta,
+		events.EventTypeCustom,
+		events.EventTypeStepFinished,
+	}, received)
+
+	var result struct {
+		Answer int `json:"answer"`
+	}
+	require.NoError(t, finished.Into(&result))
+	assert.Equal(t, 42, result.Answer)
+}
+
+func TestHandler_Errors(t *testing.T) {
+	cases := []struct {
+		name  string
+		agent RunFunc
+		code  string
+		match error
+	}{
+		{"PlainError", func(context.Context, types.RunAgentInput, EventSink) error {
+			return errors.New("model unavailable")
+		}, "", nil},
+		{"Sentinel", func(context.Context, types.RunAgentInput, EventSink) error {
+			return errors.Join(events.ErrRunRateLimited, errors.New("slow down"))
+		}, events.RunErrorCodeRateLimited, events.ErrRunRateLimited},
+		{"RunError", func(context.Context, types.RunAgentInput, EventSink) error {
+			return &events.RunError{Code: "QUOTA", Message: "quota exceeded"}
+		}, "QUOTA", nil},
+		{"Panic", func(context.Context, types.RunAgentInput, EventSink) error {
+			panic("boom")
+		}, events.RunErrorCodeInternal, events.ErrRunInternal},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			received, finished, err := run(t, tc.agent)
+			assert.Nil(t, finished)
+			assert.Equal(t, []events.EventType{events.EventTypeRunStarted}, received)
+
+			var runErr *events.RunError
+			require.True(t, errors.As(err, &runErr), "unexpected error: %v", err)
+			assert.Equal(t, tc.code, runErr.Code)
+			assert.Equal(t, "run-1", runErr.RunID)
+			if tc.match != nil {
+				assert.True(t, errors.Is(err, tc.match))
+			}
+		})
+	}
+}
+
+func TestHandler_InvalidRequests(t *testing.T) {
+	handler := Handler(func(context.Context, types.RunAgentInput, EventSink) error {
+		t.Error("agent must not run")
+		return nil
+	})
+
+	cases := []struct {
+		name   string
+		method string
+		body   string
+		status int
+	}{
+		{"Method", http.MethodGet, "", http.StatusMethodNotAllowed},
+		{"MalformedJSON", http.MethodPost, "{", http.StatusBadRequest},
+		{"InvalidInput", http.MethodPost, `{"threadId":"t"}`, http.StatusBadRequest},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(tc.method, "/", strings.NewReader(tc.body)))
+			assert.Equal(t, tc.status, rec.Code)
+		})
+	}
+}
+
+func TestHandler_SequenceValidation(t *testing.T) {
+	received, _, err := run(t, func(ctx context.Context, input types.RunAgentInput, sink EventSink) error {
+		err := sink.TextContent("msg-1", "orphan")
+		var seqErr *events.SequenceError
+		require.True(t, errors.As(err, &seqErr), "unexpected error: %v", err)
+
+		// The rejected event was not written and the stream stays usable
+		require.NoError(t, sink.TextStart("msg-1"))
+		require.NoError(t, sink.TextContent("msg-1", "hi"))
+		require.NoError(t, sink.TextEnd("msg-1"))
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []events.EventType{
+		events.EventTypeRunStarted,
+		events.EventTypeTextMessageStart,
+		events.EventTypeTextMessageContent,
+		events.EventTypeTextMessageEnd,
+	}, received)
+
+	// Returning with an open message ends the run with an error
+	unfinished := func(ctx context.Context, input types.RunAgentInput, sink EventSink) error {
+		return sink.TextStart("msg-1")
+	}
+	_, finished, err := run(t, unfinished)
+	assert.Nil(t, finished)
+	var runErr *events.RunError
+	require.True(t, errors.As(err, &runErr), "unexpected error: %v", err)
+	assert.Equal(t, events.RunErrorCodeInternal, runErr.Code)
+	assert.Contains(t, runErr.Message, "invalid stream")
+
+	// Without validation the events are written as they come
+	_, finished, err = run(t, func(ctx context.Context, input types.RunAgentInput, sink EventSink) error {
+		return sink.TextContent("msg-1", "orphan")
+	}, WithoutSequenceValidation())
+	require.NoError(t, err)
+	assert.NotNil(t, finished)
+}
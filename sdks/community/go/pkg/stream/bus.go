@@ -0,0 +1,181 @@
+// Package stream provides building blocks for fanning out and processing
+// streams of AG-UI events.
+package stream
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+)
+
+// DefaultBufferSize is the per-subscriber buffer used when none is configured
+const DefaultBufferSize = 64
+
+// OverflowPolicy controls what Publish does when a subscriber's buffer is full
+type OverflowPolicy int
+
+const (
+	// OverflowDrop discards the event for the slow subscriber and counts it
+	OverflowDrop OverflowPolicy = iota
+	// OverflowBlock waits until the slow subscriber has room or unsubscribes
+	OverflowBlock
+)
+
+// EventBus delivers every published event to all current subscribers.
+// Each subscriber has its own buffered channel, so a slow consumer only
+// affects the others when the bus is configured with OverflowBlock.
+// It is safe for concurrent use.
+type EventBus struct {
+	mu          sync.RWMutex
+	subscribers map[uint64]*subscriber
+	nextID      uint64
+	closed      bool
+	// closing is closed by Close before it takes the lock, releasing any
+	// Publish blocked on a subscriber
+	closing   chan struct{}
+	closeOnce sync.Once
+
+	bufferSize int
+	policy     OverflowPolicy
+	dropped    atomic.Uint64
+}
+
+// subscriber is a single consumer registered with the bus
+type subscriber struct {
+	ch   chan events.Event
+	done chan struct{}
+	once sync.Once
+}
+
+// EventBusOption defines options for creating event buses
+type EventBusOption func(*EventBus)
+
+// WithBufferSize sets the per-subscriber buffer size
+func WithBufferSize(size int) EventBusOption {
+	return func(b *EventBus) {
+		if size >= 0 {
+			b.bufferSize = size
+		}
+	}
+}
+
+// WithOverflowPolicy sets the behavior when a subscriber falls behind
+func WithOverflowPolicy(policy OverflowPolicy) EventBusOption {
+	return func(b *EventBus) {
+		b.policy = policy
+	}
+}
+
+// NewEventBus creates a new event bus
+func NewEventBus(options ...EventBusOption) *EventBus {
+	bus := &EventBus{
+		subscribers: make(map[uint64]*subscriber),
+		closing:     make(chan struct{}),
+		bufferSize:  DefaultBufferSize,
+		policy:      OverflowDrop,
+	}
+
+	for _, opt := range options {
+		opt(bus)
+	}
+
+	return bus
+}
+
+// Subscribe registers a new consumer. The returned cancel function
+// unsubscribes and closes the channel; it is safe to call more than once.
+// Subscribing to a closed bus returns an already closed channel.
+func (b *EventBus) Subscribe() (<-chan events.Event, func()) {
+	sub := &subscriber{
+		ch:   make(chan events.Event, b.bufferSize),
+		done: make(chan struct{}),
+	}
+
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		close(sub.ch)
+		return sub.ch, func() {}
+	}
+	id := b.nextID
+	b.nextID++
+	b.subscribers[id] = sub
+	b.mu.Unlock()
+
+	return sub.ch, func() { b.unsubscribe(id, sub) }
+}
+
+// Publish delivers the event to every subscriber according to the overflow
+// policy. Publishing to a closed bus is a no-op.
+func (b *EventBus) Publish(event events.Event) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.closed {
+		return
+	}
+
+	for _, sub := range b.subscribers {
+		if b.policy == OverflowBlock {
+			select {
+			case sub.ch <- event:
+			case <-sub.done:
+			case <-b.closing:
+			}
+			continue
+		}
+
+		select {
+		case sub.ch <- event:
+		default:
+			b.dropped.Add(1)
+		}
+	}
+}
+
+// Dropped returns the number of events discarded across all subscribers
+func (b *EventBus) Dropped() uint64 {
+	return b.dropped.Load()
+}
+
+// Subscribers returns the number of active subscribers
+func (b *EventBus) Subscribers() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.subscribers)
+}
+
+// Close unsubscribes every consumer and closes their channels. Publish calls
+// blocked on a slow subscriber return without delivering.
+func (b *EventBus) Close() {
+	b.closeOnce.Do(func() { close(b.closing) })
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return
+	}
+	b.closed = true
+
+	for id, sub := range b.subscribers {
+		sub.once.Do(func() { close(sub.done) })
+		delete(b.subscribers, id)
+		close(sub.ch)
+	}
+}
+
+// unsubscribe removes a subscriber. Signalling done before taking the write
+// lock releases any Publish blocked on this subscriber.
+func (b *EventBus) unsubscribe(id uint64, sub *subscriber) {
+	sub.once.Do(func() { close(sub.done) })
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.subscribers[id]; ok {
+		delete(b.subscribers, id)
+		close(sub.ch)
+	}
+}
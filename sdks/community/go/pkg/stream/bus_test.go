@@ -0,0 +1,140 @@
+package stream
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventBus_FanOut(t *testing.T) {
+	bus := NewEventBus()
+	first, cancelFirst := bus.Subscribe()
+	second, cancelSecond := bus.Subscribe()
+	defer cancelSecond()
+	assert.Equal(t, 2, bus.Subscribers())
+
+	bus.Publish(events.NewRunStartedEvent("thread-1", "run-1"))
+	bus.Publish(events.NewRunFinishedEvent("thread-1", "run-1"))
+
+	for _, ch := range []<-chan events.Event{first, second} {
+		assert.Equal(t, events.EventTypeRunStarted, (<-ch).Type())
+		assert.Equal(t, events.EventTypeRunFinished, (<-ch).Type())
+	}
+
+	cancelFirst()
+	cancelFirst()
+	_, ok := <-first
+	assert.False(t, ok)
+	assert.Equal(t, 1, bus.Subscribers())
+}
+
+func TestEventBus_DropsForSlowSubscriber(t *testing.T) {
+	bus := NewEventBus(WithBufferSize(1))
+	slow, cancel := bus.Subscribe()
+	defer cancel()
+
+	for i := 0; i < 3; i++ {
+		bus.Publish(events.NewTextMessageContentEvent("msg-1", "x"))
+	}
+
+	assert.Equal(t, uint64(2), bus.Dropped())
+	assert.Len(t, slow, 1)
+}
+
+func TestEventBus_BlockPolicy(t *testing.T) {
+	bus := NewEventBus(WithBufferSize(0), WithOverflowPolicy(OverflowBlock))
+	ch, cancel := bus.Subscribe()
+
+	published := make(chan struct{})
+	go func() {
+		bus.Publish(events.NewTextMessageContentEvent("msg-1", "a"))
+		bus.Publish(events.NewTextMessageContentEvent("msg-1", "b"))
+		close(published)
+	}()
+
+	event := <-ch
+	assert.Equal(t, "a", event.(*events.TextMessageContentEvent).Delta)
+
+	// Unsubscribing releases a Publish blocked on this subscriber
+	cancel()
+	select {
+	case <-published:
+	case <-time.After(time.Second):
+		t.Fatal("publish stayed blocked after unsubscribe")
+	}
+	assert.Equal(t, uint64(0), bus.Dropped())
+}
+
+func TestEventBus_CloseReleasesBlockedPublish(t *testing.T) {
+	bus := NewEventBus(WithBufferSize(0), WithOverflowPolicy(OverflowBlock))
+	ch, cancel := bus.Subscribe()
+	defer cancel()
+
+	published := make(chan struct{})
+	go func() {
+		bus.Publish(events.NewTextMessageContentEvent("msg-1", "a"))
+		close(published)
+	}()
+	// Let Publish block on the subscriber that never reads
+	time.Sleep(20 * time.Millisecond)
+
+	closed := make(chan struct{})
+	go func() {
+		bus.Close()
+		close(closed)
+	}()
+	for _, done := range []chan struct{}{published, closed} {
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("close stayed blocked behind a slow subscriber")
+		}
+	}
+
+	_, ok := <-ch
+	assert.False(t, ok)
+}
+
+func TestEventBus_Close(t *testing.T) {
+	bus := NewEventBus()
+	ch, cancel := bus.Subscribe()
+
+	bus.Close()
+	_, ok := <-ch
+	assert.False(t, ok)
+	cancel()
+
+	bus.Publish(events.NewRunStartedEvent("thread-1", "run-1"))
+	late, _ := bus.Subscribe()
+	_, ok = <-late
+	assert.False(t, ok)
+}
+
+func TestEventBus_ConcurrentPublishAndCancel(t *testing.T) {
+	bus := NewEventBus(WithBufferSize(4))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ch, cancel := bus.Subscribe()
+			for j := 0; j < 10; j++ {
+				select {
+				case <-ch:
+				default:
+				}
+			}
+			cancel()
+		}()
+	}
+	for i := 0; i < 100; i++ {
+		bus.Publish(events.NewTextMessageContentEvent("msg-1", "x"))
+	}
+	wg.Wait()
+	require.Equal(t, 0, bus.Subscribers())
+}
@@ -0,0 +1,109 @@
+package stream
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+)
+
+// Coalesce merges consecutive TEXT_MESSAGE_CONTENT events for the same message
+// into a single event whose delta is their concatenation, so that consumers
+// receive fewer, larger updates when a model streams one token at a time. A
+// merged event is sent once window has passed since its first delta, or
+// earlier when any other event arrives, which keeps every other event
+// (including message start and end) in its original position. Merged events
+// keep the timestamp and event ID of their first delta and drop its raw
+// event. A window of zero or less disables merging.
+//
+// The output channel closes when in closes, after sending any pending delta,
+// or when ctx is cancelled.
+func Coalesce(ctx context.Context, in <-chan events.Event, window time.Duration) <-chan events.Event {
+	if window <= 0 {
+		return Filter(ctx, in, func(events.Event) bool { return true })
+	}
+
+	out := make(chan events.Event)
+
+	go func() {
+		defer close(out)
+
+		var (
+			pending *events.TextMessageContentEvent
+			delta   strings.Builder
+			merged  int
+		)
+		timer := time.NewTimer(window)
+		timer.Stop()
+		defer timer.Stop()
+
+		send := func(event events.Event) bool {
+			select {
+			case out <- event:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+		// flush sends the pending delta, if any
+		flush := func() bool {
+			if pending == nil {
+				return true
+			}
+			timer.Stop()
+			event := pending
+			if merged > 1 {
+				base := *pending.BaseEvent
+				base.RawEvent = nil
+				event = &events.TextMessageContentEvent{BaseEvent: &base, MessageID: pending.MessageID, Delta: delta.String()}
+			}
+			pending = nil
+			delta.Reset()
+			merged = 0
+			return send(event)
+		}
+
+		for {
+			var expired <-chan time.Time
+			if pending != nil {
+				expired = timer.C
+			}
+
+			select {
+			case event, ok := <-in:
+				if !ok {
+					flush()
+					return
+				}
+				content, isContent := event.(*events.TextMessageContentEvent)
+				if isContent && pending != nil && content.MessageID == pending.MessageID {
+					delta.WriteString(content.Delta)
+					merged++
+					continue
+				}
+				if !flush() {
+					return
+				}
+				if isContent && content.BaseEvent != nil {
+					pending = content
+					delta.WriteString(content.Delta)
+					merged = 1
+					timer.Reset(window)
+					continue
+				}
+				if !send(event) {
+					return
+				}
+			case <-expired:
+				if !flush() {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out
+}
@@ -0,0 +1,110 @@
+package stream
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// describe renders events compactly, showing the deltas of content events
+func describe(ch <-chan events.Event) []string {
+	var out []string
+	for event := range ch {
+		if content, ok := event.(*events.TextMessageContentEvent); ok {
+			out = append(out, content.MessageID+":"+content.Delta)
+			continue
+		}
+		out = append(out, string(event.Type()))
+	}
+	return out
+}
+
+func TestCoalesce(t *testing.T) {
+	first := events.NewTextMessageContentEvent("msg-1", "Hel")
+	first.EventID = "42"
+	in := feed(
+		events.NewTextMessageStartEvent("msg-1"),
+		first,
+		events.NewTextMessageContentEvent("msg-1", "lo"),
+		events.NewTextMessageContentEvent("msg-1", ", world"),
+		events.NewTextMessageContentEvent("msg-2", "other"),
+		events.NewTextMessageContentEvent("msg-1", "!"),
+		events.NewTextMessageEndEvent("msg-1"),
+		events.NewTextMessageContentEvent("msg-2", " trailing"),
+	)
+
+	out := Coalesce(context.Background(), in, time.Minute)
+	var got []events.Event
+	for event := range out {
+		got = append(got, event)
+	}
+
+	require.Len(t, got, 6)
+	merged, ok := got[1].(*events.TextMessageContentEvent)
+	require.True(t, ok)
+	assert.Equal(t, "Hello, world", merged.Delta)
+	assert.Equal(t, "42", merged.EventID)
+	assert.Equal(t, first.Timestamp(), merged.Timestamp())
+	assert.Equal(t, "Hel", first.Delta, "input events are not modified")
+
+	assert.Equal(t, []string{
+		"TEXT_MESSAGE_START", "msg-1:Hello, world", "msg-2:other", "msg-1:!", "TEXT_MESSAGE_END", "msg-2: trailing",
+	}, describe(feed(got...)))
+}
+
+func TestCoalesce_Window(t *testing.T) {
+	in := make(chan events.Event)
+	out := Coalesce(context.Background(), in, 20*time.Millisecond)
+
+	go func() {
+		defer close(in)
+		in <- events.NewTextMessageContentEvent("msg-1", "a")
+		in <- events.NewTextMessageContentEvent("msg-1", "b")
+		time.Sleep(100 * time.Millisecond)
+		in <- events.NewTextMessageContentEvent("msg-1", "c")
+	}()
+
+	// The first batch is sent when the window expires, before the input moves on
+	select {
+	case event := <-out:
+		assert.Equal(t, "ab", event.(*events.TextMessageContentEvent).Delta)
+	case <-time.After(80 * time.Millisecond):
+		t.Fatal("pending delta was not flushed after the window")
+	}
+	assert.Equal(t, []string{"msg-1:c"}, describe(out))
+
+	// A zero window passes events through unchanged
+	out = Coalesce(context.Background(), feed(
+		events.NewTextMessageContentEvent("msg-1", "a"),
+		events.NewTextMessageContentEvent("msg-1", "b"),
+	), 0)
+	assert.Equal(t, []string{"msg-1:a", "msg-1:b"}, describe(out))
+}
+
+func TestCoalesce_CancelReleasesGoroutine(t *testing.T) {
+	testutil.VerifyNoGoroutineLeaks(t)
+
+	in := make(chan events.Event)
+	ctx, cancel := context.WithCancel(context.Background())
+	out := Coalesce(ctx, in, time.Millisecond)
+
+	// The consumer stops reading while a merged delta is pending
+	in <- events.NewTextMessageContentEvent("msg-1", "a")
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+
+	select {
+	case _, ok := <-out:
+		if ok {
+			_, ok = <-out
+		}
+		assert.False(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("output channel was not closed after cancellation")
+	}
+}
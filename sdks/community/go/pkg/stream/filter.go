@@ -0,0 +1,64 @@
+package stream
+
+import (
+	"context"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+)
+
+// Predicate reports whether an event should be kept
+type Predicate func(events.Event) bool
+
+// Filter forwards the events from in for which keep returns true. The output
+// channel closes when in closes or ctx is cancelled; cancelling ctx is how a
+// consumer that stops reading early releases the filtering goroutine.
+func Filter(ctx context.Context, in <-chan events.Event, keep Predicate) <-chan events.Event {
+	out := make(chan events.Event)
+
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case event, ok := <-in:
+				if !ok {
+					return
+				}
+				if !keep(event) {
+					continue
+				}
+				select {
+				case out <- event:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out
+}
+
+// OnlyTypes returns a predicate that keeps events of the given types
+func OnlyTypes(types ...events.EventType) Predicate {
+	set := make(map[events.EventType]struct{}, len(types))
+	for _, eventType := range types {
+		set[eventType] = struct{}{}
+	}
+	return func(event events.Event) bool {
+		if event == nil {
+			return false
+		}
+		_, ok := set[event.Type()]
+		return ok
+	}
+}
+
+// ExceptTypes returns a predicate that drops events of the given types
+func ExceptTypes(types ...events.EventType) Predicate {
+	only := OnlyTypes(types...)
+	return func(event events.Event) bool {
+		return event != nil && !only(event)
+	}
+}
@@ -0,0 +1,81 @@
+package stream
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+// feed returns a closed channel holding the given events
+func feed(evts ...events.Event) <-chan events.Event {
+	ch := make(chan events.Event, len(evts))
+	for _, event := range evts {
+		ch <- event
+	}
+	close(ch)
+	return ch
+}
+
+// drain collects events until ch closes
+func drain(ch <-chan events.Event) []events.EventType {
+	var types []events.EventType
+	for event := range ch {
+		types = append(types, event.Type())
+	}
+	return types
+}
+
+func TestFilter(t *testing.T) {
+	in := feed(
+		events.NewRunStartedEvent("thread-1", "run-1"),
+		events.NewTextMessageStartEvent("msg-1"),
+		events.NewStateDeltaEvent([]events.JSONPatchOperation{{Op: "add", Path: "/a", Value: 1}}),
+		events.NewTextMessageContentEvent("msg-1", "hi"),
+		events.NewTextMessageEndEvent("msg-1"),
+		events.NewRunFinishedEvent("thread-1", "run-1"),
+	)
+
+	out := Filter(context.Background(), in, OnlyTypes(
+		events.EventTypeTextMessageStart,
+		events.EventTypeTextMessageContent,
+		events.EventTypeTextMessageEnd,
+	))
+	assert.Equal(t, []events.EventType{
+		events.EventTypeTextMessageStart,
+		events.EventTypeTextMessageContent,
+		events.EventTypeTextMessageEnd,
+	}, drain(out))
+
+	out = Filter(context.Background(), feed(
+		events.NewRunStartedEvent("thread-1", "run-1"),
+		events.NewStateSnapshotEvent(map[string]any{}),
+	), ExceptTypes(events.EventTypeStateSnapshot))
+	assert.Equal(t, []events.EventType{events.EventTypeRunStarted}, drain(out))
+}
+
+func TestFilter_CancelReleasesGoroutine(t *testing.T) {
+	testutil.VerifyNoGoroutineLeaks(t)
+
+	in := make(chan events.Event)
+	ctx, cancel := context.WithCancel(context.Background())
+	out := Filter(ctx, in, OnlyTypes(events.EventTypeRunStarted))
+
+	// The consumer stops reading while an event is pending
+	go func() { in <- events.NewRunStartedEvent("thread-1", "run-1") }()
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+
+	select {
+	case _, ok := <-out:
+		if ok {
+			_, ok = <-out
+		}
+		assert.False(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("output channel was not closed after cancellation")
+	}
+}
@@ -0,0 +1,81 @@
+package stream
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+)
+
+// TaggedEvent is an event paired with the index of the stream it came from
+type TaggedEvent struct {
+	Source int
+	Event  events.Event
+}
+
+// Merge interleaves the events of several streams into one. Events from the
+// same stream keep their order, and no stream can starve the others. The
+// output channel closes once every input has closed, or when ctx is
+// cancelled. After cancellation the remaining events of each input are
+// drained and discarded until it closes, so producers never block on a
+// merged stream nobody reads.
+func Merge(ctx context.Context, streams ...<-chan events.Event) <-chan events.Event {
+	return fanIn(ctx, streams, func(_ int, event events.Event) events.Event {
+		return event
+	})
+}
+
+// MergeTagged is like Merge but wraps each event with the index of its
+// source stream, so that consumers can route events back to their origin
+func MergeTagged(ctx context.Context, streams ...<-chan events.Event) <-chan TaggedEvent {
+	return fanIn(ctx, streams, func(source int, event events.Event) TaggedEvent {
+		return TaggedEvent{Source: source, Event: event}
+	})
+}
+
+// fanIn starts one forwarding goroutine per input and closes the output
+// once all of them have stopped forwarding
+func fanIn[T any](ctx context.Context, streams []<-chan events.Event, wrap func(int, events.Event) T) <-chan T {
+	out := make(chan T)
+
+	var wg sync.WaitGroup
+	wg.Add(len(streams))
+	for i, in := range streams {
+		go func(source int, in <-chan events.Event) {
+			closed := forward(ctx, in, out, source, wrap)
+			wg.Done()
+			if !closed {
+				// Cancelled: keep draining so the producer does not block
+				for range in {
+				}
+			}
+		}(i, in)
+	}
+
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	return out
+}
+
+// forward sends the events of in to out until in closes, returning true, or
+// ctx is cancelled, returning false
+func forward[T any](ctx context.Context, in <-chan events.Event, out chan<- T, source int, wrap func(int, events.Event) T) bool {
+	for {
+		select {
+		case event, ok := <-in:
+			if !ok {
+				return true
+			}
+			select {
+			case out <- wrap(source, event):
+			case <-ctx.Done():
+				return false
+			}
+		case <-ctx.Done():
+			return false
+		}
+	}
+}
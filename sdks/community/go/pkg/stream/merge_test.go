@@ -0,0 +1,77 @@
+package stream
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMerge_PreservesPerStreamOrder(t *testing.T) {
+	first := feed(
+		events.NewTextMessageStartEvent("a"),
+		events.NewTextMessageContentEvent("a", "1"),
+		events.NewTextMessageEndEvent("a"),
+	)
+	second := feed(
+		events.NewTextMessageStartEvent("b"),
+		events.NewTextMessageContentEvent("b", "1"),
+		events.NewTextMessageEndEvent("b"),
+	)
+
+	perSource := map[int][]events.EventType{}
+	for tagged := range MergeTagged(context.Background(), first, second) {
+		perSource[tagged.Source] = append(perSource[tagged.Source], tagged.Event.Type())
+		if start, ok := tagged.Event.(*events.TextMessageStartEvent); ok {
+			assert.Equal(t, []string{"a", "b"}[tagged.Source], start.MessageID)
+		}
+	}
+
+	expected := []events.EventType{
+		events.EventTypeTextMessageStart,
+		events.EventTypeTextMessageContent,
+		events.EventTypeTextMessageEnd,
+	}
+	assert.Equal(t, expected, perSource[0])
+	assert.Equal(t, expected, perSource[1])
+
+	assert.Len(t, drain(Merge(context.Background(), feed(events.NewRunStartedEvent("t", "r")), feed())), 1)
+	assert.Empty(t, drain(Merge(context.Background())))
+}
+
+func TestMerge_CancelDrainsInputs(t *testing.T) {
+	testutil.VerifyNoGoroutineLeaks(t)
+
+	idle := make(chan events.Event)
+	busy := make(chan events.Event)
+	ctx, cancel := context.WithCancel(context.Background())
+	out := Merge(ctx, idle, busy)
+
+	cancel()
+	select {
+	case _, ok := <-out:
+		require.False(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("output channel was not closed after cancellation")
+	}
+
+	// Producers are not blocked by the abandoned merge
+	sent := make(chan struct{})
+	go func() {
+		for i := 0; i < 10; i++ {
+			busy <- events.NewStepStartedEvent("step")
+		}
+		close(busy)
+		close(sent)
+	}()
+	select {
+	case <-sent:
+	case <-time.After(time.Second):
+		t.Fatal("producer blocked after cancellation")
+	}
+	close(idle)
+}
@@ -0,0 +1,175 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/jsonpointer"
+)
+
+// RedactedValue replaces every value matched by a RedactRule
+const RedactedValue = "***"
+
+// RedactRule selects a value to redact by its JSON Pointer in the event's
+// JSON encoding, such as "/delta" or "/messages/*/toolCalls/*/function/arguments".
+// A "*" token matches every member of an object or element of an array.
+// When EventType is set the rule only applies to events of that type.
+type RedactRule struct {
+	EventType events.EventType
+	Path      string
+}
+
+// Redact forwards the events from in with every value matched by rules
+// replaced by RedactedValue. Matching events are copied before they are
+// changed, so the events received from in are never modified. An event that
+// cannot be copied is dropped rather than forwarded unredacted; see
+// RedactEvent for events that no longer decode once redacted. Redact panics
+// if a rule's path is not a valid JSON Pointer. The output channel closes when
+// in closes or ctx is cancelled.
+func Redact(ctx context.Context, in <-chan events.Event, rules []RedactRule) <-chan events.Event {
+	compiled, err := compileRedactRules(rules)
+	if err != nil {
+		panic(err)
+	}
+
+	out := make(chan events.Event)
+
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case event, ok := <-in:
+				if !ok {
+					return
+				}
+				redacted, err := redactEvent(event, compiled)
+				if err != nil {
+					continue
+				}
+				select {
+				case out <- redacted:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out
+}
+
+// RedactEvent returns a copy of event with every value matched by rules
+// replaced by RedactedValue, or event itself when no rule matches. A rule
+// that replaces an object or array, such as "/messages/*/toolCalls", can
+// leave JSON that no longer decodes as the event's type; the copy is then an
+// *events.UnknownEvent carrying the redacted JSON.
+func RedactEvent(event events.Event, rules []RedactRule) (events.Event, error) {
+	compiled, err := compileRedactRules(rules)
+	if err != nil {
+		return nil, err
+	}
+	return redactEvent(event, compiled)
+}
+
+// redactRule is a RedactRule with its path split into tokens
+type redactRule struct {
+	eventType events.EventType
+	tokens    []string
+}
+
+// compileRedactRules parses the path of every rule
+func compileRedactRules(rules []RedactRule) ([]redactRule, error) {
+	compiled := make([]redactRule, len(rules))
+	for i, rule := range rules {
+		tokens, err := jsonpointer.Parse(rule.Path)
+		if err != nil {
+			return nil, fmt.Errorf("redact rule %d: %w", i, err)
+		}
+		if len(tokens) == 0 {
+			return nil, fmt.Errorf("redact rule %d: path must not refer to the whole event", i)
+		}
+		compiled[i] = redactRule{eventType: rule.EventType, tokens: tokens}
+	}
+	return compiled, nil
+}
+
+// redactEvent applies the rules to a copy of event, made by a JSON round trip
+func redactEvent(event events.Event, rules []redactRule) (events.Event, error) {
+	if event == nil {
+		return nil, nil
+	}
+
+	var applicable []redactRule
+	for _, rule := range rules {
+		if rule.eventType == "" || rule.eventType == event.Type() {
+			applicable = append(applicable, rule)
+		}
+	}
+	if len(applicable) == 0 {
+		return event, nil
+	}
+
+	data, err := event.ToJSON()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode event for redaction: %w", err)
+	}
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to decode event for redaction: %w", err)
+	}
+
+	changed := false
+	for _, rule := range applicable {
+		doc = redactValue(doc, rule.tokens, &changed)
+	}
+	if !changed {
+		return event, nil
+	}
+
+	if data, err = json.Marshal(doc); err != nil {
+		return nil, fmt.Errorf("failed to encode redacted event: %w", err)
+	}
+	redacted, err := events.EventFromJSON(data)
+	if err != nil {
+		redacted, err = events.NewUnknownEvent(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode redacted event: %w", err)
+	}
+	redacted.GetBaseEvent().EventID = event.GetBaseEvent().EventID
+	return redacted, nil
+}
+
+// redactValue replaces the values below doc matched by tokens and returns
+// the updated document
+func redactValue(doc any, tokens []string, changed *bool) any {
+	if len(tokens) == 0 {
+		*changed = true
+		return RedactedValue
+	}
+
+	token, rest := tokens[0], tokens[1:]
+	switch value := doc.(type) {
+	case map[string]any:
+		if token == "*" {
+			for key, member := range value {
+				value[key] = redactValue(member, rest, changed)
+			}
+		} else if member, ok := value[token]; ok {
+			value[token] = redactValue(member, rest, changed)
+		}
+	case []any:
+		if token == "*" {
+			for i, element := range value {
+				value[i] = redactValue(element, rest, changed)
+			}
+		} else if i, err := jsonpointer.ArrayIndex(token, len(value)); err == nil && i < len(value) {
+			value[i] = redactValue(value[i], rest, changed)
+		}
+	}
+	return doc
+}
@@ -0,0 +1,89 @@
+package stream
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedact(t *testing.T) {
+	args := events.NewToolCallArgsEvent("call-1", `{"apiKey":"sk-123"}`)
+	args.EventID = "evt-1"
+	snapshot := events.NewMessagesSnapshotEvent([]events.Message{{
+		ID:   "msg-1",
+		Role: "assistant",
+		ToolCalls: []events.ToolCall{
+			{ID: "call-1", Type: "function", Function: events.Function{Name: "a", Arguments: `{"apiKey":"sk-123"}`}},
+			{ID: "call-2", Type: "function", Function: events.Function{Name: "b", Arguments: `{"apiKey":"sk-456"}`}},
+		},
+	}})
+	content := events.NewTextMessageContentEvent("msg-1", "hello")
+
+	rules := []RedactRule{
+		{EventType: events.EventTypeToolCallArgs, Path: "/delta"},
+		{Path: "/messages/*/toolCalls/*/function/arguments"},
+		{Path: "/missing/field"},
+	}
+	var out []events.Event
+	for event := range Redact(context.Background(), feed(args, snapshot, content), rules) {
+		out = append(out, event)
+	}
+	require.Len(t, out, 3)
+
+	redactedArgs := out[0].(*events.ToolCallArgsEvent)
+	assert.Equal(t, RedactedValue, redactedArgs.Delta)
+	assert.Equal(t, "call-1", redactedArgs.ToolCallID)
+	assert.Equal(t, "evt-1", redactedArgs.EventID)
+
+	redactedSnapshot := out[1].(*events.MessagesSnapshotEvent)
+	for _, call := range redactedSnapshot.Messages[0].ToolCalls {
+		assert.Equal(t, RedactedValue, call.Function.Arguments)
+	}
+	assert.Equal(t, "a", redactedSnapshot.Messages[0].ToolCalls[0].Function.Name)
+
+	// Unmatched events pass through untouched
+	assert.Same(t, content, out[2])
+
+	// The original events are not modified
+	assert.Equal(t, `{"apiKey":"sk-123"}`, args.Delta)
+	assert.Equal(t, `{"apiKey":"sk-456"}`, snapshot.Messages[0].ToolCalls[1].Function.Arguments)
+}
+
+func TestRedact_NonStringValue(t *testing.T) {
+	snapshot := events.NewMessagesSnapshotEvent([]events.Message{{
+		ID:        "msg-1",
+		Role:      "assistant",
+		ToolCalls: []events.ToolCall{{ID: "call-1", Type: "function", Function: events.Function{Name: "a", Arguments: `{"apiKey":"sk-123"}`}}},
+	}})
+	snapshot.EventID = "evt-1"
+
+	// The redacted snapshot no longer decodes, so it is forwarded as raw JSON
+	var out []events.Event
+	for event := range Redact(context.Background(), feed(snapshot), []RedactRule{{Path: "/messages/*/toolCalls"}}) {
+		out = append(out, event)
+	}
+	require.Len(t, out, 1)
+	unknown, ok := out[0].(*events.UnknownEvent)
+	require.True(t, ok, "got %T", out[0])
+	assert.Equal(t, events.EventTypeMessagesSnapshot, unknown.Type())
+	assert.Equal(t, "evt-1", unknown.EventID)
+	assert.Contains(t, string(unknown.Data), `"toolCalls":"***"`)
+	assert.NotContains(t, string(unknown.Data), "sk-123")
+}
+
+func TestRedactEvent(t *testing.T) {
+	event := events.NewTextMessageContentEvent("msg-1", "secret")
+	redacted, err := RedactEvent(event, []RedactRule{{EventType: events.EventTypeRunStarted, Path: "/delta"}})
+	require.NoError(t, err)
+	assert.Same(t, event, redacted)
+
+	_, err = RedactEvent(event, []RedactRule{{Path: "delta"}})
+	assert.Error(t, err)
+	_, err = RedactEvent(event, []RedactRule{{Path: ""}})
+	assert.Error(t, err)
+
+	assert.Panics(t, func() { Redact(context.Background(), feed(), []RedactRule{{Path: "delta"}}) })
+}
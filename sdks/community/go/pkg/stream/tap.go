@@ -0,0 +1,27 @@
+package stream
+
+import (
+	"context"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+)
+
+// Tap forwards every event from in unchanged, calling observe with each event
+// before it is forwarded. observe runs on the forwarding goroutine, so a slow
+// callback slows the stream down. A panic in observe is recovered and the
+// event is still forwarded, so a faulty probe cannot break the pipeline. The
+// output channel closes when in closes or ctx is cancelled.
+func Tap(ctx context.Context, in <-chan events.Event, observe func(events.Event)) <-chan events.Event {
+	return Filter(ctx, in, func(event events.Event) bool {
+		safeObserve(observe, event)
+		return true
+	})
+}
+
+// safeObserve calls observe, recovering from any panic
+func safeObserve(observe func(events.Event), event events.Event) {
+	defer func() {
+		_ = recover()
+	}()
+	observe(event)
+}
@@ -0,0 +1,33 @@
+package stream
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTap(t *testing.T) {
+	in := feed(
+		events.NewRunStartedEvent("thread-1", "run-1"),
+		events.NewTextMessageContentEvent("msg-1", "hi"),
+		events.NewRunFinishedEvent("thread-1", "run-1"),
+	)
+
+	var observed []events.EventType
+	out := Tap(context.Background(), in, func(event events.Event) {
+		observed = append(observed, event.Type())
+		if event.Type() == events.EventTypeTextMessageContent {
+			panic("probe failure")
+		}
+	})
+
+	expected := []events.EventType{
+		events.EventTypeRunStarted,
+		events.EventTypeTextMessageContent,
+		events.EventTypeRunFinished,
+	}
+	assert.Equal(t, expected, drain(out))
+	assert.Equal(t, expected, observed)
+}
@@ -0,0 +1,22 @@
+package testutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeClock(t *testing.T) {
+	start := time.UnixMilli(1_700_000_000_000)
+	clock := NewFakeClock(start)
+	assert.Equal(t, int64(1_700_000_000_000), clock.Now())
+	assert.Equal(t, clock.Now(), clock.Now())
+
+	clock.Advance(1500 * time.Millisecond)
+	assert.Equal(t, int64(1_700_000_001_500), clock.Now())
+	assert.Equal(t, start.Add(1500*time.Millisecond), clock.Time())
+
+	clock.Set(time.UnixMilli(42))
+	assert.Equal(t, int64(42), clock.Now())
+}
@@ -0,0 +1,39 @@
+package testutil
+
+import (
+	"runtime"
+	"testing"
+	"time"
+)
+
+// goroutineSettleTimeout bounds how long VerifyNoGoroutineLeaks waits for
+// goroutines to exit after the test
+const goroutineSettleTimeout = 2 * time.Second
+
+// VerifyNoGoroutineLeaks records the number of running goroutines and fails
+// the test if more are still running once it and its deferred calls have
+// finished. Goroutines get a short grace period to exit, so the check only
+// catches goroutines that stay blocked. Close servers and clients before the
+// test returns, since idle connections hold goroutines, and do not use it in
+// parallel tests, whose goroutines would be counted.
+func VerifyNoGoroutineLeaks(t testing.TB) {
+	t.Helper()
+	before := runtime.NumGoroutine()
+
+	t.Cleanup(func() {
+		deadline := time.Now().Add(goroutineSettleTimeout)
+		for {
+			after := runtime.NumGoroutine()
+			if after <= before {
+				return
+			}
+			if time.Now().After(deadline) {
+				buf := make([]byte, 1<<20)
+				n := runtime.Stack(buf, true)
+				t.Errorf("%d goroutines still running after the test, %d before:\n%s", after, before, buf[:n])
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	})
+}
@@ -0,0 +1,42 @@
+package testutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingTB captures failures reported by the cleanup of VerifyNoGoroutineLeaks
+type recordingTB struct {
+	testing.TB
+	cleanups []func()
+	failed   bool
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Cleanup(f func()) { r.cleanups = append(r.cleanups, f) }
+
+func (r *recordingTB) Errorf(string, ...any) { r.failed = true }
+
+func TestVerifyNoGoroutineLeaks(t *testing.T) {
+	done := make(chan struct{})
+	rec := &recordingTB{TB: t}
+	VerifyNoGoroutineLeaks(rec)
+	go func() { <-done }()
+	go func() { close(done) }()
+	for _, cleanup := range rec.cleanups {
+		cleanup()
+	}
+	assert.False(t, rec.failed, "goroutines that exit must not be reported")
+
+	block := make(chan struct{})
+	defer close(block)
+	rec = &recordingTB{TB: t}
+	VerifyNoGoroutineLeaks(rec)
+	go func() { <-block }()
+	for _, cleanup := range rec.cleanups {
+		cleanup()
+	}
+	assert.True(t, rec.failed, "a blocked goroutine must be reported")
+}
@@ -0,0 +1,224 @@
+// Package testutil provides helpers for testing code built on the AG-UI SDK.
+package testutil
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/types"
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/encoding/sse"
+)
+
+// MockServer is an AG-UI endpoint backed by httptest.Server that answers run
+// requests with a scripted SSE event stream. Scripts are built from events,
+// pauses and connection drops, and are replayed for every request. When the
+// scripted events carry event IDs, a request with a Last-Event-ID header
+// resumes after that event, so reconnecting clients can be tested end to end.
+// It is safe for concurrent use.
+type MockServer struct {
+	*httptest.Server
+
+	mu         sync.Mutex
+	steps      []*scriptStep
+	eventDelay time.Duration
+	failures   []failure
+	requests   []RecordedRequest
+}
+
+// RecordedRequest is a run request received by a MockServer
+type RecordedRequest struct {
+	Header http.Header
+	Input  types.RunAgentInput
+}
+
+// scriptStep is a single scripted action; exactly one of its fields is set
+type scriptStep struct {
+	event events.Event
+	pause time.Duration
+	drop  bool
+
+	// dropped records that a drop step has fired, so that reconnects pass it
+	dropped bool
+}
+
+// failure is a scripted error response
+type failure struct {
+	status int
+	body   string
+}
+
+// MockServerOption defines options for creating mock servers
+type MockServerOption func(*MockServer)
+
+// WithEventDelay waits d before writing each scripted event
+func WithEventDelay(d time.Duration) MockServerOption {
+	return func(s *MockServer) {
+		s.eventDelay = d
+	}
+}
+
+// NewMockServer starts a mock server with an empty script. Call Close when done.
+func NewMockServer(options ...MockServerOption) *MockServer {
+	s := &MockServer{}
+	for _, opt := range options {
+		opt(s)
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+// Script appends events to the script
+func (s *MockServer) Script(evts ...events.Event) *MockServer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, event := range evts {
+		s.steps = append(s.steps, &scriptStep{event: event})
+	}
+	return s
+}
+
+// Pause appends a delay to the script
+func (s *MockServer) Pause(d time.Duration) *MockServer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.steps = append(s.steps, &scriptStep{pause: d})
+	return s
+}
+
+// Drop appends an abrupt connection close to the script. The client sees a
+// broken stream at that point. A drop fires only once, so a client that
+// reconnects receives the rest of the script.
+func (s *MockServer) Drop() *MockServer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.steps = append(s.steps, &scriptStep{drop: true})
+	return s
+}
+
+// FailNext answers the next n requests with the given status and body
+// instead of the script
+func (s *MockServer) FailNext(n int, status int, body string) *MockServer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := 0; i < n; i++ {
+		s.failures = append(s.failures, failure{status: status, body: body})
+	}
+	return s
+}
+
+// Reset clears the script, pending failures and recorded requests
+func (s *MockServer) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.steps = nil
+	s.failures = nil
+	s.requests = nil
+}
+
+// Requests returns the run requests received so far
+func (s *MockServer) Requests() []RecordedRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]RecordedRequest(nil), s.requests...)
+}
+
+// serve handles a single run request
+func (s *MockServer) serve(w http.ResponseWriter, r *http.Request) {
+	recorded := RecordedRequest{Header: r.Header.Clone()}
+	if err := json.NewDecoder(r.Body).Decode(&recorded.Input); err != nil {
+		http.Error(w, "invalid run input: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, recorded)
+	if len(s.failures) > 0 {
+		fail := s.failures[0]
+		s.failures = s.failures[1:]
+		s.mu.Unlock()
+		http.Error(w, fail.body, fail.status)
+		return
+	}
+	steps := append([]*scriptStep(nil), s.steps...)
+	s.mu.Unlock()
+
+	writer := sse.NewStreamWriter(w)
+	for _, step := range resumeAfter(steps, r.Header.Get("Last-Event-ID")) {
+		switch {
+		case step.event != nil:
+			if !s.sleep(r, s.eventDelay) {
+				return
+			}
+			if err := writer.WriteEvent(step.event); err != nil {
+				return
+			}
+		case step.drop:
+			if s.fireDrop(step) {
+				dropConnection(w)
+				return
+			}
+		default:
+			if !s.sleep(r, step.pause) {
+				return
+			}
+		}
+	}
+}
+
+// resumeAfter returns the steps following the event with the given ID, or
+// all steps when the ID is empty or unknown
+func resumeAfter(steps []*scriptStep, lastEventID string) []*scriptStep {
+	if lastEventID == "" {
+		return steps
+	}
+	for i, step := range steps {
+		if step.event != nil && step.event.GetBaseEvent().EventID == lastEventID {
+			return steps[i+1:]
+		}
+	}
+	return steps
+}
+
+// fireDrop reports whether a drop step should close the connection
+func (s *MockServer) fireDrop(step *scriptStep) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if step.dropped {
+		return false
+	}
+	step.dropped = true
+	return true
+}
+
+// sleep waits for d unless the client goes away first
+func (s *MockServer) sleep(r *http.Request, d time.Duration) bool {
+	if d <= 0 {
+		return r.Context().Err() == nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-r.Context().Done():
+		return false
+	}
+}
+
+// dropConnection closes the underlying connection without terminating the
+// chunked response, so the client sees an unexpected EOF
+func dropConnection(w http.ResponseWriter) {
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		panic("testutil: response writer does not support hijacking")
+	}
+	conn, _, err := hijacker.Hijack()
+	if err != nil {
+		return
+	}
+	_ = conn.Close()
+}
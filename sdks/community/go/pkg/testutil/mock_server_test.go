@@ -0,0 +1,120 @@
+package testutil
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/client/sse"
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runInput is the input used for every test run
+var runInput = types.RunAgentInput{
+	ThreadID: "thread-1",
+	RunID:    "run-1",
+	State:    map[string]any{},
+	Messages: []types.Message{},
+	Tools:    []types.Tool{},
+	Context:  []types.Context{},
+}
+
+// runTypes runs an agent against url and returns the received event types
+// and the stream error
+func runTypes(t *testing.T, url string, options ...sse.RunOption) ([]events.EventType, error) {
+	out, errs, err := sse.RunAgent(context.Background(), url, runInput, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	var received []events.EventType
+	for event := range out {
+		received = append(received, event.Type())
+	}
+	return received, <-errs
+}
+
+func TestMockServer_ServesScript(t *testing.T) {
+	server := NewMockServer(WithEventDelay(5 * time.Millisecond))
+	defer server.Close()
+	server.Script(
+		events.NewRunStartedEvent("thread-1", "run-1"),
+		events.NewTextMessageContentEvent("msg-1", "hi"),
+	).Pause(10 * time.Millisecond).Script(
+		events.NewRunFinishedEvent("thread-1", "run-1"),
+	)
+
+	start := time.Now()
+	received, err := runTypes(t, server.URL)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 25*time.Millisecond)
+	assert.Equal(t, []events.EventType{
+		events.EventTypeRunStarted,
+		events.EventTypeTextMessageContent,
+		events.EventTypeRunFinished,
+	}, received)
+
+	requests := server.Requests()
+	require.Len(t, requests, 1)
+	assert.Equal(t, "run-1", requests[0].Input.RunID)
+	assert.Equal(t, "text/event-stream", requests[0].Header.Get("Accept"))
+}
+
+func TestMockServer_DropAndResume(t *testing.T) {
+	server := NewMockServer()
+	defer server.Close()
+
+	script := func() {
+		ids := events.NewEventIDGenerator("")
+		server.Reset()
+		server.Script(
+			ids.Assign(events.NewRunStartedEvent("thread-1", "run-1")),
+			ids.Assign(events.NewStepStartedEvent("plan")),
+		).Drop().Script(
+			ids.Assign(events.NewStepFinishedEvent("plan")),
+			ids.Assign(events.NewRunFinishedEvent("thread-1", "run-1")),
+		)
+	}
+
+	// Without reconnecting the client sees the broken stream
+	script()
+	received, err := runTypes(t, server.URL)
+	require.Error(t, err)
+	assert.Len(t, received, 2)
+
+	script()
+	received, err = runTypes(t, server.URL, sse.WithReconnect(2, time.Millisecond))
+	require.NoError(t, err)
+	assert.Equal(t, []events.EventType{
+		events.EventTypeRunStarted,
+		events.EventTypeStepStarted,
+		events.EventTypeStepFinished,
+		events.EventTypeRunFinished,
+	}, received)
+
+	requests := server.Requests()
+	require.Len(t, requests, 2)
+	assert.Equal(t, "2", requests[1].Header.Get("Last-Event-ID"))
+}
+
+func TestMockServer_FailNext(t *testing.T) {
+	server := NewMockServer()
+	defer server.Close()
+	server.Script(
+		events.NewRunStartedEvent("thread-1", "run-1"),
+		events.NewRunErrorEvent("model overloaded"),
+	).FailNext(1, http.StatusServiceUnavailable, "try again")
+
+	_, err := runTypes(t, server.URL)
+	var statusErr *sse.StatusError
+	require.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, http.StatusServiceUnavailable, statusErr.StatusCode)
+
+	received, err := runTypes(t, server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, []events.EventType{events.EventTypeRunStarted, events.EventTypeRunError}, received)
+}
@@ -0,0 +1,78 @@
+// Package transport decouples AG-UI protocol logic from the wire. A Transport
+// sends and receives events over any connection, such as SSE over HTTP,
+// WebSockets or gRPC streams, and the helpers here connect transports to the
+// channel-based pipelines of package stream.
+package transport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+)
+
+// Transport carries AG-UI events over a connection. Recv returns io.EOF once
+// the peer has finished sending. One-way transports, such as Server-Sent
+// Events, return an error wrapping errors.ErrUnsupported for the direction
+// they cannot carry. Send and Recv may be called concurrently
+// with each other, but not each with itself.
+type Transport interface {
+	Send(event events.Event) error
+	Recv() (events.Event, error)
+	Close() error
+}
+
+// Send writes every event from in to t until in closes or ctx is cancelled.
+// It returns the first send error, or ctx.Err() after cancellation.
+func Send(ctx context.Context, t Transport, in <-chan events.Event) error {
+	for {
+		select {
+		case event, ok := <-in:
+			if !ok {
+				return nil
+			}
+			if err := t.Send(event); err != nil {
+				return fmt.Errorf("failed to send %s event: %w", event.Type(), err)
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Receive reads events from t into the returned channel, which closes once t
+// reports io.EOF, Recv fails or ctx is cancelled. A failure other than
+// io.EOF is sent on the error channel before both channels close.
+// Cancelling ctx does not interrupt a blocked Recv; close the transport to
+// release it.
+func Receive(ctx context.Context, t Transport) (<-chan events.Event, <-chan error) {
+	out := make(chan events.Event)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer func() {
+			close(out)
+			close(errs)
+		}()
+
+		for {
+			event, err := t.Recv()
+			if err != nil {
+				if !errors.Is(err, io.EOF) && ctx.Err() == nil {
+					errs <- err
+				}
+				return
+			}
+
+			select {
+			case out <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out, errs
+}
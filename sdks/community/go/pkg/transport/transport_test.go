@@ -0,0 +1,127 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/encoding/sse"
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var _ Transport = (*sse.Transport)(nil)
+
+// chanTransport is an in-memory transport, as a WebSocket implementation
+// would look to the helpers
+type chanTransport struct {
+	ch     chan events.Event
+	closed chan struct{}
+}
+
+func newChanTransport() *chanTransport {
+	return &chanTransport{ch: make(chan events.Event), closed: make(chan struct{})}
+}
+
+func (c *chanTransport) Send(event events.Event) error {
+	select {
+	case c.ch <- event:
+		return nil
+	case <-c.closed:
+		return io.ErrClosedPipe
+	}
+}
+
+func (c *chanTransport) Recv() (events.Event, error) {
+	select {
+	case event := <-c.ch:
+		return event, nil
+	case <-c.closed:
+		return nil, io.EOF
+	}
+}
+
+func (c *chanTransport) Close() error {
+	close(c.closed)
+	return nil
+}
+
+// source returns a closed channel holding the given events
+func source(evts ...events.Event) <-chan events.Event {
+	ch := make(chan events.Event, len(evts))
+	for _, event := range evts {
+		ch <- event
+	}
+	close(ch)
+	return ch
+}
+
+func TestSendReceive(t *testing.T) {
+	testutil.VerifyNoGoroutineLeaks(t)
+
+	tr := newChanTransport()
+	out, errs := Receive(context.Background(), tr)
+
+	go func() {
+		assert.NoError(t, Send(context.Background(), tr, source(
+			events.NewRunStartedEvent("thread-1", "run-1"),
+			events.NewRunFinishedEvent("thread-1", "run-1"),
+		)))
+		tr.Close()
+	}()
+
+	var types []events.EventType
+	for event := range out {
+		types = append(types, event.Type())
+	}
+	assert.NoError(t, <-errs)
+	assert.Equal(t, []events.EventType{events.EventTypeRunStarted, events.EventTypeRunFinished}, types)
+
+	err := Send(context.Background(), tr, source(events.NewStepStartedEvent("plan")))
+	assert.ErrorIs(t, err, io.ErrClosedPipe)
+	assert.Contains(t, err.Error(), "STEP_STARTED")
+}
+
+func TestSSETransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tr := sse.NewServerTransport(w)
+		defer tr.Close()
+
+		_, err := tr.Recv()
+		assert.ErrorIs(t, err, errors.ErrUnsupported)
+		assert.NoError(t, Send(r.Context(), tr, source(
+			events.NewRunStartedEvent("thread-1", "run-1"),
+			events.NewTextMessageContentEvent("msg-1", "hi"),
+			events.NewRunFinishedEvent("thread-1", "run-1"),
+		)))
+	}))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+	tr := sse.NewClientTransport(resp)
+	defer tr.Close()
+
+	assert.ErrorIs(t, tr.Send(events.NewStepStartedEvent("plan")), errors.ErrUnsupported)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	out, errs := Receive(ctx, tr)
+
+	var types []events.EventType
+	for event := range out {
+		types = append(types, event.Type())
+	}
+	require.NoError(t, <-errs)
+	assert.Equal(t, []events.EventType{
+		events.EventTypeRunStarted,
+		events.EventTypeTextMessageContent,
+		events.EventTypeRunFinished,
+	}, types)
+}
@@ -0,0 +1,265 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+	"github.com/gorilla/websocket"
+)
+
+// DefaultPingInterval is how often WebSocket transports ping an idle peer
+// when no interval is configured
+const DefaultPingInterval = 30 * time.Second
+
+// closeTimeout bounds how long Close waits to write its final frames
+const closeTimeout = time.Second
+
+// wsConfig holds the settings applied by WSOption values
+type wsConfig struct {
+	pingInterval time.Duration
+	closeEvent   func(run *events.RunStartedEvent) events.Event
+	checkOrigin  func(r *http.Request) bool
+}
+
+// WSOption defines options for creating WebSocket transports
+type WSOption func(*wsConfig)
+
+// WithPingInterval pings the peer every interval and treats a connection
+// that has not answered within two intervals as dead, failing Recv. Zero
+// disables keepalive. The default is DefaultPingInterval.
+func WithPingInterval(interval time.Duration) WSOption {
+	return func(c *wsConfig) {
+		c.pingInterval = interval
+	}
+}
+
+// WithCloseEvent sets the terminal event Close sends when a run started on
+// the transport has not finished. closeEvent receives the run's RUN_STARTED
+// event and may return nil to send nothing. By default a RUN_ERROR with the
+// CANCELLED code is sent.
+func WithCloseEvent(closeEvent func(run *events.RunStartedEvent) events.Event) WSOption {
+	return func(c *wsConfig) {
+		c.closeEvent = closeEvent
+	}
+}
+
+// WithCheckOrigin sets the function UpgradeWS uses to accept cross-origin
+// requests. By default only requests whose Origin matches the Host are
+// accepted.
+func WithCheckOrigin(checkOrigin func(r *http.Request) bool) WSOption {
+	return func(c *wsConfig) {
+		c.checkOrigin = checkOrigin
+	}
+}
+
+// wsTransport carries events over a WebSocket connection, one JSON encoded
+// event per text message
+type wsTransport struct {
+	conn   *websocket.Conn
+	config *wsConfig
+
+	mu      sync.Mutex
+	openRun *events.RunStartedEvent
+	closed  bool
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// DialWS connects to a WebSocket endpoint serving AG-UI events. header is
+// sent with the handshake request and may be nil.
+func DialWS(ctx context.Context, url string, header http.Header, options ...WSOption) (Transport, error) {
+	config := newWSConfig(options)
+	conn, resp, err := websocket.DefaultDialer.DialContext(ctx, url, header)
+	if err != nil {
+		if resp != nil {
+			return nil, fmt.Errorf("WebSocket handshake failed with status %d: %w", resp.StatusCode, err)
+		}
+		return nil, fmt.Errorf("WebSocket dial failed: %w", err)
+	}
+	return newWSTransport(conn, config), nil
+}
+
+// UpgradeWS upgrades an HTTP request to a WebSocket connection carrying
+// AG-UI events. On failure an HTTP error has already been written to w.
+func UpgradeWS(w http.ResponseWriter, r *http.Request, options ...WSOption) (Transport, error) {
+	config := newWSConfig(options)
+	upgrader := websocket.Upgrader{CheckOrigin: config.checkOrigin}
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return nil, fmt.Errorf("WebSocket upgrade failed: %w", err)
+	}
+	return newWSTransport(conn, config), nil
+}
+
+// newWSConfig applies options over the defaults
+func newWSConfig(options []WSOption) *wsConfig {
+	config := &wsConfig{pingInterval: DefaultPingInterval}
+	for _, opt := range options {
+		opt(config)
+	}
+	return config
+}
+
+// newWSTransport wraps an established connection and starts keepalive
+func newWSTransport(conn *websocket.Conn, config *wsConfig) *wsTransport {
+	t := &wsTransport{conn: conn, config: config}
+
+	if config.pingInterval > 0 {
+		// Any sign of life from the peer, including its own pings, keeps
+		// the connection open, so one side that never reads does not make
+		// the other time out
+		_ = t.extendDeadline()
+		conn.SetPongHandler(func(string) error {
+			return t.extendDeadline()
+		})
+		conn.SetPingHandler(func(data string) error {
+			err := conn.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(config.pingInterval))
+			if err != nil && !errors.Is(err, websocket.ErrCloseSent) {
+				var netErr net.Error
+				if !errors.As(err, &netErr) || !netErr.Timeout() {
+					return err
+				}
+			}
+			return t.extendDeadline()
+		})
+
+		t.stop = make(chan struct{})
+		t.done = make(chan struct{})
+		go t.pingLoop()
+	}
+	return t
+}
+
+// Send writes an event as a text message
+func (t *wsTransport) Send(event events.Event) error {
+	if event == nil {
+		return fmt.Errorf("event cannot be nil")
+	}
+	data, err := event.ToJSON()
+	if err != nil {
+		return fmt.Errorf("event encoding failed: %w", err)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return net.ErrClosed
+	}
+	if err := t.conn.WriteMessage(websocket.TextMessage, data); err != nil {
+		return fmt.Errorf("WebSocket write failed: %w", err)
+	}
+
+	switch e := event.(type) {
+	case *events.RunStartedEvent:
+		t.openRun = e
+	case *events.RunFinishedEvent, *events.RunErrorEvent:
+		t.openRun = nil
+	}
+	return nil
+}
+
+// Recv reads the next event. It returns io.EOF once the peer has closed the
+// connection normally.
+func (t *wsTransport) Recv() (events.Event, error) {
+	messageType, data, err := t.conn.ReadMessage()
+	if err != nil {
+		if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("WebSocket read failed: %w", err)
+	}
+	if t.config.pingInterval > 0 {
+		_ = t.extendDeadline()
+	}
+	if messageType != websocket.TextMessage {
+		return nil, fmt.Errorf("unexpected WebSocket message type %d", messageType)
+	}
+
+	event, err := events.EventFromJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode WebSocket event: %w", err)
+	}
+	return event, nil
+}
+
+// extendDeadline gives the peer two ping intervals to show it is alive
+func (t *wsTransport) extendDeadline() error {
+	return t.conn.SetReadDeadline(time.Now().Add(2 * t.config.pingInterval))
+}
+
+// Close ends a run that is still open with its terminal event, sends a
+// normal close frame and closes the connection. It is safe to call more
+// than once.
+func (t *wsTransport) Close() error {
+	t.mu.Lock()
+	if t.closed {
+		t.mu.Unlock()
+		return nil
+	}
+
+	var errs []error
+	if t.openRun != nil {
+		if terminal := t.terminalEvent(t.openRun); terminal != nil {
+			if data, err := terminal.ToJSON(); err != nil {
+				errs = append(errs, fmt.Errorf("terminal event encoding failed: %w", err))
+			} else if err := t.conn.WriteMessage(websocket.TextMessage, data); err != nil {
+				errs = append(errs, fmt.Errorf("failed to write terminal event: %w", err))
+			}
+		}
+		t.openRun = nil
+	}
+	t.closed = true
+	t.mu.Unlock()
+
+	if t.stop != nil {
+		close(t.stop)
+		<-t.done
+	}
+
+	message := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
+	if err := t.conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(closeTimeout)); err != nil && !errors.Is(err, websocket.ErrCloseSent) {
+		errs = append(errs, err)
+	}
+	if err := t.conn.Close(); err != nil && !errors.Is(err, net.ErrClosed) {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// terminalEvent returns the event that ends an open run on close
+func (t *wsTransport) terminalEvent(run *events.RunStartedEvent) events.Event {
+	if t.config.closeEvent != nil {
+		return t.config.closeEvent(run)
+	}
+	return events.NewRunErrorEvent(events.ErrRunCancelled.Error(),
+		events.WithErrorCode(events.RunErrorCodeCancelled),
+		events.WithRunID(run.RunID()))
+}
+
+// pingLoop pings the peer until the transport is closed or a ping fails
+func (t *wsTransport) pingLoop() {
+	defer close(t.done)
+
+	ticker := time.NewTicker(t.config.pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := t.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(t.config.pingInterval)); err != nil {
+				return
+			}
+		case <-t.stop:
+			return
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package transport
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newWSServer serves each WebSocket connection with handle and returns a
+// client transport connected to it
+func newWSServer(t *testing.T, handle func(tr Transport), options ...WSOption) Transport {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tr, err := UpgradeWS(w, r, options...)
+		if !assert.NoError(t, err) {
+			return
+		}
+		handle(tr)
+	}))
+	t.Cleanup(server.Close)
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+	client, err := DialWS(context.Background(), url, nil, options...)
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+// recvAll reads events until the transport reports an error
+func recvAll(tr Transport) ([]events.Event, error) {
+	var received []events.Event
+	for {
+		event, err := tr.Recv()
+		if err != nil {
+			return received, err
+		}
+		received = append(received, event)
+	}
+}
+
+func TestWSTransport(t *testing.T) {
+	client := newWSServer(t, func(tr Transport) {
+		defer tr.Close()
+
+		// The client sends a request event, the server streams the run
+		request, err := tr.Recv()
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, events.EventTypeCustom, request.Type())
+		assert.NoError(t, Send(context.Background(), tr, source(
+			events.NewRunStartedEvent("thread-1", "run-1"),
+			events.NewTextMessageContentEvent("msg-1", "hi"),
+			events.NewRunFinishedEvent("thread-1", "run-1"),
+		)))
+	})
+
+	require.NoError(t, client.Send(events.NewCustomEvent("request")))
+	received, err := recvAll(client)
+	assert.Equal(t, io.EOF, err)
+	require.Len(t, received, 3)
+	assert.Equal(t, "hi", received[1].(*events.TextMessageContentEvent).Delta)
+	assert.Equal(t, events.EventTypeRunFinished, received[2].Type())
+}
+
+func TestWSTransport_CloseEndsOpenRun(t *testing.T) {
+	t.Run("RunError", func(t *testing.T) {
+		client := newWSServer(t, func(tr Transport) {
+			assert.NoError(t, tr.Send(events.NewRunStartedEvent("thread-1", "run-1")))
+			assert.NoError(t, tr.Close())
+			assert.NoError(t, tr.Close())
+		})
+
+		received, err := recvAll(client)
+		assert.Equal(t, io.EOF, err)
+		require.Len(t, received, 2)
+		runErr, ok := received[1].(*events.RunErrorEvent)
+		require.True(t, ok)
+		assert.ErrorIs(t, runErr.AsError(), events.ErrRunCancelled)
+		assert.Equal(t, "run-1", runErr.RunID())
+	})
+
+	t.Run("CustomEvent", func(t *testing.T) {
+		finish := WithCloseEvent(func(run *events.RunStartedEvent) events.Event {
+			return events.NewRunFinishedEvent(run.ThreadID(), run.RunID())
+		})
+		client := newWSServer(t, func(tr Transport) {
+			assert.NoError(t, tr.Send(events.NewRunStartedEvent("thread-1", "run-1")))
+			assert.NoError(t, tr.Close())
+		}, finish)
+
+		received, err := recvAll(client)
+		assert.Equal(t, io.EOF, err)
+		require.Len(t, received, 2)
+		assert.Equal(t, events.EventTypeRunFinished, received[1].Type())
+	})
+}
+
+func TestWSTransport_Keepalive(t *testing.T) {
+	client := newWSServer(t, func(tr Transport) {
+		defer tr.Close()
+		// Stay idle for several ping intervals without reading
+		time.Sleep(150 * time.Millisecond)
+		assert.NoError(t, tr.Send(events.NewStepStartedEvent("plan")))
+	}, WithPingInterval(20*time.Millisecond))
+
+	event, err := client.Recv()
+	require.NoError(t, err, "pings keep the idle connection open")
+	assert.Equal(t, events.EventTypeStepStarted, event.Type())
+}